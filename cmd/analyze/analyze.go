@@ -0,0 +1,173 @@
+// Package analyze implementa el comando que analiza un proyecto existente y emite el
+// resultado como JSON, YAML o tabla.
+//
+// La salida JSON/YAML se puede pasar directamente a init --answers para inicializar el
+// proyecto sin repetir el análisis.
+package analyze
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/drossan/claude-init/internal/ai"
+	"github.com/drossan/claude-init/internal/analysis"
+	"github.com/drossan/claude-init/internal/logger"
+	"github.com/drossan/claude-init/internal/scanner"
+	"github.com/spf13/cobra"
+)
+
+// AnalyzeOptions contiene las opciones configurables del comando analyze.
+type AnalyzeOptions struct {
+	Format       string   // Format es el formato de salida: json, yaml o table
+	Output       string   // Output es el archivo de salida; vacío escribe en stdout
+	Offline      bool     // Offline usa solo el detector heurístico, sin IA
+	Provider     string   // Provider es el provider de IA; vacío usa el del proyecto o Claude CLI
+	Include      []string // Include son los globs de archivos a analizar
+	Exclude      []string // Exclude son los globs de archivos a omitir
+	ScanBudget   int      // ScanBudget es el presupuesto de tokens de la estructura de directorios
+	SampleBudget int      // SampleBudget es el presupuesto de tokens de las muestras de código
+	DocsBudget   int      // DocsBudget es el presupuesto de tokens de los resúmenes de documentación
+	Reanalyze    bool     // Reanalyze ignora el análisis en caché
+}
+
+// ScanOptions retorna las opciones de escaneo del proyecto.
+func (o *AnalyzeOptions) ScanOptions() scanner.Options {
+	return scanner.Options{
+		Include:      o.Include,
+		Exclude:      o.Exclude,
+		TokenBudget:  o.ScanBudget,
+		SampleBudget: o.SampleBudget,
+		DocsBudget:   o.DocsBudget,
+	}
+}
+
+// AnalysisOptions retorna las opciones del análisis del proyecto.
+func (o *AnalyzeOptions) AnalysisOptions() analysis.Options {
+	return analysis.Options{Scan: o.ScanOptions(), Reanalyze: o.Reanalyze}
+}
+
+// NewAnalyzeCommand crea una nueva instancia del comando analyze.
+func NewAnalyzeCommand() *cobra.Command {
+	opts := &AnalyzeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "analyze [path]",
+		Short: "Analyze a project and print the result as JSON, YAML or a table",
+		Long: `Analyze an existing project without generating anything.
+
+Runs the heuristic detector, the scanner and (unless --offline) the AI analyzer,
+and prints the project analysis: stack, dependencies, build/test/lint commands,
+CI, containers, infrastructure, documentation and the team's git habits.
+
+The JSON and YAML output can be passed to init:
+
+  claude-init analyze --format yaml > analysis.yaml
+  claude-init init --answers analysis.yaml
+
+AI analyses are cached per project and reused while the git HEAD and the
+dependency manifests do not change; use --reanalyze to refresh them.
+
+Progress messages are written to stderr, so the output can be piped.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAnalyze(cmd, opts, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Format, "format", "f", analysis.FormatTable, "Output format (json, yaml, table)")
+	cmd.Flags().StringVar(&opts.Output, "output", "", "Write the analysis to a file instead of stdout")
+	cmd.Flags().BoolVar(&opts.Offline, "offline", false, "Use only the heuristic detector, without AI")
+	cmd.Flags().StringVar(&opts.Provider, "provider", "", "AI provider (cli, openai, gemini, groq, claude-api, zai)")
+	cmd.Flags().StringSliceVar(&opts.Include, "include", nil, "Glob patterns of files to analyze (gitignore syntax)")
+	cmd.Flags().StringSliceVar(&opts.Exclude, "exclude", nil, "Glob patterns of files to skip during analysis (gitignore syntax)")
+	cmd.Flags().IntVar(&opts.ScanBudget, "scan-budget", scanner.DefaultTokenBudget, "Token budget for the directory structure sent to the AI")
+	cmd.Flags().IntVar(&opts.SampleBudget, "sample-budget", scanner.DefaultSampleBudget, "Token budget for the representative source samples sent to the AI")
+	cmd.Flags().IntVar(&opts.DocsBudget, "docs-budget", scanner.DefaultDocsBudget, "Token budget for the documentation summaries (ADRs, API specs, guides) sent to the AI")
+	cmd.Flags().BoolVar(&opts.Reanalyze, "reanalyze", false, "Ignore the cached analysis and analyze again")
+
+	return cmd
+}
+
+// runAnalyze ejecuta la lógica principal del comando analyze.
+func runAnalyze(cmd *cobra.Command, opts *AnalyzeOptions, args []string) error {
+	format := strings.ToLower(opts.Format)
+	if format != analysis.FormatJSON && format != analysis.FormatYAML && format != analysis.FormatTable {
+		return fmt.Errorf("unsupported format %q (use json, yaml or table)", opts.Format)
+	}
+
+	projectPath := "."
+	if len(args) > 0 {
+		projectPath = args[0]
+	}
+	projectPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve project path: %w", err)
+	}
+	if info, err := os.Stat(projectPath); err != nil || !info.IsDir() {
+		return fmt.Errorf("project path %s is not a directory", projectPath)
+	}
+
+	// Los mensajes van a stderr para que la salida se pueda redirigir o encadenar
+	log := logger.New(cmd.ErrOrStderr(), logger.WARNLevel)
+	if verbose, err := cmd.Flags().GetBool("verbose"); err == nil && verbose {
+		log.SetLevel(logger.DEBUGLevel)
+	}
+
+	var client ai.Client
+	if !opts.Offline {
+		client = createClient(projectPath, opts.Provider, log)
+		if client != nil {
+			defer client.Close()
+		}
+	}
+
+	report, err := analysis.Analyze(projectPath, client, opts.AnalysisOptions(), log)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if opts.Output != "" {
+		file, err := os.Create(opts.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	return analysis.Write(out, report, format)
+}
+
+// createClient crea el cliente de IA del provider indicado, el fijado en .claude-init.yaml
+// o Claude CLI. Retorna nil si no está configurado o disponible, y el análisis se hace sin IA.
+func createClient(projectPath, provider string, log *logger.Logger) ai.Client {
+	factory, settings, err := ai.NewProjectClientFactory(projectPath)
+	if err != nil {
+		log.Warn("Failed to load project settings, analyzing without AI: %v", err)
+		return nil
+	}
+
+	if provider == "" {
+		provider = settings.Provider
+	}
+	if provider == "" {
+		provider = string(ai.ProviderCLI)
+	}
+
+	client, err := factory.CreateClientFromString(provider)
+	if err != nil {
+		log.Warn("AI provider %s unavailable, analyzing without AI: %v", provider, err)
+		return nil
+	}
+	if available, err := client.IsAvailable(); err != nil || !available {
+		log.Warn("AI provider %s unavailable, analyzing without AI (configure it with: claude-init config --provider %s)", provider, provider)
+		client.Close()
+		return nil
+	}
+
+	log.Debug("Using AI provider %s", provider)
+	return client
+}
@@ -0,0 +1,79 @@
+package analyze
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/analysis"
+	"github.com/drossan/claude-init/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeProject crea un proyecto Go mínimo con Makefile y documentación.
+func writeProject(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":      "module example.com/billing\n\nrequire github.com/spf13/cobra v1.8.0\n",
+		"main.go":     "package main\n\nfunc main() {}\n",
+		"Makefile":    "build:\n\tgo build ./...\n\ntest:\n\tgo test ./...\n",
+		"docs/api.md": "# API\n",
+	}
+	for rel, content := range files {
+		path := filepath.Join(dir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+// TestAnalyzeCommand_JSON verifica que --offline --format json emite el análisis completo.
+func TestAnalyzeCommand_JSON(t *testing.T) {
+	dir := writeProject(t)
+
+	cmd := NewAnalyzeCommand()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{dir, "--offline", "--format", "json"})
+	require.NoError(t, cmd.Execute())
+
+	var report analysis.Report
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, "Go", report.Language)
+	assert.Equal(t, "Cobra", report.Framework)
+	assert.Equal(t, "heuristic", report.Source)
+	assert.Equal(t, []string{"docs"}, report.DocumentationDirs)
+	require.NotNil(t, report.Detection)
+	assert.Equal(t, []string{"github.com/spf13/cobra"}, report.Detection.Dependencies["go"])
+	assert.Equal(t, []string{"make test"}, report.Detection.Tooling.CommandsFor("test"))
+}
+
+// TestAnalyzeCommand_InvalidFormat verifica que se rechazan formatos desconocidos.
+func TestAnalyzeCommand_InvalidFormat(t *testing.T) {
+	cmd := NewAnalyzeCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{t.TempDir(), "--offline", "--format", "xml"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported format")
+}
+
+// TestAnalyzeOptions_ScanOptions verifica que los presupuestos de tokens, incluido el de la
+// documentación, llegan a las opciones de escaneo como en init.
+func TestAnalyzeOptions_ScanOptions(t *testing.T) {
+	cmd := NewAnalyzeCommand()
+	flag := cmd.Flags().Lookup("docs-budget")
+	require.NotNil(t, flag)
+	assert.Equal(t, strconv.Itoa(scanner.DefaultDocsBudget), flag.DefValue)
+
+	opts := &AnalyzeOptions{ScanBudget: 100, SampleBudget: 200, DocsBudget: 300}
+	assert.Equal(t, scanner.Options{TokenBudget: 100, SampleBudget: 200, DocsBudget: 300}, opts.ScanOptions())
+}
@@ -2,13 +2,20 @@ package generate
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/drossan/claude-init/cmd/version"
 	aifactory "github.com/drossan/claude-init/internal/ai"
 	"github.com/drossan/claude-init/internal/claude"
+	"github.com/drossan/claude-init/internal/hooks"
 	"github.com/drossan/claude-init/internal/logger"
+	"github.com/drossan/claude-init/internal/mcp"
+	"github.com/drossan/claude-init/internal/preview"
+	"github.com/drossan/claude-init/internal/review"
+	"github.com/drossan/claude-init/internal/snapshot"
 	"github.com/drossan/claude-init/internal/survey"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -24,6 +31,13 @@ var (
 	skillsFlag    bool
 	commandsFlag  bool
 	guidesFlag    bool
+	reanalyzeFlag bool
+	diffFormat    string
+	reviewFlag    bool
+	lintRetries   int
+	hooksFlag     []string
+	mcpFlag       []string
+	skillRefsFlag bool
 )
 
 var generateCmd = &cobra.Command{
@@ -38,15 +52,16 @@ Requires Claude CLI to be installed. Visit: https://claude.com/claude-code
 
 Files generated:
   - agents/       : Agent configurations (architect, developer, tester, etc.)
-  - skills/       : Language/framework specific skills
+  - skills/       : Language/framework specific skills (skills/<name>/SKILL.md)
   - commands/     : Custom commands
 
 Usage:
   claude-init generate                    # Use current directory
   claude-init generate ./my-project      # Use specific path
   claude-init generate --force            # Overwrite existing files
-  claude-init generate --dry-run          # Show what would be generated
+  claude-init generate --dry-run          # Preview the changes as a unified diff
   claude-init generate --only-agents      # Generate only agents
+  claude-init generate --review           # Review each file before writing it
 `,
 	Example: `  # Generate full configuration in current directory
   claude-init generate
@@ -57,9 +72,15 @@ Usage:
   # Generate only agents and skills
   claude-init generate --only-agents --only-skills
 
-  # Show what would be generated without creating files
+  # Preview the changes as a unified diff without writing files
   claude-init generate --dry-run
 
+  # Preview the changes as JSON for other tools
+  claude-init generate --dry-run --diff-format=json
+
+  # Accept, reject, edit or regenerate each file before writing it
+  claude-init generate --review
+
   # Overwrite existing files
   claude-init generate --force`,
 	Args: cobra.MaximumNArgs(1),
@@ -79,17 +100,34 @@ func GetGenerateCmd() *cobra.Command {
 
 func init() {
 	generateCmd.Flags().BoolVarP(&forceFlag, "force", "f", false, "overwrite existing files")
-	generateCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "show what would be generated without creating files")
+	generateCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "generate in a temporary directory and show the changes without writing files")
+	generateCmd.Flags().StringVar(&diffFormat, "diff-format", preview.FormatUnified, "format of the --dry-run preview (unified, json)")
+	generateCmd.Flags().BoolVar(&reviewFlag, "review", false, "accept, reject, edit or regenerate each generated file before writing it")
+	generateCmd.Flags().IntVar(&lintRetries, "lint-retries", claude.DefaultLintRetries, "times to ask the AI to fix a generated agent, skill or command that fails validation")
+	generateCmd.Flags().StringSliceVar(&hooksFlag, "hooks", nil, "Claude Code hooks to add to settings.json (format, lint, test, all)")
+	generateCmd.Flags().StringSliceVar(&mcpFlag, "mcp", nil, "MCP servers to add to .mcp.json (postgres, sqlite, mongodb, redis, docs, github, gitlab, all)")
+	generateCmd.Flags().BoolVar(&skillRefsFlag, "skill-references", false, "generate a cheat sheet and example snippets next to each skill's SKILL.md")
 	generateCmd.Flags().StringVar(&configDirFlag, "config-dir", "", "config directory (default: .claude/)")
 	generateCmd.Flags().StringVar(&outputDirFlag, "output-dir", "", "output directory (default: <project>/.claude/)")
 	generateCmd.Flags().BoolVar(&agentsFlag, "only-agents", false, "generate only agents")
 	generateCmd.Flags().BoolVar(&skillsFlag, "only-skills", false, "generate only skills")
 	generateCmd.Flags().BoolVar(&commandsFlag, "only-commands", false, "generate only commands")
 	generateCmd.Flags().BoolVar(&guidesFlag, "only-guides", false, "generate only guides")
+	generateCmd.Flags().BoolVar(&reanalyzeFlag, "reanalyze", false, "detect the project again instead of reusing the cached analysis")
 }
 
 func runGenerate(cmd *cobra.Command, args []string) error {
-	// Inicializar logger si es nil
+	if err := preview.ValidateFormat(diffFormat); err != nil {
+		return err
+	}
+	if reviewFlag && dryRunFlag {
+		return fmt.Errorf("--review and --dry-run cannot be combined")
+	}
+
+	// Inicializar logger si es nil; con la vista previa en JSON, el progreso va a stderr
+	if dryRunFlag && diffFormat == preview.FormatJSON {
+		log = logger.New(cmd.ErrOrStderr(), logger.INFOLevel)
+	}
 	if log == nil {
 		log = logger.New(os.Stdout, logger.INFOLevel)
 	}
@@ -122,24 +160,11 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	log.Debugf("Project path: %s", absPath)
 
 	// Cargar configuración existente del proyecto
-	projectConfig, err := loadProjectConfig(absPath)
+	answers, err := LoadAnswers(absPath)
 	if err != nil {
 		return fmt.Errorf("failed to load project config: %w (run 'claude-init init' first)", err)
 	}
 
-	// Convertir ProjectConfig a survey.Answers para usar con el generador
-	answers := &survey.Answers{
-		ProjectName:     projectConfig.ProjectName,
-		Description:     projectConfig.Description,
-		Language:        projectConfig.Language,
-		Framework:       projectConfig.Framework,
-		Architecture:    projectConfig.Architecture,
-		Database:        projectConfig.Database,
-		ProjectCategory: projectConfig.ProjectCategory,
-		BusinessContext: projectConfig.BusinessContext,
-		AIProvider:      projectConfig.AIProvider,
-	}
-
 	// Determinar el directorio de salida
 	outputDir := filepath.Join(absPath, ".claude")
 	if outputDirFlag != "" {
@@ -147,13 +172,19 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Verificar si ya existe la configuración
-	if _, err := os.Stat(outputDir); err == nil && !forceFlag {
+	if _, err := os.Stat(outputDir); err == nil && !forceFlag && !dryRunFlag {
 		return fmt.Errorf("configuration directory already exists: %s (use --force to overwrite)", outputDir)
 	}
 
-	// Crear cliente según el provider configurado
-	factory := aifactory.NewClientFactory()
-	client, err := factory.CreateClientFromString(projectConfig.AIProvider)
+	// Crear cliente según el provider configurado (.claude-init.yaml tiene prioridad)
+	factory, settings, err := aifactory.NewProjectClientFactory(absPath)
+	if err != nil {
+		return fmt.Errorf("failed to load project settings: %w", err)
+	}
+	if settings.Provider != "" {
+		answers.AIProvider = settings.Provider
+	}
+	client, err := factory.CreateClientFromString(answers.AIProvider)
 	if err != nil {
 		return fmt.Errorf("error creating AI client: %w", err)
 	}
@@ -161,6 +192,29 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	// Crear generador usando el cliente
 	generator := claude.NewGenerator(absPath, answers, client)
 	generator.SetLogger(log)
+	generator.SetProjectSettings(settings)
+	generator.SetToolVersion(version.Version)
+	generator.SetLintRetries(lintRetries)
+	generator.SetSkillReferences(skillRefsFlag)
+	if !reanalyzeFlag {
+		if cache, err := claude.NewAnalysisCache(absPath); err == nil {
+			generator.SetAnalysisCache(cache)
+		}
+	}
+
+	// Añadir los hooks elegidos con --hooks, mostrando qué hace cada uno
+	selected, err := hooks.Choose(log.StdLogger().Writer(), generator.AvailableHooks(), hooksFlag, nil)
+	if err != nil {
+		return fmt.Errorf("failed to select hooks: %w", err)
+	}
+	generator.SetHooks(selected)
+
+	// Añadir los servidores MCP elegidos con --mcp
+	servers, err := mcp.Choose(log.StdLogger().Writer(), generator.AvailableMCPServers(), mcpFlag, nil)
+	if err != nil {
+		return fmt.Errorf("failed to select MCP servers: %w", err)
+	}
+	generator.SetMCPServers(servers)
 
 	// Obtener recomendación usando AI provider
 	log.Info("Getting structure recommendations from AI provider...")
@@ -171,6 +225,11 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		recommendation = getDefaultRecommendation(answers)
 	}
 
+	// Aplicar include/exclude del proyecto
+	recommendation.Agents = settings.Agents.Apply(recommendation.Agents)
+	recommendation.Skills = settings.Skills.Apply(recommendation.Skills)
+	recommendation.Commands = settings.Commands.Apply(recommendation.Commands)
+
 	// Determinar qué generar
 	generateAgents := agentsFlag
 	generateSkills := skillsFlag
@@ -185,16 +244,29 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		generateGuides = true
 	}
 
-	// Generar según lo solicitado
+	// Generar en un staging que solo reemplaza la configuración actual si todo termina bien.
+	// Con --dry-run el staging queda fuera del proyecto y solo se compara con él.
+	newStage := snapshot.NewStage
 	if dryRunFlag {
-		return runDryRun(recommendation, outputDir, generateAgents, generateSkills, generateCommands, generateGuides)
+		newStage = snapshot.NewPreviewStage
 	}
+	stage, err := newStage(absPath, claude.ManagedPaths(absPath, answers.Packages))
+	if err != nil {
+		return err
+	}
+	defer stage.Discard()
+	generator.SetOutputDir(stage.Dir())
 
 	// Crear directorio base
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
+	if err := os.MkdirAll(filepath.Join(stage.Dir(), ".claude"), 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
+	// Pasar las skills planas de versiones anteriores a skills/<name>/SKILL.md
+	if _, err := generator.MigrateSkills(); err != nil {
+		log.Warn("Failed to migrate skills: %v", err)
+	}
+
 	// Generar agentes
 	if generateAgents {
 		for _, agent := range recommendation.Agents {
@@ -210,8 +282,10 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		for _, skill := range recommendation.Skills {
 			// Determinar tipo de skill basado en el contexto
 			skillType := "language"
-			if answers.Framework != "" && strings.EqualFold(skill, answers.Framework) {
-				skillType = "framework"
+			for _, framework := range answers.Frameworks() {
+				if strings.EqualFold(skill, normalizeSkillName(framework)) {
+					skillType = "framework"
+				}
 			}
 			if err := generator.GenerateSkill(skillType, skill); err != nil {
 				log.Warn("Failed to generate skill %s: %v", skill, err)
@@ -230,62 +304,74 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		log.Info("✓ Commands generated")
 	}
 
+	// Generar settings.json con los permisos de las herramientas y los hooks elegidos
+	if err := generator.GenerateSettings(); err != nil {
+		log.Warn("Failed to generate settings.json: %v", err)
+	}
+	if err := generator.GenerateMCP(); err != nil {
+		log.Warn("Failed to generate .mcp.json: %v", err)
+	}
+
 	// Generar guías (no implementado todavía, solo placeholder)
 	if generateGuides {
 		log.Info("Guides generation not yet implemented")
 	}
 
-	log.Info("✓ Configuration generated successfully at: %s", outputDir)
-	return nil
-}
-
-func runDryRun(rec *claude.Recommendation, outputDir string, agents, skills, commands, guides bool) error {
-	log.Info("Dry run mode - showing what would be generated:")
-	log.Info("Output directory: %s", outputDir)
-	log.Info("")
-
-	if agents {
-		log.Info("Would generate agents:")
-		for _, agent := range rec.Agents {
-			log.Info("  - %s.md", agent)
-		}
+	if err := generator.SaveManifest(); err != nil {
+		log.Warn("Failed to save the generation manifest: %v", err)
 	}
 
-	if skills {
-		log.Info("Would generate skills:")
-		for _, skill := range rec.Skills {
-			log.Info("  - %s.md", skill)
-		}
+	if dryRunFlag {
+		return writePreview(cmd.OutOrStdout(), absPath, stage, diffFormat)
 	}
 
-	if commands {
-		log.Info("Would generate commands:")
-		for _, cmd := range rec.Commands {
-			log.Info("  - %s.md", cmd)
+	// Revisar cada archivo antes de confirmar el staging en el proyecto
+	if reviewFlag {
+		summary, err := review.NewReviewer(cmd.OutOrStdout(), review.SurveyPrompter{}).Review(absPath, stage.Dir(), generator)
+		if err != nil {
+			return fmt.Errorf("review failed, nothing was written: %w", err)
 		}
+		log.Info("%s", summary)
 	}
 
-	if guides {
-		log.Info("Would generate guides:")
-		log.Info("  - development_guide.md")
+	store, err := snapshot.NewStore(absPath)
+	if err != nil {
+		return err
+	}
+	if _, err := stage.Commit(store, "generate"); err != nil {
+		return err
 	}
 
+	log.Info("✓ Configuration generated successfully at: %s", outputDir)
 	return nil
 }
 
+// writePreview escribe en out los cambios que aplicaría la generación hecha en el staging.
+func writePreview(out io.Writer, projectPath string, stage *snapshot.Stage, format string) error {
+	changes, err := preview.Compare(projectPath, stage.Dir(), stage.Paths(), claude.InternalPaths())
+	if err != nil {
+		return err
+	}
+	return preview.Write(out, changes, format, preview.ColorEnabled(out))
+}
+
 // ProjectConfig representa la configuración del proyecto guardada por init.
 type ProjectConfig struct {
-	ProjectOrigin   string `yaml:"project_origin" json:"project_origin"`
-	ProjectName     string `yaml:"project_name" json:"project_name"`
-	Description     string `yaml:"description" json:"description"`
-	Language        string `yaml:"language" json:"language"`
-	Framework       string `yaml:"framework" json:"framework"`
-	Architecture    string `yaml:"architecture" json:"architecture"`
-	Database        string `yaml:"database" json:"database"`
-	ProjectCategory string `yaml:"project_category" json:"project_category"`
-	BusinessContext string `yaml:"business_context" json:"business_context"`
-	AIProvider      string `yaml:"ai_provider" json:"ai_provider"`
-	CreatedAt       string `yaml:"created_at" json:"created_at"`
+	ProjectOrigin   string            `yaml:"project_origin" json:"project_origin"`
+	ProjectName     string            `yaml:"project_name" json:"project_name"`
+	Description     string            `yaml:"description" json:"description"`
+	Language        string            `yaml:"language" json:"language"`
+	Framework       string            `yaml:"framework" json:"framework"`
+	Architecture    string            `yaml:"architecture" json:"architecture"`
+	Database        string            `yaml:"database" json:"database"`
+	ProjectCategory string            `yaml:"project_category" json:"project_category"`
+	BusinessContext string            `yaml:"business_context" json:"business_context"`
+	AIProvider      string            `yaml:"ai_provider" json:"ai_provider"`
+	FollowUps       []survey.FollowUp `yaml:"follow_ups,omitempty" json:"follow_ups,omitempty"`
+	Conventions     []string          `yaml:"conventions,omitempty" json:"conventions,omitempty"`
+	Extra           map[string]string `yaml:"extra,omitempty" json:"extra,omitempty"`
+	Packages        []string          `yaml:"packages,omitempty" json:"packages,omitempty"`
+	CreatedAt       string            `yaml:"created_at" json:"created_at"`
 }
 
 // loadProjectConfig carga la configuración del proyecto desde .claude/
@@ -312,6 +398,33 @@ func loadProjectConfig(projectPath string) (*ProjectConfig, error) {
 	return &config, nil
 }
 
+// LoadAnswers carga las respuestas guardadas por init en .claude/project.yaml.
+func LoadAnswers(projectPath string) (*survey.Answers, error) {
+	projectConfig, err := loadProjectConfig(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convertir ProjectConfig a survey.Answers para usar con el generador
+	answers := &survey.Answers{
+		ProjectName:     projectConfig.ProjectName,
+		Description:     projectConfig.Description,
+		Language:        projectConfig.Language,
+		Framework:       projectConfig.Framework,
+		Architecture:    projectConfig.Architecture,
+		Database:        projectConfig.Database,
+		ProjectCategory: projectConfig.ProjectCategory,
+		BusinessContext: projectConfig.BusinessContext,
+		AIProvider:      projectConfig.AIProvider,
+		FollowUps:       projectConfig.FollowUps,
+		Conventions:     projectConfig.Conventions,
+		Extra:           projectConfig.Extra,
+		Packages:        projectConfig.Packages,
+	}
+	answers.Normalize()
+	return answers, nil
+}
+
 // getDefaultRecommendation retorna una recomendación por defecto basada en las respuestas.
 func getDefaultRecommendation(answers *survey.Answers) *claude.Recommendation {
 	agents := []string{"architect", "developer", "tester", "reviewer"}
@@ -320,16 +433,20 @@ func getDefaultRecommendation(answers *survey.Answers) *claude.Recommendation {
 
 	// Agregar agent de debugger solo para arquitecturas complejas
 	complexArchitectures := []string{"Microservicios", "DDD", "Hexagonal", "Event-Driven", "Serverless"}
+	architecture := survey.NormalizeChoice("architecture", answers.Architecture)
 	for _, arch := range complexArchitectures {
-		if answers.Architecture == arch {
+		if architecture == arch {
 			agents = append(agents, "debugger")
 			break
 		}
 	}
 
-	// Agregar skill de framework si existe
-	if answers.Framework != "" {
-		skills = append(skills, strings.ToLower(answers.Framework))
+	// Agregar una skill por cada framework y base de datos seleccionados
+	for _, framework := range answers.Frameworks() {
+		skills = append(skills, normalizeSkillName(framework))
+	}
+	for _, database := range answers.Databases() {
+		skills = append(skills, normalizeSkillName(database))
 	}
 
 	description := fmt.Sprintf("Default structure for %s %s project", answers.Language, answers.ProjectCategory)
@@ -1,12 +1,15 @@
 package generate
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/drossan/claude-init/internal/claude"
 	"github.com/drossan/claude-init/internal/logger"
+	"github.com/drossan/claude-init/internal/preview"
+	"github.com/drossan/claude-init/internal/snapshot"
 	"github.com/drossan/claude-init/internal/survey"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -36,6 +39,8 @@ func TestGenerateFlags(t *testing.T) {
 	requiredFlags := []string{
 		"force",
 		"dry-run",
+		"diff-format",
+		"review",
 		"config-dir",
 		"output-dir",
 		"only-agents",
@@ -192,23 +197,21 @@ func TestGetDefaultRecommendation_GeneratesValidRecommendation(t *testing.T) {
 	}
 }
 
-func TestRunDryRun_DoesNotCreateFiles(t *testing.T) {
+func TestWritePreview_DoesNotCreateFiles(t *testing.T) {
 	tempDir := t.TempDir()
 
-	// Crear logger
-	log = logger.New(os.Stdout, logger.INFOLevel)
-
-	// Crear recomendación de prueba
-	rec := &claude.Recommendation{
-		Agents:      []string{"architect", "developer"},
-		Commands:    []string{"test", "build"},
-		Skills:      []string{"go"},
-		Description: "Test recommendation",
-	}
-
-	// Ejecutar dry-run
-	err := runDryRun(rec, filepath.Join(tempDir, ".claude"), true, true, true, true)
-	assert.NoError(t, err)
+	// Generar en un staging de vista previa, fuera del proyecto
+	stage, err := snapshot.NewPreviewStage(tempDir, claude.ManagedPaths(tempDir, nil))
+	require.NoError(t, err)
+	defer stage.Discard()
+	agentPath := filepath.Join(stage.Dir(), ".claude", "agents", "architect.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(agentPath), 0755))
+	require.NoError(t, os.WriteFile(agentPath, []byte("# Architect\n"), 0644))
+
+	var out bytes.Buffer
+	require.NoError(t, writePreview(&out, tempDir, stage, preview.FormatUnified))
+	assert.Contains(t, out.String(), "architect.md (12 B)")
+	assert.Contains(t, out.String(), "1 file(s): 1 added")
 
 	// Verificar que NO se creó el directorio
 	claudeDir := filepath.Join(tempDir, ".claude")
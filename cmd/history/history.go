@@ -0,0 +1,83 @@
+// Package history implementa el comando que lista las instantáneas de las generaciones
+// anteriores de un proyecto, las que undo puede restaurar.
+package history
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/drossan/claude-init/internal/snapshot"
+	"github.com/spf13/cobra"
+)
+
+// NewHistoryCommand crea una nueva instancia del comando history.
+func NewHistoryCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "history [path]",
+		Short: "List the snapshots of previous generations",
+		Long: `List the snapshots of the configuration of a project.
+
+Every run of init, generate, update and undo writes to a staging directory and
+swaps it in only when it finishes. The previous .claude/ and CLAUDE.md files
+are kept as a timestamped snapshot outside the project (in the user cache
+directory). The latest snapshots are listed, newest first; restore any of them
+with 'claude-init undo --to <id>'.`,
+		Example: `  # List the snapshots of the current directory
+  claude-init history`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectPath, err := ProjectPath(args)
+			if err != nil {
+				return err
+			}
+			store, err := snapshot.NewStore(projectPath)
+			if err != nil {
+				return err
+			}
+			return History(cmd.OutOrStdout(), store)
+		},
+	}
+}
+
+// History escribe en out las instantáneas del almacén, de la más reciente a la más antigua.
+func History(out io.Writer, store *snapshot.Store) error {
+	snapshots, err := store.List()
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 {
+		fmt.Fprintln(out, "No snapshots yet: they are taken every time the configuration is generated.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tDATE\tREPLACED BY\tFILES\tSTATUS")
+	for _, s := range snapshots {
+		status := ""
+		if s.Restored() {
+			status = "restored " + s.RestoredAt.Local().Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", s.ID, s.CreatedAt.Local().Format("2006-01-02 15:04:05"), s.Command, len(s.Paths), status)
+	}
+	return w.Flush()
+}
+
+// ProjectPath retorna la ruta absoluta del proyecto indicado en args (por defecto, el
+// directorio actual) y verifica que es un directorio.
+func ProjectPath(args []string) (string, error) {
+	projectPath := "."
+	if len(args) > 0 {
+		projectPath = args[0]
+	}
+	projectPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve project path: %w", err)
+	}
+	if info, err := os.Stat(projectPath); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("project path %s is not a directory", projectPath)
+	}
+	return projectPath, nil
+}
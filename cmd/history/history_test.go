@@ -0,0 +1,43 @@
+package history
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHistory verifica el listado de instantáneas, con y sin generaciones previas.
+func TestHistory(t *testing.T) {
+	project := t.TempDir()
+	store := snapshot.NewStoreWithDir(project, t.TempDir())
+
+	var out bytes.Buffer
+	require.NoError(t, History(&out, store))
+	assert.Contains(t, out.String(), "No snapshots yet")
+
+	require.NoError(t, os.WriteFile(filepath.Join(project, "CLAUDE.md"), []byte("# Project\n"), 0644))
+	taken, err := store.Take("generate", []string{".claude", "CLAUDE.md"})
+	require.NoError(t, err)
+
+	out.Reset()
+	require.NoError(t, History(&out, store))
+	assert.Regexp(t, `ID\s+DATE\s+REPLACED BY\s+FILES\s+STATUS`, out.String())
+	assert.Regexp(t, taken.ID+`\s+\S+ \S+\s+generate\s+1`, out.String())
+}
+
+// TestProjectPath verifica la resolución de la ruta del proyecto.
+func TestProjectPath(t *testing.T) {
+	dir := t.TempDir()
+
+	path, err := ProjectPath([]string{dir})
+	require.NoError(t, err)
+	assert.Equal(t, dir, path)
+
+	_, err = ProjectPath([]string{filepath.Join(dir, "missing")})
+	assert.ErrorContains(t, err, "is not a directory")
+}
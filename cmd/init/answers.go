@@ -0,0 +1,263 @@
+package init
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/drossan/claude-init/internal/ai"
+	"github.com/drossan/claude-init/internal/analysis"
+	"github.com/drossan/claude-init/internal/survey"
+	"gopkg.in/yaml.v3"
+)
+
+// StdinAnswers es el valor de --answers que indica leer las respuestas en JSON desde stdin.
+const StdinAnswers = "-"
+
+// conventionsKey es la clave de las convenciones de código en las respuestas. Se unen
+// con saltos de línea en lugar de comas porque cada convención puede contener comas.
+const conventionsKey = analysis.ConventionsKey
+
+// AnswerFlags contiene las respuestas pasadas individualmente como flags.
+type AnswerFlags struct {
+	Origin            string
+	Name              string
+	Description       string
+	Language          string
+	Framework         string
+	Architecture      string
+	Database          string
+	Category          string
+	BusinessContext   string
+	Provider          string
+	DocumentationDirs []string
+	Packages          []string
+}
+
+// toMap convierte los flags al formato de survey.Answers.ToMap, omitiendo los vacíos.
+func (f AnswerFlags) toMap() map[string]string {
+	values := map[string]string{
+		"project_origin":     f.Origin,
+		"project_name":       f.Name,
+		"description":        f.Description,
+		"language":           f.Language,
+		"framework":          f.Framework,
+		"architecture":       f.Architecture,
+		"database":           f.Database,
+		"project_category":   f.Category,
+		"business_context":   f.BusinessContext,
+		"ai_provider":        f.Provider,
+		"documentation_dirs": strings.Join(f.DocumentationDirs, ","),
+		"packages":           strings.Join(f.Packages, ","),
+	}
+
+	for key, value := range values {
+		if value == "" {
+			delete(values, key)
+		}
+	}
+
+	return values
+}
+
+// IsNonInteractive indica si init debe ejecutarse sin prompts.
+func (o *InitOptions) IsNonInteractive() bool {
+	return o.AnswersFile != "" || o.Yes || len(o.Answers.toMap()) > 0
+}
+
+// collectAnswers combina el archivo de respuestas con los flags individuales.
+// Los flags tienen prioridad sobre el archivo. questions son las preguntas del survey, cuyos
+// IDs son las claves personalizadas que acepta el archivo.
+func (o *InitOptions) collectAnswers(stdin io.Reader, questions []*survey.Question) (map[string]string, error) {
+	answers := make(map[string]string)
+
+	if o.AnswersFile != "" {
+		loaded, err := loadAnswersFile(o.AnswersFile, stdin, questions)
+		if err != nil {
+			return nil, err
+		}
+		answers = loaded
+	}
+
+	for key, value := range o.Answers.toMap() {
+		answers[key] = value
+	}
+
+	return answers, nil
+}
+
+// loadAnswersFile lee las respuestas desde un archivo YAML o JSON.
+// Si path es "-", lee JSON desde stdin. Las claves deben ser respuestas predefinidas o IDs
+// de questions.
+func loadAnswersFile(path string, stdin io.Reader, questions []*survey.Question) (map[string]string, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	if path == StdinAnswers {
+		data, err = io.ReadAll(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read answers from stdin: %w", err)
+		}
+	} else {
+		data, err = os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read answers file: %w", err)
+		}
+	}
+
+	isJSON := path == StdinAnswers || strings.EqualFold(filepath.Ext(path), ".json")
+	unmarshal := yaml.Unmarshal
+	if isJSON {
+		unmarshal = json.Unmarshal
+	}
+
+	raw := make(map[string]interface{})
+	if err := unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse answers: %w", err)
+	}
+
+	if analysis.IsReport(raw) {
+		var report analysis.Report
+		if err := unmarshal(data, &report); err != nil {
+			return nil, fmt.Errorf("failed to parse analysis: %w", err)
+		}
+		return report.AnswerValues(), nil
+	}
+
+	return normalizeAnswerValues(raw, questions)
+}
+
+// normalizeAnswerValues convierte los valores del archivo de respuestas a strings.
+// Las listas (por ejemplo documentation_dirs) se unen con comas, salvo las convenciones,
+// que se unen con saltos de línea. Las claves de preguntas personalizadas se guardan como
+// respuestas personalizadas; las que no corresponden a ninguna pregunta son un error.
+func normalizeAnswerValues(raw map[string]interface{}, questions []*survey.Question) (map[string]string, error) {
+	known := knownAnswerKeys(questions)
+	answers := make(map[string]string, len(raw))
+
+	var unknown []string
+	for key, value := range raw {
+		if !known[key] {
+			unknown = append(unknown, key)
+			continue
+		}
+
+		switch v := value.(type) {
+		case nil:
+			continue
+		case string:
+			answers[key] = strings.TrimSpace(v)
+		case []interface{}:
+			parts := make([]string, 0, len(v))
+			for _, item := range v {
+				parts = append(parts, strings.TrimSpace(fmt.Sprint(item)))
+			}
+			separator := ","
+			if key == conventionsKey {
+				separator = "\n"
+			}
+			answers[key] = strings.Join(parts, separator)
+		default:
+			answers[key] = fmt.Sprint(v)
+		}
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown answer keys: %s", strings.Join(unknown, ", "))
+	}
+
+	return answers, nil
+}
+
+// knownAnswerKeys retorna las claves que acepta un archivo de respuestas: las predefinidas,
+// las convenciones y los IDs de las preguntas del survey.
+func knownAnswerKeys(questions []*survey.Question) map[string]bool {
+	known := map[string]bool{conventionsKey: true}
+	for key := range (&survey.Answers{}).ToMap() {
+		known[key] = true
+	}
+	for _, question := range questions {
+		known[question.ID] = true
+	}
+	return known
+}
+
+// normalizeProjectOrigin acepta "new"/"existing" además de los valores del survey.
+func normalizeProjectOrigin(origin string) string {
+	switch strings.ToLower(strings.TrimSpace(origin)) {
+	case "nuevo", "new":
+		return "Nuevo"
+	case "existente", "existing":
+		return "Existente"
+	default:
+		return origin
+	}
+}
+
+// runNonInteractiveFlow construye las respuestas sin prompts a partir de las respuestas explícitas.
+//
+// Con acceptAnalysis (--yes), un proyecto existente se analiza primero y las respuestas
+// explícitas se aplican encima del resultado del análisis.
+func runNonInteractiveFlow(projectPath string, client ai.Client, explicit map[string]string, acceptAnalysis bool, analysisOpts analysis.Options) (*survey.Answers, error) {
+	origin := normalizeProjectOrigin(explicit["project_origin"])
+	if origin == "" {
+		origin = "Nuevo"
+		if acceptAnalysis {
+			origin = "Existente"
+		}
+	}
+
+	base := &survey.Answers{ProjectOrigin: origin}
+
+	if origin == "Existente" && acceptAnalysis {
+		log.Info("\nAnalizando proyecto existente...")
+
+		result, err := analysis.NewAnalyzer(projectPath, client, analysisOpts, log).Analyze()
+		if err != nil {
+			log.Warn("Project analysis failed: %v", err)
+		} else {
+			base = analysis.Answers(result)
+		}
+
+		base.DocumentationDirs = detectDocumentationDirs(projectPath)
+	}
+
+	merged := base.ToMap()
+	for key, value := range explicit {
+		merged[key] = value
+	}
+	merged["project_origin"] = origin
+
+	// Las convenciones explícitas (por ejemplo de un análisis guardado) sustituyen a las del análisis
+	conventions := base.Conventions
+	if value, ok := merged[conventionsKey]; ok {
+		conventions = splitLines(value)
+		delete(merged, conventionsKey)
+	}
+
+	answers := &survey.Answers{}
+	answers.FromMap(merged)
+	answers.Normalize()
+	answers.Packages = resolvePackages(projectPath, answers.Packages)
+	answers.Conventions = conventions
+
+	return answers, nil
+}
+
+// splitLines divide un valor en líneas no vacías.
+func splitLines(value string) []string {
+	var lines []string
+	for _, line := range strings.Split(value, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,161 @@
+package init
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/analysis"
+	"github.com/drossan/claude-init/internal/claude"
+	"github.com/drossan/claude-init/internal/logger"
+	"github.com/drossan/claude-init/internal/survey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoadAnswersFile_YAML verifica que se cargan respuestas desde YAML.
+func TestLoadAnswersFile_YAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.yaml")
+	content := `project_name: billing-api
+language: Go
+documentation_dirs:
+  - docs
+  - adr
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	answers, err := loadAnswersFile(path, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "billing-api", answers["project_name"])
+	assert.Equal(t, "Go", answers["language"])
+	assert.Equal(t, "docs,adr", answers["documentation_dirs"])
+}
+
+// TestLoadAnswersFile_JSON verifica que se cargan respuestas desde JSON.
+func TestLoadAnswersFile_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"project_name":"web","framework":"Next.js"}`), 0644))
+
+	answers, err := loadAnswersFile(path, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "web", answers["project_name"])
+	assert.Equal(t, "Next.js", answers["framework"])
+}
+
+// TestLoadAnswersFile_Stdin verifica que "-" lee JSON desde stdin.
+func TestLoadAnswersFile_Stdin(t *testing.T) {
+	answers, err := loadAnswersFile(StdinAnswers, strings.NewReader(`{"language":"Python"}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Python", answers["language"])
+}
+
+// TestLoadAnswersFile_UnknownKey_ReturnsError verifica que las claves desconocidas se rechazan.
+func TestLoadAnswersFile_UnknownKey_ReturnsError(t *testing.T) {
+	_, err := loadAnswersFile(StdinAnswers, strings.NewReader(`{"projct_name":"typo"}`), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "projct_name")
+}
+
+// TestLoadAnswersFile_CustomKeys verifica que se conservan las claves de las preguntas
+// personalizadas.
+func TestLoadAnswersFile_CustomKeys(t *testing.T) {
+	questions := []*survey.Question{{ID: "team_size"}, {ID: "compliance"}}
+	answers, err := loadAnswersFile(StdinAnswers, strings.NewReader(`{"team_size":5,"compliance":"GDPR"}`), questions)
+	require.NoError(t, err)
+	assert.Equal(t, "5", answers["team_size"])
+	assert.Equal(t, "GDPR", answers["compliance"])
+
+	_, err = loadAnswersFile(StdinAnswers, strings.NewReader(`{"team_sise":5}`), questions)
+	assert.ErrorContains(t, err, "unknown answer keys: team_sise")
+}
+
+// TestLoadAnswersFile_AnalysisReport verifica que init --answers acepta la salida de analyze.
+func TestLoadAnswersFile_AnalysisReport(t *testing.T) {
+	log = logger.New(io.Discard, logger.INFOLevel)
+
+	report := &analysis.Report{
+		ProjectAnalysis: claude.ProjectAnalysis{
+			Name:            "billing-api",
+			Description:     "Billing service",
+			Language:        "Go",
+			Architecture:    "Hexagonal",
+			ProjectCategory: "API REST",
+			BusinessContext: "Facturación recurrente",
+			Conventions:     []string{"Errors wrapped with %w, never ignored", "Table-driven tests"},
+			Source:          claude.AnalysisSourceHeuristic,
+		},
+		DocumentationDirs: []string{"docs"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, analysis.Write(&buf, report, analysis.FormatYAML))
+	path := filepath.Join(t.TempDir(), "analysis.yaml")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+
+	explicit, err := loadAnswersFile(path, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "billing-api", explicit["project_name"])
+	assert.Equal(t, "Existente", explicit["project_origin"])
+	assert.Equal(t, "docs", explicit["documentation_dirs"])
+	assert.NotContains(t, explicit, "source")
+
+	answers, err := runNonInteractiveFlow(t.TempDir(), &mockClient{}, explicit, false, analysis.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, report.Conventions, answers.Conventions)
+	assert.Empty(t, answers.Extra)
+	assert.NoError(t, answers.Validate())
+}
+
+// TestCollectAnswers_FlagsOverrideFile verifica que los flags tienen prioridad sobre el archivo.
+func TestCollectAnswers_FlagsOverrideFile(t *testing.T) {
+	opts := &InitOptions{
+		AnswersFile: StdinAnswers,
+		Answers:     AnswerFlags{Language: "Rust"},
+	}
+
+	answers, err := opts.collectAnswers(strings.NewReader(`{"project_name":"cli","language":"Go"}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "cli", answers["project_name"])
+	assert.Equal(t, "Rust", answers["language"])
+}
+
+// TestInitOptions_IsNonInteractive verifica cuándo se activa el modo no interactivo.
+func TestInitOptions_IsNonInteractive(t *testing.T) {
+	assert.False(t, (&InitOptions{}).IsNonInteractive())
+	assert.True(t, (&InitOptions{Yes: true}).IsNonInteractive())
+	assert.True(t, (&InitOptions{AnswersFile: "answers.yaml"}).IsNonInteractive())
+	assert.True(t, (&InitOptions{Answers: AnswerFlags{Name: "api"}}).IsNonInteractive())
+}
+
+// TestRunNonInteractiveFlow_NewProject verifica que se construyen respuestas válidas sin prompts.
+func TestRunNonInteractiveFlow_NewProject(t *testing.T) {
+	log = logger.New(os.Stdout, logger.INFOLevel)
+
+	explicit := map[string]string{
+		"project_origin":   "new",
+		"project_name":     "billing-api",
+		"description":      "Billing service",
+		"language":         "Go",
+		"architecture":     "Hexagonal",
+		"project_category": "API REST",
+		"business_context": "Facturación recurrente para clientes B2B",
+	}
+
+	answers, err := runNonInteractiveFlow(t.TempDir(), &mockClient{}, explicit, false, analysis.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "Nuevo", answers.ProjectOrigin)
+	assert.Equal(t, "billing-api", answers.ProjectName)
+	assert.NoError(t, answers.Validate())
+}
+
+// TestNewInitCommand_HasNonInteractiveFlags verifica los flags del modo no interactivo.
+func TestNewInitCommand_HasNonInteractiveFlags(t *testing.T) {
+	cmd := NewInitCommand()
+
+	for _, flag := range []string{"answers", "yes", "name", "language", "framework", "provider", "docs", "packages"} {
+		assert.NotNil(t, cmd.Flags().Lookup(flag), "missing flag: %s", flag)
+	}
+}
@@ -6,6 +6,7 @@ package init
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -13,11 +14,20 @@ import (
 	"time"
 
 	gSurvey "github.com/AlecAivazis/survey/v2"
+	"github.com/drossan/claude-init/cmd/version"
 	"github.com/drossan/claude-init/internal/ai"
 	aifactory "github.com/drossan/claude-init/internal/ai"
+	"github.com/drossan/claude-init/internal/analysis"
 	"github.com/drossan/claude-init/internal/claude"
 	"github.com/drossan/claude-init/internal/config"
+	"github.com/drossan/claude-init/internal/detector"
+	"github.com/drossan/claude-init/internal/hooks"
 	"github.com/drossan/claude-init/internal/logger"
+	"github.com/drossan/claude-init/internal/mcp"
+	"github.com/drossan/claude-init/internal/preview"
+	"github.com/drossan/claude-init/internal/review"
+	"github.com/drossan/claude-init/internal/scanner"
+	"github.com/drossan/claude-init/internal/snapshot"
 	"github.com/drossan/claude-init/internal/survey"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -41,8 +51,55 @@ type InitOptions struct {
 	Force bool
 	// DryRun indica si solo se debe mostrar qué se generaría sin crear archivos.
 	DryRun bool
+	// DiffFormat es el formato de la vista previa de --dry-run (unified o json).
+	DiffFormat string
+	// Review pide aceptar, rechazar, editar o regenerar cada archivo antes de escribirlo.
+	Review bool
+	// LintRetries es el número de intentos de corregir con la IA un archivo generado no válido.
+	LintRetries int
+	// Hooks son los hooks a generar (format, lint, test, all o none); vacío pregunta por cada uno.
+	Hooks []string
+	// MCP son los servidores MCP a escribir en .mcp.json (postgres, docs, github, all o none...);
+	// vacío pregunta por cada uno.
+	MCP []string
+	// SkillReferences genera con IA una chuleta y ejemplos de código junto al SKILL.md de cada skill.
+	SkillReferences bool
 	// ConfigDir es el directorio de configuración (default: .claude).
 	ConfigDir string
+	// AnswersFile es un archivo YAML/JSON con las respuestas ("-" para leer JSON desde stdin).
+	AnswersFile string
+	// Yes acepta los resultados del análisis sin preguntar.
+	Yes bool
+	// Answers contiene las respuestas pasadas como flags individuales.
+	Answers AnswerFlags
+	// Include son los globs de archivos que se analizan (por defecto, todos).
+	Include []string
+	// Exclude son los globs de archivos que se excluyen del análisis, además de los ignorados.
+	Exclude []string
+	// ScanBudget es el presupuesto de tokens de la estructura de directorios enviada a la IA.
+	ScanBudget int
+	// SampleBudget es el presupuesto de tokens de las muestras de código enviadas a la IA.
+	SampleBudget int
+	// DocsBudget es el presupuesto de tokens de los resúmenes de documentación (ADRs, specs de API, guías).
+	DocsBudget int
+	// Reanalyze ignora el análisis en caché y vuelve a analizar el proyecto.
+	Reanalyze bool
+}
+
+// ScanOptions retorna las opciones de escaneo del proyecto.
+func (o *InitOptions) ScanOptions() scanner.Options {
+	return scanner.Options{
+		Include:      o.Include,
+		Exclude:      o.Exclude,
+		TokenBudget:  o.ScanBudget,
+		SampleBudget: o.SampleBudget,
+		DocsBudget:   o.DocsBudget,
+	}
+}
+
+// AnalysisOptions retorna las opciones del análisis de proyectos existentes.
+func (o *InitOptions) AnalysisOptions() analysis.Options {
+	return analysis.Options{Scan: o.ScanOptions(), Reanalyze: o.Reanalyze}
 }
 
 // Execute añade el comando init al root command.
@@ -77,7 +134,16 @@ the .claude/ structure with agents, commands, and skills.
 
 Requires Claude CLI to be installed. Visit: https://claude.com/claude-code
 
-If no path is provided, the current directory is used.`,
+If no path is provided, the current directory is used.
+
+Use --answers, the individual answer flags or --yes to run without prompts
+(for example from CI or project generators):
+
+  claude-init init --answers answers.yaml
+  echo '{"project_name":"api"}' | claude-init init --answers - --yes
+  claude-init init --name api --language Go --architecture Hexagonal ...
+
+The output of "claude-init analyze --format yaml" is also accepted by --answers.`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runInit(cmd, opts, args)
@@ -86,15 +152,53 @@ If no path is provided, the current directory is used.`,
 
 	// Configurar flags (eliminados flags de IA: --ai-provider, --api-key, --no-ai)
 	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Overwrite existing files")
-	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show what would be generated without creating files")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Generate in a temporary directory and show the changes without creating files")
+	cmd.Flags().StringVar(&opts.DiffFormat, "diff-format", preview.FormatUnified, "Format of the --dry-run preview (unified, json)")
+	cmd.Flags().BoolVar(&opts.Review, "review", false, "Accept, reject, edit or regenerate each generated file before writing it")
+	cmd.Flags().IntVar(&opts.LintRetries, "lint-retries", claude.DefaultLintRetries, "Times to ask the AI to fix a generated agent, skill or command that fails validation")
+	cmd.Flags().StringSliceVar(&opts.Hooks, "hooks", nil, "Claude Code hooks to generate (format, lint, test, all, none); asks for each one when interactive")
+	cmd.Flags().StringSliceVar(&opts.MCP, "mcp", nil, "MCP servers to add to .mcp.json (postgres, sqlite, mongodb, redis, docs, github, gitlab, all, none); asks for each one when interactive")
+	cmd.Flags().BoolVar(&opts.SkillReferences, "skill-references", false, "Generate a cheat sheet and example snippets next to each skill's SKILL.md")
 	cmd.Flags().StringVar(&opts.ConfigDir, "config-dir", DefaultConfigDir, "Config directory name")
 
+	// Flags para ejecución no interactiva
+	cmd.Flags().StringVar(&opts.AnswersFile, "answers", "", "Answers file (YAML or JSON, '-' reads JSON from stdin)")
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Accept project analysis results without prompting")
+	cmd.Flags().StringVar(&opts.Answers.Origin, "origin", "", "Project origin (new|existing)")
+	cmd.Flags().StringVar(&opts.Answers.Name, "name", "", "Project name")
+	cmd.Flags().StringVar(&opts.Answers.Description, "description", "", "Short project description")
+	cmd.Flags().StringVar(&opts.Answers.Language, "language", "", "Main language")
+	cmd.Flags().StringVar(&opts.Answers.Framework, "framework", "", "Framework")
+	cmd.Flags().StringVar(&opts.Answers.Architecture, "architecture", "", "Architecture")
+	cmd.Flags().StringVar(&opts.Answers.Database, "database", "", "Database")
+	cmd.Flags().StringVar(&opts.Answers.Category, "category", "", "Project category (API REST, Web App, CLI, Library...)")
+	cmd.Flags().StringVar(&opts.Answers.BusinessContext, "business-context", "", "Business context")
+	cmd.Flags().StringVar(&opts.Answers.Provider, "provider", "", "AI provider (cli, openai, gemini, groq, claude-api, zai)")
+	cmd.Flags().StringSliceVar(&opts.Answers.DocumentationDirs, "docs", nil, "Additional documentation directories")
+	cmd.Flags().StringSliceVar(&opts.Answers.Packages, "packages", nil, "Monorepo packages that get their own CLAUDE.md ('all' for every package)")
+	cmd.Flags().StringSliceVar(&opts.Include, "include", nil, "Glob patterns of files to analyze (gitignore syntax)")
+	cmd.Flags().StringSliceVar(&opts.Exclude, "exclude", nil, "Glob patterns of files to skip during analysis (gitignore syntax)")
+	cmd.Flags().IntVar(&opts.ScanBudget, "scan-budget", scanner.DefaultTokenBudget, "Token budget for the directory structure sent to the AI")
+	cmd.Flags().IntVar(&opts.SampleBudget, "sample-budget", scanner.DefaultSampleBudget, "Token budget for the representative source samples sent to the AI")
+	cmd.Flags().IntVar(&opts.DocsBudget, "docs-budget", scanner.DefaultDocsBudget, "Token budget for the documentation summaries (ADRs, API specs, guides) sent to the AI")
+	cmd.Flags().BoolVar(&opts.Reanalyze, "reanalyze", false, "Ignore the cached project analysis and analyze again")
+
 	return cmd
 }
 
 // runInit ejecuta el comando init con el flujo simplificado usando Claude CLI.
 func runInit(cmd *cobra.Command, opts *InitOptions, args []string) error {
-	// Inicializar logger si es nil
+	if err := preview.ValidateFormat(opts.DiffFormat); err != nil {
+		return err
+	}
+	if opts.Review && (opts.DryRun || opts.IsNonInteractive()) {
+		return fmt.Errorf("--review is interactive and cannot be combined with --dry-run or non-interactive answers")
+	}
+
+	// Inicializar logger si es nil; con la vista previa en JSON, el progreso va a stderr
+	if opts.DryRun && opts.DiffFormat == preview.FormatJSON {
+		log = logger.New(cmd.ErrOrStderr(), logger.INFOLevel)
+	}
 	if log == nil {
 		log = logger.New(os.Stdout, logger.INFOLevel)
 	}
@@ -110,30 +214,66 @@ func runInit(cmd *cobra.Command, opts *InitOptions, args []string) error {
 		return err
 	}
 
-	// 3. Verificar si ya existe configuración
-	if err := checkExistingConfig(projectPath, opts.ConfigDir, opts.Force); err != nil {
+	// 3. Verificar si ya existe configuración (la vista previa puede compararse con ella)
+	if err := checkExistingConfig(projectPath, opts.ConfigDir, opts.Force || opts.DryRun); err != nil {
 		return err
 	}
 
-	// 4. PREGUNTAR POR PROVIDER DE IA
-	log.Info("\nAI Provider Selection")
-	aiProvider, err := askAIProvider()
+	// Cargar configuración del proyecto (.claude-init.yaml) combinada con la global
+	factory, settings, err := aifactory.NewProjectClientFactory(projectPath)
+	if err != nil {
+		return fmt.Errorf("failed to load project settings: %w", err)
+	}
+
+	// Las preguntas del survey definen también las claves personalizadas de --answers
+	questions, err := getProjectQuestions(projectPath, settings)
 	if err != nil {
-		return fmt.Errorf("failed to ask AI provider: %w", err)
+		return fmt.Errorf("failed to load survey questions: %w", err)
+	}
+
+	// Cargar respuestas explícitas (modo no interactivo)
+	nonInteractive := opts.IsNonInteractive()
+	var explicit map[string]string
+	if nonInteractive {
+		explicit, err = opts.collectAnswers(cmd.InOrStdin(), questions)
+		if err != nil {
+			return fmt.Errorf("failed to load answers: %w", err)
+		}
+	}
+
+	// 4. PREGUNTAR POR PROVIDER DE IA (salvo que venga en las respuestas o lo fije el proyecto)
+	aiProvider := explicit["ai_provider"]
+	switch {
+	case aiProvider != "":
+		log.Info("\nUsing AI provider from answers: %s", aiProvider)
+	case settings.Provider != "":
+		aiProvider = settings.Provider
+		log.Info("\nUsing AI provider pinned in %s: %s", config.ProjectSettingsFile, aiProvider)
+	case nonInteractive:
+		aiProvider = string(ai.ProviderCLI)
+		log.Info("\nUsing default AI provider: %s", aiProvider)
+	default:
+		log.Info("\nAI Provider Selection")
+		aiProvider, err = askAIProvider()
+		if err != nil {
+			return fmt.Errorf("failed to ask AI provider: %w", err)
+		}
 	}
 
 	// Crear cliente según provider seleccionado
-	factory := aifactory.NewClientFactory()
 	client, err := factory.CreateClientFromString(aiProvider)
 	if err != nil {
 		// Si el error es por falta de configuración, pedirla interactivamente
-		if strings.Contains(err.Error(), "not configured") {
+		if strings.Contains(err.Error(), "not configured") && !nonInteractive {
 			log.Info("AI provider not configured. Let's set it up!")
 			if err := configureProvider(aiProvider); err != nil {
 				return fmt.Errorf("failed to configure provider: %w", err)
 			}
 			// Reintentar crear el cliente después de configurar
-			factory = aifactory.NewClientFactory()
+			factory, _, err = aifactory.NewProjectClientFactory(projectPath)
+			if err != nil {
+				return fmt.Errorf("failed to load project settings: %w", err)
+			}
 			client, err = factory.CreateClientFromString(aiProvider)
 			if err != nil {
 				return fmt.Errorf("error creating AI client after configuration: %w", err)
@@ -154,23 +294,15 @@ func runInit(cmd *cobra.Command, opts *InitOptions, args []string) error {
 
 	log.Info("✓ AI provider configured: %s", aiProvider)
 
-	// 5. NUEVA PREGUNTA: ¿Proyecto nuevo o existente?
+	// 5. Recopilar la información del proyecto
 	log.Info("\nStarting project configuration...")
 
-	projectOrigin, err := askProjectOrigin()
-	if err != nil {
-		return fmt.Errorf("failed to ask project origin: %w", err)
-	}
-
 	var answers *survey.Answers
-
-	// 6. Branch según el origen del proyecto
-	if projectOrigin == "Existente" {
-		answers, err = runExistingProjectFlow(projectPath, client)
+	if nonInteractive {
+		answers, err = runNonInteractiveFlow(projectPath, client, explicit, opts.Yes, opts.AnalysisOptions())
 	} else {
-		answers, err = runNewProjectFlow(client)
+		answers, err = runInteractiveFlow(projectPath, client, questions, opts.AnalysisOptions())
 	}
-
 	if err != nil {
 		return err
 	}
@@ -178,56 +310,143 @@ func runInit(cmd *cobra.Command, opts *InitOptions, args []string) error {
 	// Asignar el provider seleccionado
 	answers.AIProvider = aiProvider
 
+	// Validar las respuestas con la IA y hacer las preguntas de seguimiento
+	if !nonInteractive {
+		answers = runAIValidation(client, answers)
+	}
+
 	// Validar respuestas
 	if err := answers.Validate(); err != nil {
 		return fmt.Errorf("invalid answers: %w", err)
 	}
 
+	log.Info("\n✓ Project information collected successfully!")
+
+	// Generar en un staging: la configuración actual solo se reemplaza si todo termina bien.
+	// Con --dry-run el staging queda fuera del proyecto y solo se compara con él.
+	stage, err := newStage(projectPath, opts, answers)
+	if err != nil {
+		return err
+	}
+	defer stage.Discard()
+
 	// Guardar configuración del proyecto
-	if err := saveProjectConfig(projectPath, opts.ConfigDir, answers); err != nil {
+	if err := saveProjectConfig(stage.Dir(), opts.ConfigDir, answers); err != nil {
 		return fmt.Errorf("failed to save project config: %w", err)
 	}
 
-	log.Info("\n✓ Project information collected successfully!")
-
 	// 8. Generar estructura usando AI provider
 	log.Info("\nGenerating .claude/ structure with AI provider...")
 
-	if err := generateClaudeStructure(projectPath, opts, answers, client); err != nil {
+	generator, err := generateClaudeStructure(projectPath, stage.Dir(), opts, answers, client, settings)
+	if err != nil {
 		return fmt.Errorf("failed to generate structure: %w", err)
 	}
 
+	// Revisar cada archivo antes de confirmar el staging en el proyecto
+	if opts.Review {
+		summary, err := review.NewReviewer(cmd.OutOrStdout(), review.SurveyPrompter{}).Review(projectPath, stage.Dir(), generator)
+		if err != nil {
+			return fmt.Errorf("review failed, nothing was written: %w", err)
+		}
+		log.Info("\n%s", summary)
+	}
+
+	if opts.DryRun {
+		if err := writePreview(cmd.OutOrStdout(), projectPath, stage, opts.DiffFormat); err != nil {
+			return err
+		}
+	} else {
+		store, err := snapshot.NewStore(projectPath)
+		if err != nil {
+			return err
+		}
+		backup, err := stage.Commit(store, "init")
+		if err != nil {
+			return err
+		}
+		if len(backup.Paths) > 0 {
+			log.Info("Previous configuration saved as snapshot %s (restore it with 'claude-init undo')", backup.ID)
+		}
+	}
+
 	// 7. Mostrar resumen
 	printSummary(cmd, answers, opts)
 
 	return nil
 }
 
-// generateClaudeStructure genera la estructura .claude/ usando un Client de IA.
-func generateClaudeStructure(projectPath string, opts *InitOptions, answers *survey.Answers, client ai.Client) error {
-	outputDir := filepath.Join(projectPath, opts.ConfigDir)
+// newStage crea el staging de init con la configuración actual del proyecto, fuera del
+// proyecto con --dry-run. Con --force se parte de un directorio de configuración vacío,
+// como si no existiera.
+func newStage(projectPath string, opts *InitOptions, answers *survey.Answers) (*snapshot.Stage, error) {
+	paths := claude.ManagedPaths(projectPath, answers.Packages)
+	configDir := filepath.ToSlash(filepath.Clean(opts.ConfigDir))
+	if configDir != DefaultConfigDir {
+		paths = append(paths, configDir)
+	}
 
+	create := snapshot.NewStage
 	if opts.DryRun {
-		// En modo dry-run, solo mostrar qué se generaría
-		fmt.Printf("\nWould generate .claude/ structure at: %s\n", outputDir)
-		fmt.Printf("  Project: %s\n", answers.ProjectName)
-		fmt.Printf("  Language: %s\n", answers.Language)
-		if answers.Framework != "" {
-			fmt.Printf("  Framework: %s\n", answers.Framework)
+		create = snapshot.NewPreviewStage
+	}
+	stage, err := create(projectPath, paths)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Force {
+		for _, dir := range []string{DefaultConfigDir, configDir} {
+			if err := os.RemoveAll(filepath.Join(stage.Dir(), dir)); err != nil {
+				stage.Discard()
+				return nil, fmt.Errorf("failed to remove existing config directory: %w", err)
+			}
 		}
-		fmt.Printf("  Architecture: %s\n", answers.Architecture)
-		fmt.Printf("  Category: %s\n", answers.ProjectCategory)
-		return nil
 	}
+	return stage, nil
+}
 
+// generateClaudeStructure genera la estructura .claude/ usando un Client de IA. Los archivos
+// se escriben bajo outputDir, el staging del proyecto. Retorna el generador para revisarlos.
+func generateClaudeStructure(projectPath, outputDir string, opts *InitOptions, answers *survey.Answers, client ai.Client, settings *config.ProjectSettings) (*claude.Generator, error) {
 	// Crear directorio base .claude
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return fmt.Errorf("failed to create .claude directory: %w", err)
+	if err := os.MkdirAll(filepath.Join(outputDir, opts.ConfigDir), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create .claude directory: %w", err)
 	}
 
 	// Crear generador usando el client apropiado
 	generator := claude.NewGenerator(projectPath, answers, client)
+	generator.SetOutputDir(outputDir)
 	generator.SetLogger(log)
+	generator.SetProjectSettings(settings)
+	generator.SetToolVersion(version.Version)
+	generator.SetLintRetries(opts.LintRetries)
+	generator.SetSkillReferences(opts.SkillReferences)
+	generator.SetScanOptions(opts.ScanOptions())
+	if cache, err := claude.NewAnalysisCache(projectPath); err == nil {
+		generator.SetAnalysisCache(cache)
+	}
+
+	// Elegir los hooks: por nombre con --hooks o, en modo interactivo, uno a uno tras verlos
+	var confirm hooks.Confirmer
+	if !opts.IsNonInteractive() {
+		confirm = hooks.SurveyConfirm
+	}
+	selected, err := hooks.Choose(log.StdLogger().Writer(), generator.AvailableHooks(), opts.Hooks, confirm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select hooks: %w", err)
+	}
+	generator.SetHooks(selected)
+
+	// Elegir los servidores MCP de la misma forma: por nombre con --mcp o uno a uno
+	var confirmServer mcp.Confirmer
+	if !opts.IsNonInteractive() {
+		confirmServer = mcp.SurveyConfirm
+	}
+	servers, err := mcp.Choose(log.StdLogger().Writer(), generator.AvailableMCPServers(), opts.MCP, confirmServer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select MCP servers: %w", err)
+	}
+	generator.SetMCPServers(servers)
 
 	// Obtener recomendación usando AI provider
 	log.Info("Getting structure recommendations from AI provider...")
@@ -240,11 +459,20 @@ func generateClaudeStructure(projectPath string, opts *InitOptions, answers *sur
 
 	// Generar estructura completa basada en recomendación
 	if err := generator.GenerateAll(recommendation); err != nil {
-		return fmt.Errorf("failed to generate structure: %w", err)
+		return nil, fmt.Errorf("failed to generate structure: %w", err)
 	}
 
 	log.Info("✓ Structure generated successfully")
-	return nil
+	return generator, nil
+}
+
+// writePreview escribe en out los cambios que aplicaría la generación hecha en el staging.
+func writePreview(out io.Writer, projectPath string, stage *snapshot.Stage, format string) error {
+	changes, err := preview.Compare(projectPath, stage.Dir(), stage.Paths(), claude.InternalPaths())
+	if err != nil {
+		return err
+	}
+	return preview.Write(out, changes, format, preview.ColorEnabled(out))
 }
 
 // getDefaultRecommendation retorna una recomendación por defecto basada en las respuestas.
@@ -255,16 +483,20 @@ func getDefaultRecommendation(answers *survey.Answers) *claude.Recommendation {
 
 	// Agregar agent de debugger solo para arquitecturas complejas
 	complexArchitectures := []string{"Microservicios", "DDD", "Hexagonal", "Event-Driven", "Serverless"}
+	architecture := survey.NormalizeChoice("architecture", answers.Architecture)
 	for _, arch := range complexArchitectures {
-		if answers.Architecture == arch {
+		if architecture == arch {
 			agents = append(agents, "debugger")
 			break
 		}
 	}
 
-	// Agregar skill de framework si existe
-	if answers.Framework != "" {
-		skills = append(skills, strings.ToLower(answers.Framework))
+	// Agregar una skill por cada framework y base de datos seleccionados
+	for _, framework := range answers.Frameworks() {
+		skills = append(skills, normalizeSkillName(framework))
+	}
+	for _, database := range answers.Databases() {
+		skills = append(skills, normalizeSkillName(database))
 	}
 
 	description := fmt.Sprintf("Default structure for %s %s project", answers.Language, answers.ProjectCategory)
@@ -359,10 +591,7 @@ func checkExistingConfig(projectPath, configDir string, force bool) error {
 		if !force {
 			return fmt.Errorf("config directory already exists: %s (use --force to overwrite)", configPath)
 		}
-		// Si force es true, eliminar directorio existente
-		if err := os.RemoveAll(configPath); err != nil {
-			return fmt.Errorf("failed to remove existing config directory: %w", err)
-		}
+		// Con force, el directorio existente se reemplaza al terminar la generación (ver newStage)
 	}
 	return nil
 }
@@ -397,17 +626,21 @@ func printSummary(cmd *cobra.Command, answers *survey.Answers, opts *InitOptions
 
 // ProjectConfig representa la configuración del proyecto guardada.
 type ProjectConfig struct {
-	ProjectOrigin   string `yaml:"project_origin" json:"project_origin"`
-	ProjectName     string `yaml:"project_name" json:"project_name"`
-	Description     string `yaml:"description" json:"description"`
-	Language        string `yaml:"language" json:"language"`
-	Framework       string `yaml:"framework" json:"framework"`
-	Architecture    string `yaml:"architecture" json:"architecture"`
-	Database        string `yaml:"database" json:"database"`
-	ProjectCategory string `yaml:"project_category" json:"project_category"`
-	BusinessContext string `yaml:"business_context" json:"business_context"`
-	AIProvider      string `yaml:"ai_provider" json:"ai_provider"`
-	CreatedAt       string `yaml:"created_at" json:"created_at"`
+	ProjectOrigin   string            `yaml:"project_origin" json:"project_origin"`
+	ProjectName     string            `yaml:"project_name" json:"project_name"`
+	Description     string            `yaml:"description" json:"description"`
+	Language        string            `yaml:"language" json:"language"`
+	Framework       string            `yaml:"framework" json:"framework"`
+	Architecture    string            `yaml:"architecture" json:"architecture"`
+	Database        string            `yaml:"database" json:"database"`
+	ProjectCategory string            `yaml:"project_category" json:"project_category"`
+	BusinessContext string            `yaml:"business_context" json:"business_context"`
+	AIProvider      string            `yaml:"ai_provider" json:"ai_provider"`
+	FollowUps       []survey.FollowUp `yaml:"follow_ups,omitempty" json:"follow_ups,omitempty"`
+	Conventions     []string          `yaml:"conventions,omitempty" json:"conventions,omitempty"`
+	Extra           map[string]string `yaml:"extra,omitempty" json:"extra,omitempty"`
+	Packages        []string          `yaml:"packages,omitempty" json:"packages,omitempty"`
+	CreatedAt       string            `yaml:"created_at" json:"created_at"`
 }
 
 // saveProjectConfig guarda las respuestas del survey en un archivo YAML.
@@ -430,6 +663,10 @@ func saveProjectConfig(projectPath, configDir string, answers *survey.Answers) e
 		ProjectCategory: answers.ProjectCategory,
 		BusinessContext: answers.BusinessContext,
 		AIProvider:      answers.AIProvider,
+		FollowUps:       answers.FollowUps,
+		Conventions:     answers.Conventions,
+		Extra:           answers.Extra,
+		Packages:        answers.Packages,
 		CreatedAt:       time.Now().Format(time.RFC3339),
 	}
 
@@ -465,24 +702,35 @@ func askProjectOrigin() (string, error) {
 	return origin, nil
 }
 
+// runInteractiveFlow pregunta por el origen del proyecto y ejecuta el flujo correspondiente.
+func runInteractiveFlow(projectPath string, client ai.Client, questions []*survey.Question, analysisOpts analysis.Options) (*survey.Answers, error) {
+	projectOrigin, err := askProjectOrigin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to ask project origin: %w", err)
+	}
+
+	if projectOrigin == "Existente" {
+		return runExistingProjectFlow(projectPath, client, questions, analysisOpts)
+	}
+	return runNewProjectFlow(client, questions)
+}
+
+// detectDocumentationDirs detecta los directorios de documentación comunes del proyecto.
+func detectDocumentationDirs(projectPath string) []string {
+	return detector.DocumentationDirs(projectPath)
+}
+
 // askDocumentationDirs pregunta al usuario por directorios de documentación adicionales.
 func askDocumentationDirs(projectPath string, answers *survey.Answers) (*survey.Answers, error) {
 	// Primero, detectar automáticamente directorios comunes
-	commonDocDirs := []string{"docs", "documentation", "guide", "guides", "wiki", "help"}
-	foundDirs := make([]string, 0)
-
-	for _, dir := range commonDocDirs {
-		fullPath := filepath.Join(projectPath, dir)
-		if _, err := os.Stat(fullPath); err == nil {
-			foundDirs = append(foundDirs, dir)
-		}
-	}
+	foundDirs := detectDocumentationDirs(projectPath)
 
-	// Mostrar directorios detectados
+	// Mostrar directorios detectados con los documentos que contienen (recursivamente)
 	if len(foundDirs) > 0 {
+		docFiles := scanner.NewScanner(projectPath, scanner.Options{}).DocumentFiles(foundDirs)
 		log.Info("\nDirectorios de documentación detectados:")
 		for _, dir := range foundDirs {
-			log.Info("  ✓ %s/", dir)
+			log.Info("  ✓ %s/ (%d documentos)", dir, countDocuments(docFiles, dir))
 		}
 		log.Info("")
 	}
@@ -550,55 +798,70 @@ func askDocumentationDirs(projectPath string, answers *survey.Answers) (*survey.
 	return answers, nil
 }
 
+// countDocuments cuenta los documentos que están dentro de dir, en cualquier subdirectorio.
+func countDocuments(files []string, dir string) int {
+	prefix := strings.Trim(filepath.ToSlash(dir), "/") + "/"
+	count := 0
+	for _, file := range files {
+		if strings.HasPrefix(file, prefix) {
+			count++
+		}
+	}
+	return count
+}
+
 // runExistingProjectFlow analiza y pre-llena el survey para proyectos existentes.
-func runExistingProjectFlow(projectPath string, client ai.Client) (*survey.Answers, error) {
+func runExistingProjectFlow(projectPath string, client ai.Client, questions []*survey.Question, analysisOpts analysis.Options) (*survey.Answers, error) {
 	log.Info("\nAnalizando proyecto existente...")
 	log.Info("Esto puede tomar unos segundos...\n")
 
-	analyzer := claude.NewAnalyzer(projectPath, client)
-	analyzer.SetLogger(log)
-
-	analysis, err := analyzer.Analyze()
+	result, err := analysis.NewAnalyzer(projectPath, client, analysisOpts, log).Analyze()
 	if err != nil {
 		log.Warn("Project analysis failed: %v", err)
 		log.Info("Falling back to manual survey...\n")
-		return runNewProjectFlow(client)
+		return runNewProjectFlow(client, questions)
 	}
 
 	// Convertir análisis a Answers
-	prefill := &survey.Answers{
-		ProjectOrigin:   "Existente",
-		ProjectName:     analysis.Name,
-		Description:     analysis.Description,
-		Language:        analysis.Language,
-		Framework:       analysis.Framework,
-		Architecture:    analysis.Architecture,
-		Database:        analysis.Database,
-		ProjectCategory: analysis.ProjectCategory,
-		BusinessContext: analysis.BusinessContext,
-	}
+	prefill := analysis.Answers(result)
 
 	// Mostrar resultados del análisis
-	log.Info("Análisis completado:")
-	log.Info("  Nombre: %s", analysis.Name)
-	log.Info("  Lenguaje: %s", analysis.Language)
-	if analysis.Framework != "" {
-		log.Info("  Framework: %s", analysis.Framework)
+	if result.Source == claude.AnalysisSourceHeuristic {
+		log.Info("Análisis heurístico completado (sin IA):")
+	} else {
+		log.Info("Análisis completado:")
+	}
+	log.Info("  Nombre: %s", result.Name)
+	log.Info("  Lenguaje: %s", result.Language)
+	if result.Framework != "" {
+		log.Info("  Framework: %s", result.Framework)
+	}
+	log.Info("  Arquitectura: %s", result.Architecture)
+	if result.Database != "" {
+		log.Info("  Base de datos: %s", result.Database)
+	}
+	log.Info("  Categoría: %s", result.ProjectCategory)
+	if result.TestingFramework != "" {
+		log.Info("  Testing: %s", result.TestingFramework)
+	}
+	if result.Detection != nil && len(result.Detection.CI) > 0 {
+		log.Info("  CI: %s", strings.Join(result.Detection.CI, ", "))
 	}
-	log.Info("  Arquitectura: %s", analysis.Architecture)
-	if analysis.Database != "" {
-		log.Info("  Base de datos: %s", analysis.Database)
+	if len(result.Conventions) > 0 {
+		log.Info("  Convenciones (%d archivos de muestra):", len(result.SampledFiles))
+		for _, convention := range result.Conventions {
+			log.Info("    • %s", convention)
+		}
 	}
-	log.Info("  Categoría: %s", analysis.ProjectCategory)
 	log.Info("\nPor favor, revisa y edita la información pre-llenada:\n")
 
 	// Ejecutar survey con valores pre-llenados
-	questions := getProjectQuestions()
 	runner := survey.NewRunner(questions)
 	answers, err := runner.RunWithPrefill(prefill)
 	if err != nil {
 		return nil, err
 	}
+	answers.Conventions = result.Conventions
 
 	// Preguntar por directorios de documentación adicionales
 	answers, err = askDocumentationDirs(projectPath, answers)
@@ -607,14 +870,67 @@ func runExistingProjectFlow(projectPath string, client ai.Client) (*survey.Answe
 		// Continuar sin los directorios de documentación
 	}
 
+	// En un monorepo, preguntar qué paquetes tendrán su propio CLAUDE.md
+	if result.Detection != nil && result.Detection.Workspace != nil {
+		showWorkspace(result.Detection.Workspace)
+		packages, err := askPackages(result.Detection.Workspace)
+		if err != nil {
+			log.Warn("No se pudieron seleccionar los paquetes: %v", err)
+		}
+		answers.Packages = packages
+	}
+
 	return answers, nil
 }
 
+// runAIValidation envía las respuestas al provider, muestra sus sugerencias y
+// pregunta las preguntas de seguimiento. Si la validación falla, se continúa sin ella.
+func runAIValidation(client ai.Client, answers *survey.Answers) *survey.Answers {
+	log.Info("\nValidando la información del proyecto con IA...")
+
+	validator := claude.NewValidator(client)
+	validator.SetLogger(log)
+
+	result, err := validator.Validate(answers)
+	if err != nil {
+		log.Warn("AI validation skipped: %v", err)
+		return answers
+	}
+
+	if len(result.MissingInfo) > 0 {
+		log.Info("\nInformación que falta o es ambigua:")
+		for _, info := range result.MissingInfo {
+			log.Info("  - %s", info)
+		}
+	}
+
+	if len(result.Suggestions) > 0 {
+		log.Info("\nSugerencias:")
+		for _, suggestion := range result.Suggestions {
+			log.Info("  - %s", suggestion)
+		}
+	}
+
+	questions := survey.NewFollowUpQuestions(result.Questions)
+	if len(questions) == 0 {
+		return answers
+	}
+
+	log.Info("\nPreguntas de seguimiento (opcionales):\n")
+	runner := survey.NewRunner(questions)
+	withFollowUps, err := runner.RunWithPrefill(answers)
+	if err != nil {
+		log.Warn("No se pudieron recoger las respuestas de seguimiento: %v", err)
+		return answers
+	}
+
+	return withFollowUps
+}
+
 // runNewProjectFlow ejecuta el survey normal para proyectos nuevos.
-func runNewProjectFlow(client ai.Client) (*survey.Answers, error) {
+func runNewProjectFlow(client ai.Client, questions []*survey.Question) (*survey.Answers, error) {
 	log.Info("Please answer the following questions to configure your project.\n")
 
-	questions := getProjectQuestions()
 	runner := survey.NewRunner(questions)
 	answers, err := runner.Run()
 	if err != nil {
@@ -625,9 +941,14 @@ func runNewProjectFlow(client ai.Client) (*survey.Answers, error) {
 	return answers, nil
 }
 
-// getProjectQuestions retorna todas las preguntas del survey.
-func getProjectQuestions() []*survey.Question {
-	return survey.GetProjectQuestions()
+// getProjectQuestions retorna las preguntas del survey: las incrustadas por defecto combinadas
+// con las del proyecto (.claude-init.yaml) o, si no hay, con las del usuario/equipo.
+func getProjectQuestions(projectPath string, settings *config.ProjectSettings) ([]*survey.Question, error) {
+	paths := []string{settings.QuestionsPath(projectPath)}
+	if globalPath, err := config.GetQuestionsPath(); err == nil {
+		paths = append(paths, globalPath)
+	}
+	return survey.LoadQuestions(paths...)
 }
 
 // configureProvider configura interactivamente un provider de IA.
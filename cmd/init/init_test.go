@@ -3,10 +3,13 @@ package init
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/drossan/claude-init/internal/ai"
+	"github.com/drossan/claude-init/internal/config"
 	"github.com/drossan/claude-init/internal/logger"
+	"github.com/drossan/claude-init/internal/scanner"
 	"github.com/drossan/claude-init/internal/survey"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -55,6 +58,10 @@ func TestNewInitCommand_HasCorrectFlags(t *testing.T) {
 		"force",
 		"dry-run",
 		"config-dir",
+		"include",
+		"exclude",
+		"scan-budget",
+		"sample-budget",
 	}
 
 	for _, flag := range requiredFlags {
@@ -66,6 +73,27 @@ func TestNewInitCommand_HasCorrectFlags(t *testing.T) {
 	assert.Equal(t, "f", forceFlag.Shorthand)
 }
 
+// TestInitOptions_ScanOptions verifica que los flags de escaneo se trasladan a scanner.Options.
+func TestInitOptions_ScanOptions(t *testing.T) {
+	cmd := NewInitCommand()
+	require.NoError(t, cmd.Flags().Parse([]string{"--include", "src/**,cmd", "--exclude", "*.gen.go", "--scan-budget", "500", "--sample-budget", "1000", "--docs-budget", "1500"}))
+
+	include, _ := cmd.Flags().GetStringSlice("include")
+	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+	budget, _ := cmd.Flags().GetInt("scan-budget")
+	sampleBudget, _ := cmd.Flags().GetInt("sample-budget")
+	docsBudget, _ := cmd.Flags().GetInt("docs-budget")
+	opts := &InitOptions{Include: include, Exclude: exclude, ScanBudget: budget, SampleBudget: sampleBudget, DocsBudget: docsBudget}
+
+	assert.Equal(t, scanner.Options{
+		Include:      []string{"src/**", "cmd"},
+		Exclude:      []string{"*.gen.go"},
+		TokenBudget:  500,
+		SampleBudget: 1000,
+		DocsBudget:   1500,
+	}, opts.ScanOptions())
+}
+
 // TestNewInitCommand_DoesNotHaveAIFlags verifica que los flags de IA fueron eliminados.
 func TestNewInitCommand_DoesNotHaveAIFlags(t *testing.T) {
 	cmd := NewInitCommand()
@@ -211,6 +239,22 @@ func TestGetDefaultRecommendation_GeneratesValidRecommendation(t *testing.T) {
 			mustContainAgents: []string{"debugger"},
 			mustContainSkills: []string{"nodejs", "express"},
 		},
+		{
+			name: "Go project with multiple frameworks, databases and free-text architecture",
+			answers: &survey.Answers{
+				Language:        "Go",
+				Framework:       "Gin, Echo",
+				Architecture:    "microservices",
+				Database:        "PostgreSQL, Redis",
+				ProjectCategory: "API REST",
+			},
+			wantLen: struct {
+				agents   int
+				commands int
+			}{agents: 5, commands: 3},
+			mustContainAgents: []string{"debugger"},
+			mustContainSkills: []string{"go", "gin", "echo", "postgresql", "redis"},
+		},
 		{
 			name: "Python project with monolith architecture",
 			answers: &survey.Answers{
@@ -266,7 +310,7 @@ func TestGenerateClaudeStructure_CreatesAllComponents(t *testing.T) {
 	}
 
 	client := &mockClient{}
-	err := generateClaudeStructure(tempDir, opts, answers, client)
+	_, err := generateClaudeStructure(tempDir, tempDir, opts, answers, client, &config.ProjectSettings{})
 	assert.NoError(t, err)
 
 	// Verificar que se creó la estructura
@@ -337,3 +381,36 @@ func TestSaveProjectConfig_SavesValidYAML(t *testing.T) {
 	assert.Contains(t, string(content), "test-project")
 	assert.Contains(t, string(content), "Test description")
 }
+
+// TestNewStage_DryRunAndForce verifica que la vista previa se prepara fuera del proyecto y
+// que --force parte de un directorio de configuración vacío.
+func TestNewStage_DryRunAndForce(t *testing.T) {
+	tempDir := t.TempDir()
+	agentPath := filepath.Join(".claude", "agents", "architect.md")
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, ".claude", "agents"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, agentPath), []byte("# Architect\n"), 0644))
+
+	stage, err := newStage(tempDir, &InitOptions{ConfigDir: ".claude", DryRun: true}, &survey.Answers{})
+	require.NoError(t, err)
+	defer stage.Discard()
+	assert.False(t, strings.HasPrefix(stage.Dir(), tempDir), "la vista previa no escribe en el proyecto")
+	assert.FileExists(t, filepath.Join(stage.Dir(), agentPath))
+
+	forced, err := newStage(tempDir, &InitOptions{ConfigDir: ".claude", Force: true}, &survey.Answers{})
+	require.NoError(t, err)
+	defer forced.Discard()
+	assert.True(t, strings.HasPrefix(forced.Dir(), tempDir))
+	assert.NoDirExists(t, filepath.Join(forced.Dir(), ".claude"))
+	assert.FileExists(t, filepath.Join(tempDir, agentPath), "el proyecto no cambia hasta confirmar el staging")
+}
+
+// TestInitCommand_ReviewRequiresInteractiveRun verifica que --review no se combina con
+// --dry-run ni con respuestas no interactivas.
+func TestInitCommand_ReviewRequiresInteractiveRun(t *testing.T) {
+	for _, args := range [][]string{{"--review", "--dry-run"}, {"--review", "--yes"}} {
+		cmd := NewInitCommand()
+		cmd.SetArgs(append(args, t.TempDir()))
+		err := cmd.Execute()
+		assert.ErrorContains(t, err, "--review is interactive")
+	}
+}
@@ -0,0 +1,77 @@
+package init
+
+import (
+	"strings"
+
+	gSurvey "github.com/AlecAivazis/survey/v2"
+	"github.com/drossan/claude-init/internal/detector"
+)
+
+// AllPackages es el valor de --packages que selecciona todos los paquetes del monorepo.
+const AllPackages = "all"
+
+// showWorkspace muestra los paquetes detectados en un monorepo.
+func showWorkspace(workspace *detector.Workspace) {
+	log.Info("\nMonorepo detectado (%s):", strings.Join(workspace.Tools, ", "))
+	for _, pkg := range workspace.Packages {
+		log.Info("  • %s", pkg.Summary())
+	}
+}
+
+// askPackages pregunta para qué paquetes del monorepo se genera un CLAUDE.md propio.
+func askPackages(workspace *detector.Workspace) ([]string, error) {
+	var generate bool
+	confirm := &gSurvey.Confirm{
+		Message: "¿Generar un CLAUDE.md propio para los paquetes del monorepo?",
+		Help:    "Cada CLAUDE.md describe el stack y los comandos del paquete; la raíz mantiene el .claude/ común",
+		Default: false,
+	}
+	if err := gSurvey.AskOne(confirm, &generate); err != nil {
+		return nil, err
+	}
+	if !generate {
+		return nil, nil
+	}
+
+	options := workspace.PackagePaths()
+	var selected []string
+	prompt := &gSurvey.MultiSelect{
+		Message: "Paquetes:",
+		Options: options,
+		Default: options,
+	}
+	if err := gSurvey.AskOne(prompt, &selected); err != nil {
+		return nil, err
+	}
+
+	return selected, nil
+}
+
+// resolvePackages valida los paquetes pedidos con --packages o --answers contra el
+// workspace detectado. "all" selecciona todos; las rutas desconocidas se descartan.
+func resolvePackages(projectPath string, requested []string) []string {
+	if len(requested) == 0 {
+		return nil
+	}
+
+	result, err := detector.NewDetector(projectPath).Detect()
+	if err != nil || result.Workspace == nil {
+		log.Warn("No se detectó un monorepo; se ignoran los paquetes indicados")
+		return nil
+	}
+
+	var packages []string
+	for _, path := range requested {
+		if strings.EqualFold(path, AllPackages) {
+			return result.Workspace.PackagePaths()
+		}
+		pkg, ok := result.Workspace.Package(path)
+		if !ok {
+			log.Warn("  ⚠ Paquete '%s' no encontrado en el monorepo, se omitirá", path)
+			continue
+		}
+		packages = append(packages, pkg.Path)
+	}
+
+	return packages
+}
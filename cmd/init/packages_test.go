@@ -0,0 +1,56 @@
+package init
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/analysis"
+	"github.com/drossan/claude-init/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMonorepo crea un monorepo npm con dos paquetes.
+func newMonorepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"package.json":          `{"name": "acme", "workspaces": ["apps/*"]}`,
+		"apps/web/package.json": `{"name": "web"}`,
+		"apps/api/package.json": `{"name": "api"}`,
+	}
+	for rel, content := range files {
+		path := filepath.Join(dir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+// TestResolvePackages verifica la selección de paquetes con --packages.
+func TestResolvePackages(t *testing.T) {
+	log = logger.New(os.Stdout, logger.INFOLevel)
+	dir := newMonorepo(t)
+
+	assert.Equal(t, []string{"apps/api", "apps/web"}, resolvePackages(dir, []string{AllPackages}))
+	assert.Equal(t, []string{"apps/web"}, resolvePackages(dir, []string{"./apps/web", "apps/missing"}))
+	assert.Nil(t, resolvePackages(dir, nil))
+	assert.Nil(t, resolvePackages(t.TempDir(), []string{"all"}), "sin monorepo no hay paquetes")
+}
+
+// TestRunNonInteractiveFlow_Packages verifica que --packages llega a las respuestas.
+func TestRunNonInteractiveFlow_Packages(t *testing.T) {
+	log = logger.New(os.Stdout, logger.INFOLevel)
+	dir := newMonorepo(t)
+
+	explicit := map[string]string{
+		"project_origin": "new",
+		"project_name":   "acme",
+		"packages":       "all",
+	}
+
+	answers, err := runNonInteractiveFlow(dir, &mockClient{}, explicit, false, analysis.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apps/api", "apps/web"}, answers.Packages)
+}
@@ -0,0 +1,124 @@
+// Package lint implementa el comando que valida los agents, skills y commands de un
+// directorio .claude con los formatos que acepta Claude Code.
+package lint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/drossan/claude-init/internal/lint"
+	"github.com/spf13/cobra"
+)
+
+// Formatos de salida del comando lint.
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+)
+
+// ErrIssuesFound indica que la validación ha encontrado errores.
+var ErrIssuesFound = errors.New("lint found errors")
+
+// LintOptions contiene las opciones configurables del comando lint.
+type LintOptions struct {
+	Format string // Format es el formato de salida: table o json
+}
+
+// NewLintCommand crea una nueva instancia del comando lint.
+func NewLintCommand() *cobra.Command {
+	opts := &LintOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "lint [.claude]",
+		Short: "Validate the frontmatter of agents, skills and commands",
+		Long: `Validate the agents, skills and commands of a .claude directory against the
+formats Claude Code accepts: YAML frontmatter that parses, required name and
+description fields, lowercase hyphenated names, known tool names and models.
+
+Errors make Claude Code reject or misread the file; warnings do not. The
+command exits with a non-zero status when it finds any error, so it can run
+in CI. init and generate run the same checks after generating each file.`,
+		Example: `  # Lint the .claude directory of the current project
+  claude-init lint
+
+  # Machine-readable output
+  claude-init lint path/to/project/.claude --format json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			claudeDir := ".claude"
+			if len(args) > 0 {
+				claudeDir = args[0]
+			}
+			err := Lint(cmd.OutOrStdout(), claudeDir, opts)
+			if errors.Is(err, ErrIssuesFound) {
+				cmd.SilenceUsage = true
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Format, "format", "f", FormatTable, "Output format (table, json)")
+
+	return cmd
+}
+
+// Lint valida claudeDir y escribe el informe en out en el formato de opts. Retorna
+// ErrIssuesFound si hay algún error.
+func Lint(out io.Writer, claudeDir string, opts *LintOptions) error {
+	format := strings.ToLower(opts.Format)
+	if format != FormatTable && format != FormatJSON {
+		return fmt.Errorf("unsupported format %q (use table or json)", opts.Format)
+	}
+
+	claudeDir, err := filepath.Abs(claudeDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+	report, err := lint.Dir(claudeDir)
+	if err != nil {
+		return err
+	}
+
+	if err := Write(out, report, format); err != nil {
+		return err
+	}
+	if report.Errors > 0 {
+		return fmt.Errorf("%w: %d error(s) in %s", ErrIssuesFound, report.Errors, claudeDir)
+	}
+	return nil
+}
+
+// Write escribe el informe en el formato indicado.
+func Write(out io.Writer, report *lint.Report, format string) error {
+	if format == FormatJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+		return nil
+	}
+
+	if len(report.Issues) > 0 {
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "FILE\tSEVERITY\tFIELD\tMESSAGE")
+		for _, issue := range report.Issues {
+			field := issue.Field
+			if field == "" {
+				field = "-"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", issue.Path, issue.Severity, field, issue.Message)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintf(out, "Checked %d file(s): %d error(s), %d warning(s).\n", report.Files, report.Errors, report.Warnings)
+	return nil
+}
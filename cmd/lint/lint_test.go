@@ -0,0 +1,46 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/lint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLint verifica la salida en tabla y JSON, y que los errores hacen fallar el comando.
+func TestLint(t *testing.T) {
+	claudeDir := t.TempDir()
+	agentPath := filepath.Join(claudeDir, "agents", "dev.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(agentPath), 0755))
+	require.NoError(t, os.WriteFile(agentPath, []byte("---\nname: dev\ndescription: Developer\n---\n"), 0644))
+
+	var out bytes.Buffer
+	require.NoError(t, Lint(&out, claudeDir, &LintOptions{Format: FormatTable}))
+	assert.Equal(t, "Checked 1 file(s): 0 error(s), 0 warning(s).\n", out.String())
+
+	// Un agent con una herramienta desconocida
+	require.NoError(t, os.WriteFile(agentPath, []byte("---\nname: dev\ndescription: Developer\ntools: Read, Terminal\n---\n"), 0644))
+	out.Reset()
+	err := Lint(&out, claudeDir, &LintOptions{Format: FormatTable})
+	assert.ErrorIs(t, err, ErrIssuesFound)
+	assert.Contains(t, out.String(), "FILE")
+	assert.Contains(t, out.String(), `agents/dev.md  error     tools  unknown tool "Terminal"`)
+	assert.Contains(t, out.String(), "Checked 1 file(s): 1 error(s), 0 warning(s).")
+
+	out.Reset()
+	err = Lint(&out, claudeDir, &LintOptions{Format: "JSON"})
+	assert.ErrorIs(t, err, ErrIssuesFound)
+	var report lint.Report
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, 1, report.Errors)
+	require.Len(t, report.Issues, 1)
+	assert.Equal(t, "tools", report.Issues[0].Field)
+
+	err = Lint(&out, claudeDir, &LintOptions{Format: "yaml"})
+	assert.ErrorContains(t, err, "unsupported format")
+}
@@ -1,10 +1,15 @@
 package root
 
 import (
+	"github.com/drossan/claude-init/cmd/analyze"
 	"github.com/drossan/claude-init/cmd/completion"
 	configcmd "github.com/drossan/claude-init/cmd/config"
 	"github.com/drossan/claude-init/cmd/generate"
+	"github.com/drossan/claude-init/cmd/history"
 	initcmd "github.com/drossan/claude-init/cmd/init"
+	"github.com/drossan/claude-init/cmd/lint"
+	"github.com/drossan/claude-init/cmd/undo"
+	"github.com/drossan/claude-init/cmd/update"
 	"github.com/drossan/claude-init/cmd/version"
 	"github.com/drossan/claude-init/internal/logger"
 	"github.com/spf13/cobra"
@@ -63,6 +68,11 @@ func init() {
 
 	// Añadir comandos adicionales
 	rootCmd.AddCommand(version.NewVersionCommand())
+	rootCmd.AddCommand(analyze.NewAnalyzeCommand())
+	rootCmd.AddCommand(update.NewUpdateCommand())
+	rootCmd.AddCommand(history.NewHistoryCommand())
+	rootCmd.AddCommand(undo.NewUndoCommand())
+	rootCmd.AddCommand(lint.NewLintCommand())
 	rootCmd.AddCommand(completion.NewCompletionCommand(rootCmd))
 	rootCmd.AddCommand(configcmd.Cmd)
 }
@@ -0,0 +1,86 @@
+// Package undo implementa el comando que restaura la configuración de un proyecto a una
+// generación anterior a partir de sus instantáneas.
+package undo
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/drossan/claude-init/cmd/history"
+	"github.com/drossan/claude-init/internal/snapshot"
+	"github.com/spf13/cobra"
+)
+
+// UndoOptions contiene las opciones configurables del comando undo.
+type UndoOptions struct {
+	To string // To es el ID de la instantánea a restaurar; vacío restaura la última generación
+}
+
+// NewUndoCommand crea una nueva instancia del comando undo.
+func NewUndoCommand() *cobra.Command {
+	opts := &UndoOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "undo [path]",
+		Short: "Restore the configuration replaced by the last generation",
+		Long: `Restore .claude/ and CLAUDE.md files to their state before a generation.
+
+Without --to, restores the state before the latest init, generate or update not
+undone yet, so running undo repeatedly goes back one generation at a time.
+The current state is snapshotted first, so an undo can be restored as well
+(see 'claude-init history').`,
+		Example: `  # Undo the last generation
+  claude-init undo
+
+  # Restore a specific snapshot
+  claude-init undo --to 20260102-150405.000`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectPath, err := history.ProjectPath(args)
+			if err != nil {
+				return err
+			}
+			store, err := snapshot.NewStore(projectPath)
+			if err != nil {
+				return err
+			}
+			return Undo(cmd.OutOrStdout(), store, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.To, "to", "", "ID of the snapshot to restore (see 'claude-init history')")
+
+	return cmd
+}
+
+// Undo restaura la instantánea indicada en opts, o la de la última generación, y escribe en
+// out qué se ha restaurado.
+func Undo(out io.Writer, store *snapshot.Store, opts *UndoOptions) error {
+	id := opts.To
+	if id == "" {
+		latest, err := store.Latest()
+		if err != nil {
+			return err
+		}
+		id = latest.ID
+	}
+
+	restored, err := store.Get(id)
+	if err != nil {
+		return err
+	}
+	backup, err := store.Restore(id)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Restored snapshot %s (state before %s, %s).\n", restored.ID, restored.Command, restored.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+	for _, path := range restored.Paths {
+		fmt.Fprintf(out, "  restored  %s\n", path)
+	}
+	for _, path := range restored.Missing {
+		fmt.Fprintf(out, "  removed   %s\n", path)
+	}
+	fmt.Fprintf(out, "The replaced state was saved as snapshot %s.\n", backup.ID)
+	return nil
+}
@@ -0,0 +1,51 @@
+package undo
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUndo verifica que undo restaura la última generación o la instantánea indicada.
+func TestUndo(t *testing.T) {
+	project := t.TempDir()
+	store := snapshot.NewStoreWithDir(project, t.TempDir())
+	claudeMD := filepath.Join(project, "CLAUDE.md")
+	paths := []string{".claude", "CLAUDE.md"}
+
+	err := Undo(&bytes.Buffer{}, store, &UndoOptions{})
+	assert.ErrorContains(t, err, "no generation to undo")
+
+	// Dos generaciones: la primera crea CLAUDE.md y la segunda lo reemplaza
+	for _, content := range []string{"v1", "v2"} {
+		stage, err := snapshot.NewStage(project, paths)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(stage.Dir(), "CLAUDE.md"), []byte(content), 0644))
+		_, err = stage.Commit(store, "generate")
+		require.NoError(t, err)
+	}
+	snapshots, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	first := snapshots[1]
+
+	var out bytes.Buffer
+	require.NoError(t, Undo(&out, store, &UndoOptions{}))
+	assert.Contains(t, out.String(), "restored  CLAUDE.md")
+	content, err := os.ReadFile(claudeMD)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", string(content))
+
+	out.Reset()
+	require.NoError(t, Undo(&out, store, &UndoOptions{To: first.ID}))
+	assert.Contains(t, out.String(), "removed   CLAUDE.md")
+	assert.NoFileExists(t, claudeMD)
+
+	err = Undo(&out, store, &UndoOptions{To: "missing"})
+	assert.ErrorContains(t, err, "snapshot missing not found")
+}
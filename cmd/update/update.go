@@ -0,0 +1,234 @@
+// Package update implementa el comando que actualiza la configuración generada de un
+// proyecto, regenerando solo lo que ha cambiado y conservando las ediciones a mano.
+package update
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/drossan/claude-init/cmd/generate"
+	"github.com/drossan/claude-init/cmd/version"
+	"github.com/drossan/claude-init/internal/ai"
+	"github.com/drossan/claude-init/internal/claude"
+	"github.com/drossan/claude-init/internal/config"
+	"github.com/drossan/claude-init/internal/logger"
+	"github.com/drossan/claude-init/internal/snapshot"
+	"github.com/spf13/cobra"
+)
+
+// UpdateOptions contiene las opciones configurables del comando update.
+type UpdateOptions struct {
+	DryRun      bool   // DryRun muestra qué se regeneraría sin escribir nada
+	NoMerge     bool   // NoMerge conserva los archivos editados a mano en lugar de fusionarlos
+	Prune       bool   // Prune elimina los archivos que ya no se recomiendan y no se han editado
+	Provider    string // Provider es el provider de IA; vacío usa el del proyecto
+	Reanalyze   bool   // Reanalyze ignora el análisis en caché
+	LintRetries int    // LintRetries es el número de intentos de corregir con la IA un archivo no válido
+}
+
+// NewUpdateCommand crea una nueva instancia del comando update.
+func NewUpdateCommand() *cobra.Command {
+	opts := &UpdateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "update [path]",
+		Short: "Regenerate only what changed, preserving hand-edited files",
+		Long: `Update the generated configuration of a project incrementally.
+
+Compares the generation manifest (.claude/.claude-init-manifest.json) with the
+current state of the project and regenerates only the files whose inputs
+changed: the answers in .claude/project.yaml, the dependency manifests or the
+template they were adapted from. Newly recommended agents, skills and commands
+are created.
+
+Files are handled according to their state:
+  - untouched since generated: replaced with the new version
+  - edited by hand: three-way merged with the new version (conflicting blocks
+    are marked as in git); with --no-merge they are kept as they are
+  - deleted by hand: not recreated
+  - no longer recommended: reported; --prune removes them unless edited
+
+Every file touched is listed with what happened to it. The previous state is
+kept as a snapshot that 'claude-init undo' restores.`,
+		Example: `  # Update the configuration of the current directory
+  claude-init update
+
+  # Show what would be regenerated and why
+  claude-init update --dry-run
+
+  # Never touch hand-edited files and remove files no longer recommended
+  claude-init update --no-merge --prune`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUpdate(cmd, opts, args)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show what would be regenerated and why, without writing files")
+	cmd.Flags().BoolVar(&opts.NoMerge, "no-merge", false, "Keep hand-edited files as they are instead of merging them")
+	cmd.Flags().BoolVar(&opts.Prune, "prune", false, "Remove generated files that are no longer recommended, unless edited")
+	cmd.Flags().StringVar(&opts.Provider, "provider", "", "AI provider (cli, openai, gemini, groq, claude-api, zai)")
+	cmd.Flags().BoolVar(&opts.Reanalyze, "reanalyze", false, "Detect the project again instead of reusing the cached analysis")
+	cmd.Flags().IntVar(&opts.LintRetries, "lint-retries", claude.DefaultLintRetries, "Times to ask the AI to fix a regenerated agent, skill or command that fails validation")
+
+	return cmd
+}
+
+// runUpdate ejecuta la lógica principal del comando update.
+func runUpdate(cmd *cobra.Command, opts *UpdateOptions, args []string) error {
+	projectPath := "."
+	if len(args) > 0 {
+		projectPath = args[0]
+	}
+	projectPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve project path: %w", err)
+	}
+	if info, err := os.Stat(projectPath); err != nil || !info.IsDir() {
+		return fmt.Errorf("project path %s is not a directory", projectPath)
+	}
+
+	log := logger.New(cmd.ErrOrStderr(), logger.INFOLevel)
+	if verbose, err := cmd.Flags().GetBool("verbose"); err == nil && verbose {
+		log.SetLevel(logger.DEBUGLevel)
+	}
+
+	answers, err := generate.LoadAnswers(projectPath)
+	if err != nil {
+		return fmt.Errorf("failed to load project config: %w (run 'claude-init init' first)", err)
+	}
+
+	factory, settings, err := ai.NewProjectClientFactory(projectPath)
+	if err != nil {
+		return fmt.Errorf("failed to load project settings: %w", err)
+	}
+	provider := opts.Provider
+	if provider == "" {
+		provider = settings.Provider
+	}
+	if provider == "" {
+		provider = answers.AIProvider
+	}
+	client, err := factory.CreateClientFromString(provider)
+	if err != nil {
+		return fmt.Errorf("error creating AI client: %w", err)
+	}
+	defer client.Close()
+
+	generator := claude.NewGenerator(projectPath, answers, client)
+	generator.SetLogger(log)
+	generator.SetProjectSettings(settings)
+	generator.SetToolVersion(version.Version)
+	generator.SetLintRetries(opts.LintRetries)
+	if !opts.Reanalyze {
+		if cache, err := claude.NewAnalysisCache(projectPath); err == nil {
+			generator.SetAnalysisCache(cache)
+		}
+	}
+
+	return Update(cmd.OutOrStdout(), projectPath, generator, settings, opts, log)
+}
+
+// Update planifica y aplica la actualización del proyecto con el generador indicado, y
+// escribe en out qué se ha hecho (o, con DryRun, qué se haría) con cada archivo.
+func Update(out io.Writer, projectPath string, generator *claude.Generator, settings *config.ProjectSettings, opts *UpdateOptions, log *logger.Logger) error {
+	manifest, err := claude.LoadManifest(projectPath)
+	if err != nil {
+		return err
+	}
+
+	// La recomendación solo se vuelve a pedir si han cambiado las respuestas o las dependencias
+	recommendation := manifest.Recommendation()
+	if generator.InputsChanged(manifest) {
+		log.Info("Project changed since the last generation, getting structure recommendations...")
+		if rec, err := generator.GetRecommendation(); err == nil {
+			recommendation = rec
+		} else {
+			log.Warn("Failed to get recommendation from AI provider, keeping the current structure: %v", err)
+		}
+	}
+	recommendation.Agents = settings.Agents.Apply(recommendation.Agents)
+	recommendation.Skills = settings.Skills.Apply(recommendation.Skills)
+	recommendation.Commands = settings.Commands.Apply(recommendation.Commands)
+
+	plan, err := generator.PlanUpdate(recommendation)
+	if err != nil {
+		return err
+	}
+	if plan.Empty() {
+		fmt.Fprintln(out, "Everything is up to date.")
+		return nil
+	}
+
+	if opts.DryRun {
+		return WritePlan(out, plan)
+	}
+
+	// Actualizar en un staging que solo reemplaza la configuración actual si todo termina bien
+	stage, err := snapshot.NewStage(projectPath, claude.ManagedPaths(projectPath, generator.Packages()))
+	if err != nil {
+		return err
+	}
+	defer stage.Discard()
+	generator.SetOutputDir(stage.Dir())
+
+	results, err := generator.Update(plan, claude.UpdateOptions{Merge: !opts.NoMerge, Prune: opts.Prune})
+	if err != nil {
+		return err
+	}
+
+	store, err := snapshot.NewStore(projectPath)
+	if err != nil {
+		return err
+	}
+	if _, err := stage.Commit(store, "update"); err != nil {
+		return err
+	}
+	return WriteResults(out, results)
+}
+
+// WritePlan escribe los archivos que se regenerarían, con el motivo, los que ya no se
+// recomiendan y las skills que se migrarían al formato de directorio.
+func WritePlan(out io.Writer, plan *claude.UpdatePlan) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FILE\tACTION\tREASON")
+	for _, item := range plan.Items {
+		action := "regenerate"
+		if len(item.Reasons) == 1 && item.Reasons[0] == claude.ReasonNew {
+			action = "create"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", item.Path, action, strings.Join(item.Reasons, ", "))
+	}
+	for _, entry := range plan.Obsolete {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", entry.Path, claude.UpdateObsolete, "no longer recommended")
+	}
+	for _, path := range plan.Migrate {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", path, "migrate", "moves to the skills/<name>/SKILL.md layout")
+	}
+	return w.Flush()
+}
+
+// WriteResults escribe qué se ha hecho con cada archivo y avisa de los conflictos.
+func WriteResults(out io.Writer, results []claude.UpdateResult) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FILE\tRESULT\tDETAIL")
+	conflicts := 0
+	for _, result := range results {
+		if result.Outcome == claude.UpdateConflict {
+			conflicts++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", result.Path, result.Outcome, result.Detail)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if conflicts > 0 {
+		fmt.Fprintf(out, "\n%d file(s) have merge conflicts: resolve the <<<<<<< / >>>>>>> blocks by hand.\n", conflicts)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package update
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/ai"
+	"github.com/drossan/claude-init/internal/claude"
+	"github.com/drossan/claude-init/internal/config"
+	"github.com/drossan/claude-init/internal/logger"
+	"github.com/drossan/claude-init/internal/survey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockClient is a mock implementation of ai.Client that always returns the same document.
+type mockClient struct{}
+
+func (m *mockClient) SendMessage(systemPrompt, userMessage string) (string, error) {
+	return "---\nname: generated\ndescription: Generated\n---\n# Generated\n", nil
+}
+
+func (m *mockClient) SendSimpleMessage(message string) (string, error) {
+	return m.SendMessage("", message)
+}
+
+func (m *mockClient) Provider() ai.Provider {
+	return "mock"
+}
+
+func (m *mockClient) IsAvailable() (bool, error) {
+	return true, nil
+}
+
+func (m *mockClient) Close() error {
+	return nil
+}
+
+// TestNewUpdateCommand_Flags verifica que el comando define sus flags.
+func TestNewUpdateCommand_Flags(t *testing.T) {
+	cmd := NewUpdateCommand()
+
+	assert.Equal(t, "update [path]", cmd.Use)
+	for _, flag := range []string{"dry-run", "no-merge", "prune", "provider", "reanalyze"} {
+		assert.NotNil(t, cmd.Flags().Lookup(flag), "flag %s should be defined", flag)
+	}
+}
+
+// TestUpdate verifica el informe de update: nada que hacer, el plan con --dry-run y lo
+// hecho con cada archivo.
+func TestUpdate(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	dir := t.TempDir()
+	base := claude.GetBaseItems()
+	settings := &config.ProjectSettings{
+		Agents:   config.ItemSelection{Exclude: base.Agents},
+		Commands: config.ItemSelection{Exclude: base.Commands},
+		Skills:   config.ItemSelection{Exclude: base.Skills},
+	}
+	answers := &survey.Answers{ProjectName: "acme", Language: "Go"}
+	newGenerator := func() *claude.Generator {
+		g := claude.NewGenerator(dir, answers, &mockClient{})
+		g.SetProjectSettings(settings)
+		return g
+	}
+	log := logger.New(io.Discard, logger.INFOLevel)
+
+	// Sin manifiesto no hay nada que actualizar
+	err := Update(io.Discard, dir, newGenerator(), settings, &UpdateOptions{}, log)
+	assert.ErrorContains(t, err, "run 'claude-init init' first")
+
+	require.NoError(t, newGenerator().GenerateAll(&claude.Recommendation{Commands: []string{"test"}}))
+
+	var out bytes.Buffer
+	require.NoError(t, Update(&out, dir, newGenerator(), settings, &UpdateOptions{}, log))
+	assert.Equal(t, "Everything is up to date.\n", out.String())
+
+	// Una dependencia nueva y una edición a mano
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/acme\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".claude", "commands", "test.md"), []byte("# Mine\n"), 0644))
+
+	out.Reset()
+	require.NoError(t, Update(&out, dir, newGenerator(), settings, &UpdateOptions{DryRun: true}, log))
+	assert.Regexp(t, `CLAUDE.md\s+regenerate\s+dependencies changed`, out.String())
+	assert.Regexp(t, `\.claude/commands/test.md\s+regenerate\s+dependencies changed`, out.String())
+
+	out.Reset()
+	require.NoError(t, Update(&out, dir, newGenerator(), settings, &UpdateOptions{NoMerge: true}, log))
+	assert.Regexp(t, `\.claude/commands/test.md\s+kept\s+edited by the user`, out.String())
+	assert.Regexp(t, `CLAUDE.md\s+unchanged`, out.String())
+
+	content, err := os.ReadFile(filepath.Join(dir, ".claude", "commands", "test.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# Mine\n", string(content))
+
+	// Un paquete nuevo en las respuestas: su CLAUDE.md se crea en el proyecto y queda al día
+	for path, content := range map[string]string{
+		"go.work":       "go 1.22\n\nuse (\n\t./api\n\t./worker\n)\n",
+		"api/go.mod":    "module example.com/api\n",
+		"worker/go.mod": "module example.com/worker\n",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0644))
+	}
+	answers.Packages = []string{"api"}
+	out.Reset()
+	require.NoError(t, Update(&out, dir, newGenerator(), settings, &UpdateOptions{NoMerge: true}, log))
+	assert.Regexp(t, `api/CLAUDE.md\s+created`, out.String())
+	assert.FileExists(t, filepath.Join(dir, "api", "CLAUDE.md"))
+
+	out.Reset()
+	require.NoError(t, Update(&out, dir, newGenerator(), settings, &UpdateOptions{DryRun: true}, log))
+	assert.NotContains(t, out.String(), "api/CLAUDE.md")
+}
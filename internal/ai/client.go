@@ -24,6 +24,19 @@ type Client interface {
 	Close() error
 }
 
+// ModelReporter lo implementan los clientes que conocen el modelo que usan.
+type ModelReporter interface {
+	Model() string
+}
+
+// ModelOf retorna el modelo del cliente o "" si no lo indica.
+func ModelOf(client Client) string {
+	if reporter, ok := client.(ModelReporter); ok {
+		return reporter.Model()
+	}
+	return ""
+}
+
 // ValidationResult contiene el resultado de validar las respuestas del usuario.
 type ValidationResult struct {
 	IsValid     bool     // true si las respuestas son válidas
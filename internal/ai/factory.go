@@ -31,6 +31,35 @@ func NewClientFactory() *ClientFactory {
 	}
 }
 
+// NewClientFactoryWithConfig crea una fábrica de clientes con una configuración ya cargada.
+// Se usa cuando la configuración global se ha combinado con la del proyecto (.claude-init.yaml).
+func NewClientFactoryWithConfig(cfg *config.GlobalConfig) *ClientFactory {
+	if cfg == nil {
+		return NewClientFactory()
+	}
+	return &ClientFactory{
+		config: cfg,
+	}
+}
+
+// NewProjectClientFactory crea una fábrica de clientes combinando la configuración global
+// con la configuración del proyecto ubicado en projectPath.
+func NewProjectClientFactory(projectPath string) (*ClientFactory, *config.ProjectSettings, error) {
+	settings, err := config.LoadProjectSettings(projectPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = &config.GlobalConfig{
+			Providers: make(map[string]config.ProviderConfig),
+		}
+	}
+
+	return NewClientFactoryWithConfig(cfg.Merge(settings)), settings, nil
+}
+
 // CreateClient crea un cliente según el provider especificado.
 func (f *ClientFactory) CreateClient(provider Provider) (Client, error) {
 	switch provider {
@@ -149,6 +178,11 @@ func (c *ClaudeAPIClient) Provider() Provider {
 	return ProviderClaudeAPI
 }
 
+// Model retorna el modelo que usa el cliente.
+func (c *ClaudeAPIClient) Model() string {
+	return c.client.Model()
+}
+
 // IsAvailable siempre retorna true (si hay API key configurada).
 func (c *ClaudeAPIClient) IsAvailable() (bool, error) {
 	return true, nil
@@ -179,6 +213,11 @@ func (c *OpenAIClient) Provider() Provider {
 	return ProviderOpenAI
 }
 
+// Model retorna el modelo que usa el cliente.
+func (c *OpenAIClient) Model() string {
+	return c.client.Model()
+}
+
 // IsAvailable siempre retorna true (si hay API key configurada).
 func (c *OpenAIClient) IsAvailable() (bool, error) {
 	return true, nil
@@ -209,6 +248,11 @@ func (c *ZAIClient) Provider() Provider {
 	return ProviderZAI
 }
 
+// Model retorna el modelo que usa el cliente.
+func (c *ZAIClient) Model() string {
+	return c.client.Model()
+}
+
 // IsAvailable siempre retorna true (si hay API key configurada).
 func (c *ZAIClient) IsAvailable() (bool, error) {
 	return true, nil
@@ -239,6 +283,11 @@ func (c *GeminiClient) Provider() Provider {
 	return ProviderGemini
 }
 
+// Model retorna el modelo que usa el cliente.
+func (c *GeminiClient) Model() string {
+	return c.client.Model()
+}
+
 // IsAvailable siempre retorna true (si hay API key configurada).
 func (c *GeminiClient) IsAvailable() (bool, error) {
 	return true, nil
@@ -269,6 +318,11 @@ func (c *GroqClient) Provider() Provider {
 	return ProviderGroq
 }
 
+// Model retorna el modelo que usa el cliente.
+func (c *GroqClient) Model() string {
+	return c.client.Model()
+}
+
 // IsAvailable siempre retorna true (si hay API key configurada).
 func (c *GroqClient) IsAvailable() (bool, error) {
 	return true, nil
@@ -2,6 +2,8 @@ package ai
 
 import (
 	"testing"
+
+	"github.com/drossan/claude-init/internal/config"
 )
 
 // TestCLIClientIsAvailable verifies that CLIClient.IsAvailable works correctly
@@ -48,3 +50,32 @@ func TestCLIClientHasWrapper(t *testing.T) {
 		t.Error("CLIClient.wrapper is nil, this will cause panic when calling methods")
 	}
 }
+
+// TestModelOf verifies that API clients report the model from the provider
+// config, falling back to the provider default, and that the CLI reports none.
+func TestModelOf(t *testing.T) {
+	factory := NewClientFactoryWithConfig(&config.GlobalConfig{
+		Providers: map[string]config.ProviderConfig{
+			"openai": {APIKey: "key", Model: "gpt-4o"},
+			"groq":   {APIKey: "key"},
+		},
+	})
+
+	tests := []struct {
+		provider Provider
+		want     string
+	}{
+		{ProviderOpenAI, "gpt-4o"},
+		{ProviderGroq, "llama-3.3-70b-versatile"},
+		{ProviderCLI, ""},
+	}
+	for _, tt := range tests {
+		client, err := factory.CreateClient(tt.provider)
+		if err != nil {
+			t.Fatalf("CreateClient(%s) failed: %v", tt.provider, err)
+		}
+		if got := ModelOf(client); got != tt.want {
+			t.Errorf("ModelOf(%s) = %q, want %q", tt.provider, got, tt.want)
+		}
+	}
+}
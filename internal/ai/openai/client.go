@@ -163,6 +163,11 @@ func (c *Client) SendSimpleMessage(message string) (string, error) {
 	return c.SendMessage("", message)
 }
 
+// Model retorna el modelo que usa el cliente.
+func (c *Client) Model() string {
+	return c.model
+}
+
 // Close cierra el cliente y libera recursos.
 func (c *Client) Close() error {
 	// Nada que cerrar para el cliente HTTP básico
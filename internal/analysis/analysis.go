@@ -0,0 +1,246 @@
+// Package analysis contiene el análisis de proyectos existentes que comparten los comandos
+// analyze e init: el informe con el análisis y los directorios de documentación, su salida
+// como JSON, YAML o tabla, y su conversión en respuestas del survey.
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/drossan/claude-init/internal/ai"
+	"github.com/drossan/claude-init/internal/claude"
+	"github.com/drossan/claude-init/internal/detector"
+	"github.com/drossan/claude-init/internal/logger"
+	"github.com/drossan/claude-init/internal/scanner"
+	"github.com/drossan/claude-init/internal/survey"
+	"gopkg.in/yaml.v3"
+)
+
+// Formatos de salida del informe.
+const (
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+	FormatTable = "table"
+)
+
+// ConventionsKey es la clave de las convenciones de código en las respuestas. Se unen
+// con saltos de línea en lugar de comas porque cada convención puede contener comas.
+const ConventionsKey = "conventions"
+
+// Options son las opciones del análisis de un proyecto existente.
+type Options struct {
+	Scan      scanner.Options
+	Reanalyze bool // Ignora el análisis en caché
+}
+
+// Report es el análisis del proyecto más sus directorios de documentación: la salida de
+// claude-init analyze. Sus campos son compatibles con init --answers.
+type Report struct {
+	claude.ProjectAnalysis `yaml:",inline"`
+	DocumentationDirs      []string `json:"documentation_dirs,omitempty" yaml:"documentation_dirs,omitempty"`
+}
+
+// NewAnalyzer crea el analizador de proyectos existentes, con la caché de análisis del
+// usuario salvo que no se pueda determinar su directorio.
+func NewAnalyzer(projectPath string, client ai.Client, opts Options, log *logger.Logger) *claude.Analyzer {
+	analyzer := claude.NewAnalyzer(projectPath, client)
+	analyzer.SetLogger(log)
+	analyzer.SetScanOptions(opts.Scan)
+
+	cache, err := claude.NewAnalysisCache(projectPath)
+	if err != nil {
+		log.Debug("Analysis cache disabled: %v", err)
+		return analyzer
+	}
+	analyzer.SetCache(cache, opts.Reanalyze)
+	return analyzer
+}
+
+// Analyze analiza el proyecto con el cliente indicado (nil para un análisis solo heurístico).
+// Los análisis con IA se guardan en la caché del usuario y se reutilizan mientras el
+// repositorio no cambie.
+func Analyze(projectPath string, client ai.Client, opts Options, log *logger.Logger) (*Report, error) {
+	result, err := NewAnalyzer(projectPath, client, opts, log).Analyze()
+	if err != nil {
+		return nil, fmt.Errorf("project analysis failed: %w", err)
+	}
+
+	return &Report{
+		ProjectAnalysis:   *result,
+		DocumentationDirs: detector.DocumentationDirs(projectPath),
+	}, nil
+}
+
+// Answers convierte el resultado del análisis en respuestas pre-llenadas de un proyecto
+// existente.
+func Answers(result *claude.ProjectAnalysis) *survey.Answers {
+	answers := &survey.Answers{
+		ProjectOrigin:   "Existente",
+		ProjectName:     result.Name,
+		Description:     result.Description,
+		Language:        result.Language,
+		Framework:       result.Framework,
+		Architecture:    result.Architecture,
+		Database:        result.Database,
+		ProjectCategory: result.ProjectCategory,
+		BusinessContext: result.BusinessContext,
+		Conventions:     result.Conventions,
+	}
+	answers.Normalize()
+	return answers
+}
+
+// IsReport indica si un archivo de respuestas ya decodificado es la salida de analyze.
+func IsReport(raw map[string]interface{}) bool {
+	source, _ := raw["source"].(string)
+	_, hasName := raw["name"]
+	return hasName && (source == claude.AnalysisSourceAI || source == claude.AnalysisSourceHeuristic)
+}
+
+// AnswerValues convierte el informe en respuestas de un proyecto existente, con las claves
+// de un archivo de respuestas y sin las vacías.
+func (r *Report) AnswerValues() map[string]string {
+	answers := Answers(&r.ProjectAnalysis)
+	answers.DocumentationDirs = r.DocumentationDirs
+
+	values := answers.ToMap()
+	values[ConventionsKey] = strings.Join(r.Conventions, "\n")
+	for key, value := range values {
+		if value == "" {
+			delete(values, key)
+		}
+	}
+	return values
+}
+
+// Write escribe el análisis en el formato indicado.
+func Write(w io.Writer, report *Report, format string) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("failed to encode analysis: %w", err)
+		}
+	case FormatYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("failed to encode analysis: %w", err)
+		}
+		return encoder.Close()
+	default:
+		return writeTable(w, report)
+	}
+	return nil
+}
+
+// writeTable escribe el análisis como tablas legibles agrupadas por sección.
+func writeTable(w io.Writer, report *Report) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	row := func(label, value string) {
+		if value != "" {
+			fmt.Fprintf(tw, "%s\t%s\n", label, value)
+		}
+	}
+	first := true
+	section := func(title string) {
+		if !first {
+			fmt.Fprintln(tw)
+		}
+		first = false
+		fmt.Fprintln(tw, strings.ToUpper(title))
+	}
+
+	section("Project")
+	row("Name", report.Name)
+	row("Description", report.Description)
+	row("Language", report.Language)
+	row("Framework", report.Framework)
+	row("Architecture", report.Architecture)
+	row("Database", report.Database)
+	row("Category", report.ProjectCategory)
+	row("Testing", report.TestingFramework)
+	row("Business context", report.BusinessContext)
+	row("Source", report.Source)
+	row("Documentation", strings.Join(report.DocumentationDirs, ", "))
+
+	if detection := report.Detection; detection != nil {
+		if len(detection.Languages) > 0 {
+			section("Languages")
+			for _, share := range detection.Languages {
+				row(share.Language, fmt.Sprintf("%d files", share.Files))
+			}
+		}
+
+		if len(detection.Dependencies) > 0 {
+			section("Dependencies")
+			ecosystems := make([]string, 0, len(detection.Dependencies))
+			for ecosystem := range detection.Dependencies {
+				ecosystems = append(ecosystems, ecosystem)
+			}
+			sort.Strings(ecosystems)
+			for _, ecosystem := range ecosystems {
+				row(ecosystem, strings.Join(detection.Dependencies[ecosystem], ", "))
+			}
+			row("Skills", strings.Join(detection.Skills, ", "))
+		}
+
+		if tooling := detection.Tooling; tooling != nil && len(tooling.Commands) > 0 {
+			section("Commands")
+			for _, command := range tooling.Commands {
+				fmt.Fprintf(tw, "%s\t%s\t%s\n", command.Kind, command.Source, command.Command)
+			}
+		}
+
+		if len(detection.CI) > 0 || !detection.Tooling.Empty() {
+			section("Infrastructure")
+			row("CI", strings.Join(detection.CI, ", "))
+			if tooling := detection.Tooling; tooling != nil {
+				row("Make targets", strings.Join(tooling.MakeTargets, ", "))
+				row("Containers", strings.Join(tooling.Containers, "; "))
+				row("Infrastructure", strings.Join(tooling.Infrastructure, "; "))
+			}
+		}
+
+		if history := detection.History; history != nil {
+			section("History")
+			row("Commits", fmt.Sprintf("%d (%d contributors)", history.Commits, history.Contributors))
+			row("Convention", history.CommitConvention)
+			row("Commit types", strings.Join(history.CommitTypes, ", "))
+			row("Tickets", history.TicketPattern)
+			row("Branches", strings.Join(history.BranchPrefixes, ", "))
+			for _, hot := range history.HotPaths {
+				row("Hot path", fmt.Sprintf("%s (%d changes)", hot.Path, hot.Changes))
+			}
+			if history.CodeChanges > 0 {
+				row("Test/code changes", fmt.Sprintf("%.2f", history.TestRatio()))
+			}
+		}
+
+		if len(detection.Documentation) > 0 {
+			section("Docs")
+			row("Files", strings.Join(detection.Documentation, ", "))
+		}
+
+		if workspace := detection.Workspace; workspace != nil {
+			section("Packages")
+			for _, pkg := range workspace.Packages {
+				row(pkg.Summary(), strings.Join(pkg.Commands, ", "))
+			}
+		}
+	}
+
+	if len(report.Conventions) > 0 {
+		section("Conventions")
+		for _, convention := range report.Conventions {
+			fmt.Fprintf(tw, "-\t%s\n", convention)
+		}
+	}
+
+	return tw.Flush()
+}
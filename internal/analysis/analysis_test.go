@@ -0,0 +1,76 @@
+package analysis
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/claude"
+	"github.com/drossan/claude-init/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// writeProject crea un proyecto Go mínimo con Makefile y documentación.
+func writeProject(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":      "module example.com/billing\n\nrequire github.com/spf13/cobra v1.8.0\n",
+		"main.go":     "package main\n\nfunc main() {}\n",
+		"Makefile":    "build:\n\tgo build ./...\n\ntest:\n\tgo test ./...\n",
+		"docs/api.md": "# API\n",
+	}
+	for rel, content := range files {
+		path := filepath.Join(dir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+// TestWrite_YAMLAndTable verifica los formatos YAML y tabla.
+func TestWrite_YAMLAndTable(t *testing.T) {
+	report, err := Analyze(writeProject(t), nil, Options{}, logger.New(io.Discard, logger.WARNLevel))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, Write(&out, report, FormatYAML))
+	var decoded Report
+	require.NoError(t, yaml.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, report.Name, decoded.Name)
+	assert.Equal(t, report.Detection.Tooling.Commands, decoded.Detection.Tooling.Commands)
+
+	out.Reset()
+	require.NoError(t, Write(&out, report, FormatTable))
+	assert.Contains(t, out.String(), "PROJECT\n")
+	assert.Contains(t, out.String(), "COMMANDS\n")
+	assert.Regexp(t, `test\s+Makefile\s+make test`, out.String())
+}
+
+// TestReport_AnswerValues verifica la conversión del informe en respuestas.
+func TestReport_AnswerValues(t *testing.T) {
+	report := &Report{
+		ProjectAnalysis: claude.ProjectAnalysis{
+			Name:        "billing-api",
+			Language:    "go",
+			Conventions: []string{"Errors wrapped with %w, never ignored", "Table-driven tests"},
+			Source:      claude.AnalysisSourceHeuristic,
+		},
+		DocumentationDirs: []string{"docs"},
+	}
+
+	values := report.AnswerValues()
+	assert.Equal(t, "Existente", values["project_origin"])
+	assert.Equal(t, "billing-api", values["project_name"])
+	assert.Equal(t, "Go", values["language"])
+	assert.Equal(t, "docs", values["documentation_dirs"])
+	assert.Equal(t, "Errors wrapped with %w, never ignored\nTable-driven tests", values[ConventionsKey])
+	assert.NotContains(t, values, "database")
+
+	assert.True(t, IsReport(map[string]interface{}{"name": "billing-api", "source": claude.AnalysisSourceAI}))
+	assert.False(t, IsReport(map[string]interface{}{"project_name": "billing-api"}))
+}
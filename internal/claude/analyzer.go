@@ -6,29 +6,61 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/drossan/claude-init/internal/ai"
+	"github.com/drossan/claude-init/internal/detector"
+	"github.com/drossan/claude-init/internal/scanner"
+)
+
+// Origen del análisis de un proyecto.
+const (
+	// AnalysisSourceAI indica que la IA refinó la detección heurística.
+	AnalysisSourceAI = "ai"
+	// AnalysisSourceHeuristic indica que el análisis se hizo solo con heurísticas locales, sin IA.
+	AnalysisSourceHeuristic = "heuristic"
 )
 
 // ProjectAnalysis contiene el análisis del proyecto extraído por Claude.
 type ProjectAnalysis struct {
-	Name             string `json:"name"`
-	Description      string `json:"description"`
-	Language         string `json:"language"`
-	Framework        string `json:"framework,omitempty"`
-	Architecture     string `json:"architecture"`
-	Database         string `json:"database,omitempty"`
-	ProjectCategory  string `json:"project_category"`
-	BusinessContext  string `json:"business_context"`
-	GitSystem        string `json:"git_system,omitempty"`
-	TestingFramework string `json:"testing_framework,omitempty"`
+	Name             string `json:"name" yaml:"name"`
+	Description      string `json:"description" yaml:"description"`
+	Language         string `json:"language" yaml:"language"`
+	Framework        string `json:"framework,omitempty" yaml:"framework,omitempty"`
+	Architecture     string `json:"architecture" yaml:"architecture"`
+	Database         string `json:"database,omitempty" yaml:"database,omitempty"`
+	ProjectCategory  string `json:"project_category" yaml:"project_category"`
+	BusinessContext  string `json:"business_context" yaml:"business_context"`
+	GitSystem        string `json:"git_system,omitempty" yaml:"git_system,omitempty"`
+	TestingFramework string `json:"testing_framework,omitempty" yaml:"testing_framework,omitempty"`
+
+	// Conventions son las convenciones de código observadas por la IA en las muestras de código.
+	Conventions []string `json:"conventions,omitempty" yaml:"conventions,omitempty"`
+	// SampledFiles son los archivos representativos enviados a la IA.
+	SampledFiles []string `json:"sampled_files,omitempty" yaml:"sampled_files,omitempty"`
+
+	// Source indica si el análisis lo refinó la IA o es solo heurístico.
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+	// Cached indica que el análisis se reutilizó de la caché sin escanear ni llamar a la IA.
+	Cached bool `json:"cached,omitempty" yaml:"cached,omitempty"`
+	// Detection contiene el resultado de la detección heurística sin IA.
+	Detection *detector.Result `json:"detection,omitempty" yaml:"detection,omitempty"`
 }
 
-// Analyzer analiza proyectos existentes usando un Client de IA.
+// Analyzer analiza proyectos existentes.
+//
+// Primero ejecuta el detector heurístico (sin IA) y después, si hay un Client,
+// envía su resultado a la IA para que lo refine y complete la descripción y el
+// contexto de negocio. Si la IA no está disponible o falla, retorna el análisis heurístico.
 type Analyzer struct {
 	projectPath string
 	logger      Logger
 	client      ai.Client
+	detection   *detector.Result
+	scanOptions scanner.Options
+	samples     []scanner.Sample
+	cache       *AnalysisCache
+	reanalyze   bool
 }
 
 // Logger es la interfaz que debe cumplir el logger.
@@ -52,10 +84,60 @@ func (a *Analyzer) SetLogger(logger Logger) {
 	a.logger = logger
 }
 
-// Analyze ejecuta el análisis del proyecto.
+// SetScanOptions establece los globs de inclusión/exclusión y el presupuesto de tokens del escaneo.
+func (a *Analyzer) SetScanOptions(opts scanner.Options) {
+	a.scanOptions = opts
+}
+
+// SetCache establece la caché de análisis. Con reanalyze se ignora el análisis guardado,
+// pero el nuevo resultado se guarda igualmente.
+func (a *Analyzer) SetCache(cache *AnalysisCache, reanalyze bool) {
+	a.cache = cache
+	a.reanalyze = reanalyze
+}
+
+// Analyze ejecuta el análisis del proyecto. Con IA y caché, reutiliza el análisis guardado
+// mientras el HEAD de git, los manifiestos y las opciones de escaneo no cambien.
 func (a *Analyzer) Analyze() (*ProjectAnalysis, error) {
-	// Primero escanear el proyecto localmente
+	if a.client == nil {
+		a.detect()
+		return a.AnalyzeOffline()
+	}
+	if a.cache == nil {
+		a.detect()
+		return a.analyzeWithAI()
+	}
+
+	key, head, manifestHash := a.cache.Key(a.scanOptions)
+	if !a.reanalyze {
+		if entry, ok := a.cache.Load(key); ok {
+			a.logInfo("Usando el análisis en caché del %s (usa --reanalyze para repetirlo)", entry.CreatedAt.Local().Format("2006-01-02 15:04"))
+			a.detection = entry.Analysis.Detection
+			entry.Analysis.Cached = true
+			return entry.Analysis, nil
+		}
+	}
+
+	a.detect()
+	analysis, err := a.analyzeWithAI()
+	if err != nil || analysis.Source != AnalysisSourceAI {
+		return analysis, err
+	}
+
+	entry := &CacheEntry{Key: key, GitHead: head, ManifestHash: manifestHash, CreatedAt: time.Now(), Analysis: analysis}
+	if err := a.cache.Save(entry); err != nil {
+		a.logWarn("Could not cache the analysis: %v", err)
+	}
+	return analysis, nil
+}
+
+// analyzeWithAI envía el escaneo, las muestras de código y la detección a la IA. Si la IA
+// falla, retorna el análisis heurístico.
+func (a *Analyzer) analyzeWithAI() (*ProjectAnalysis, error) {
+	// Escanear el proyecto localmente y extraer muestras de código representativas
 	projectInfo := a.scanProject()
+	a.samples = scanner.NewScanner(a.projectPath, a.scanOptions).Samples()
+	a.logDebug("Sampled %d representative files", len(a.samples))
 
 	prompt := a.buildAnalysisPrompt(projectInfo)
 	systemPrompt := a.buildSystemPrompt()
@@ -64,6 +146,10 @@ func (a *Analyzer) Analyze() (*ProjectAnalysis, error) {
 
 	output, err := a.client.SendMessage(systemPrompt, prompt)
 	if err != nil {
+		if a.detection != nil {
+			a.logWarn("AI analysis failed, using heuristic analysis: %v", err)
+			return a.AnalyzeOffline()
+		}
 		return nil, fmt.Errorf("analysis failed: %w", err)
 	}
 
@@ -71,13 +157,74 @@ func (a *Analyzer) Analyze() (*ProjectAnalysis, error) {
 
 	analysis, err := a.parseAnalysis(output)
 	if err != nil {
+		if a.detection != nil {
+			a.logWarn("Could not parse AI analysis, using heuristic analysis: %v", err)
+			return a.AnalyzeOffline()
+		}
 		return nil, fmt.Errorf("parse failed: %w", err)
 	}
 
+	analysis.Source = AnalysisSourceAI
+	for _, sample := range a.samples {
+		analysis.SampledFiles = append(analysis.SampledFiles, sample.Path)
+	}
 	a.logDebug("Analysis completed successfully")
 	return analysis, nil
 }
 
+// AnalyzeOffline analiza el proyecto solo con el detector heurístico, sin usar la IA.
+func (a *Analyzer) AnalyzeOffline() (*ProjectAnalysis, error) {
+	if a.detection == nil && !a.detect() {
+		return nil, fmt.Errorf("heuristic analysis failed for %s", a.projectPath)
+	}
+
+	analysis := &ProjectAnalysis{Source: AnalysisSourceHeuristic}
+	a.applyDetection(analysis)
+	a.applyDefaults(analysis)
+
+	a.logDebug("Heuristic analysis: language=%s, frameworks=%v, architecture=%s",
+		analysis.Language, a.detection.Frameworks, analysis.Architecture)
+	return analysis, nil
+}
+
+// detect ejecuta el detector heurístico y guarda el resultado. Retorna false si falla.
+func (a *Analyzer) detect() bool {
+	detection, err := detector.NewDetectorWithOptions(a.projectPath, a.scanOptions).Detect()
+	if err != nil {
+		a.logDebug("Heuristic detection failed: %v", err)
+		return false
+	}
+	a.detection = detection
+	return true
+}
+
+// applyDetection completa los campos vacíos del análisis con el resultado heurístico.
+func (a *Analyzer) applyDetection(analysis *ProjectAnalysis) {
+	detection := a.detection
+	if detection == nil {
+		return
+	}
+	analysis.Detection = detection
+
+	fill := func(field *string, value string) {
+		if strings.TrimSpace(*field) == "" {
+			*field = value
+		}
+	}
+
+	fill(&analysis.Name, detection.Name)
+	fill(&analysis.Description, detection.Description)
+	fill(&analysis.Language, detection.Language)
+	fill(&analysis.Framework, strings.Join(detection.Frameworks, ", "))
+	fill(&analysis.Architecture, detection.Architecture)
+	fill(&analysis.Database, strings.Join(detection.Databases, ", "))
+	fill(&analysis.ProjectCategory, detection.ProjectCategory)
+	fill(&analysis.TestingFramework, strings.Join(detection.TestFrameworks, ", "))
+	if _, err := os.Stat(filepath.Join(a.projectPath, ".git")); err == nil {
+		fill(&analysis.GitSystem, "git")
+	}
+}
+
 // buildSystemPrompt construye el system prompt para Claude.
 func (a *Analyzer) buildSystemPrompt() string {
 	return `You are an expert software project analyst. Your task is to analyze existing projects and extract structured information about them.
@@ -100,6 +247,7 @@ Based on this file structure and configuration, identify:
 6. Project category (API, Web App, CLI, Library, etc.)
 7. Business context and purpose
 8. Testing framework if present
+9. Coding conventions visible in the code samples (naming, error handling, project layout, test style)
 
 Respond with a JSON object using this exact structure:
 {
@@ -112,10 +260,38 @@ Respond with a JSON object using this exact structure:
   "project_category": "project type (REST API, Web App, CLI, Library, etc.)",
   "business_context": "business context and project purpose",
   "git_system": "version control system (git, svn, etc., or empty string)",
-  "testing_framework": "testing framework (optional, or empty string)"
+  "testing_framework": "testing framework (optional, or empty string)",
+  "conventions": ["short, concrete convention observed in the code samples (empty array if there are no samples)"]
+}
+
+CRITICAL: Respond with ONLY the raw JSON object. Do not include markdown code blocks, explanations, or any additional text.`, projectInfo+a.samplesPromptSection()+a.detectionPromptSection())
 }
 
-CRITICAL: Respond with ONLY the raw JSON object. Do not include markdown code blocks, explanations, or any additional text.`, projectInfo)
+// detectionPromptSection retorna la detección heurística para que la IA la refine.
+func (a *Analyzer) detectionPromptSection() string {
+	if a.detection == nil {
+		return ""
+	}
+	return fmt.Sprintf(`
+
+Deterministic detection (from manifests, file extensions and layout):
+%s
+
+Keep these detected values unless the project information clearly contradicts them.
+Your job is to refine them and to fill in the description and business context.`, a.detection.Summary())
+}
+
+// samplesPromptSection retorna las muestras de código representativas para que la IA
+// deduzca la arquitectura y las convenciones del código real.
+func (a *Analyzer) samplesPromptSection() string {
+	if len(a.samples) == 0 {
+		return ""
+	}
+	return `
+
+Representative source samples (entrypoints, routers, models, tests and config; long files show only their declarations):
+` + scanner.FormatSamples(a.samples) + `
+Base the architecture and the conventions on these samples, not only on the directory names.`
 }
 
 // scanProject escanea el directorio del proyecto y recopila información.
@@ -131,9 +307,9 @@ func (a *Analyzer) scanProject() string {
 		info.WriteString("Version control: git\n")
 	}
 
-	// Escanear estructura de directorios
+	// Escanear estructura de directorios respetando los ignorados y el presupuesto de tokens
 	info.WriteString("\nDirectory structure:\n")
-	a.scanDirectory(a.projectPath, "", &info, 0)
+	info.WriteString(scanner.NewScanner(a.projectPath, a.scanOptions).Tree())
 
 	// Leer archivos de configuración importantes
 	info.WriteString("\n\nConfiguration files:\n")
@@ -156,68 +332,28 @@ func (a *Analyzer) scanProject() string {
 		}
 	}
 
-	return info.String()
-}
-
-// scanDirectory escanea recursivamente un directorio y escribe su estructura.
-func (a *Analyzer) scanDirectory(dir, prefix string, info *strings.Builder, depth int) {
-	if depth > 5 { // Limitar profundidad
-		return
-	}
-
-	entries, err := os.ReadDir(dir)
-	if err != nil {
-		return
+	// En monorepos, incluir los paquetes y sus manifiestos
+	if a.detection != nil && a.detection.Workspace != nil {
+		a.scanWorkspace(a.detection.Workspace, configFiles, &info)
 	}
 
-	// Directorios a ignorar
-	ignoreDirs := map[string]bool{
-		"node_modules": true,
-		"vendor":       true,
-		".git":         true,
-		"dist":         true,
-		"build":        true,
-		"target":       true,
-		"bin":          true,
-		"obj":          true,
-		".venv":        true,
-		"venv":         true,
-		"__pycache__":  true,
-		".claude":      true,
-		".idea":        true,
-		".vscode":      true,
-	}
-
-	dirs := []string{}
-	files := []string{}
-
-	for _, entry := range entries {
-		name := entry.Name()
-		if ignoreDirs[name] {
-			continue
-		}
-
-		if entry.IsDir() {
-			dirs = append(dirs, name)
-		} else {
-			files = append(files, name)
-		}
-	}
+	return info.String()
+}
 
-	// Mostrar primero los directorios
-	for _, dirName := range dirs {
-		fullPath := filepath.Join(dir, dirName)
-		info.WriteString(fmt.Sprintf("%s%s/\n", prefix, dirName))
-		a.scanDirectory(fullPath, prefix+"  ", info, depth+1)
+// scanWorkspace escribe los paquetes de un monorepo junto con sus manifiestos.
+func (a *Analyzer) scanWorkspace(workspace *detector.Workspace, configFiles []string, info *strings.Builder) {
+	info.WriteString(fmt.Sprintf("\n\nMonorepo packages (%s):\n", strings.Join(workspace.Tools, ", ")))
+	for _, pkg := range workspace.Packages {
+		info.WriteString(fmt.Sprintf("- %s\n", pkg.Summary()))
 	}
 
-	// Luego mostrar archivos (limitados)
-	for i, fileName := range files {
-		if i >= 10 { // Limitar a 10 archivos por directorio
-			info.WriteString(fmt.Sprintf("%s... (%d more files)\n", prefix, len(files)-10))
-			break
+	for _, pkg := range workspace.Packages {
+		for _, configFile := range configFiles {
+			rel := filepath.Join(filepath.FromSlash(pkg.Path), configFile)
+			if content, err := os.ReadFile(filepath.Join(a.projectPath, rel)); err == nil {
+				info.WriteString(fmt.Sprintf("\n--- %s ---\n%s\n", filepath.ToSlash(rel), truncateString(string(content), 300)))
+			}
 		}
-		info.WriteString(fmt.Sprintf("%s%s\n", prefix, fileName))
 	}
 }
 
@@ -233,7 +369,16 @@ func (a *Analyzer) parseAnalysis(output string) (*ProjectAnalysis, error) {
 		return nil, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	// Usar valores por defecto en lugar de fallar
+	// Completar con la detección heurística y usar valores por defecto en lugar de fallar
+	a.applyDetection(&analysis)
+	a.applyDefaults(&analysis)
+
+	a.logDebug("Parsed analysis: name=%s, language=%s, category=%s", analysis.Name, analysis.Language, analysis.ProjectCategory)
+	return &analysis, nil
+}
+
+// applyDefaults asigna valores por defecto a los campos requeridos vacíos.
+func (a *Analyzer) applyDefaults(analysis *ProjectAnalysis) {
 	if analysis.Name == "" {
 		analysis.Name = "Unknown Project"
 		a.logDebug("Missing field 'name', using default: 'Unknown Project'")
@@ -254,9 +399,6 @@ func (a *Analyzer) parseAnalysis(output string) (*ProjectAnalysis, error) {
 		analysis.BusinessContext = "General purpose software project"
 		a.logDebug("Missing field 'business_context', using default")
 	}
-
-	a.logDebug("Parsed analysis: name=%s, language=%s, category=%s", analysis.Name, analysis.Language, analysis.ProjectCategory)
-	return &analysis, nil
 }
 
 // extractJSON extrae el objeto JSON de una respuesta mixta.
@@ -323,6 +465,20 @@ func truncateString(s string, maxLen int) string {
 	return s[:maxLen] + "..."
 }
 
+// logWarn logs warning messages if logger is set.
+func (a *Analyzer) logWarn(format string, args ...interface{}) {
+	if a.logger != nil {
+		a.logger.Warn(format, args...)
+	}
+}
+
+// logInfo logs info messages if logger is set.
+func (a *Analyzer) logInfo(format string, args ...interface{}) {
+	if a.logger != nil {
+		a.logger.Info(format, args...)
+	}
+}
+
 // logDebug logs debug messages if logger is set.
 func (a *Analyzer) logDebug(format string, args ...interface{}) {
 	if a.logger != nil {
@@ -1,6 +1,9 @@
 package claude
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/drossan/claude-init/internal/ai"
@@ -337,3 +340,74 @@ func (m *mockLogger) Warn(format string, args ...interface{}) {
 func (m *mockLogger) Error(format string, args ...interface{}) {
 	m.errorMessages = append(m.errorMessages, format)
 }
+
+// newGoProject crea un proyecto Go mínimo con Gin y PostgreSQL para los tests del analizador.
+func newGoProject(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/shop\n\nrequire (\n\tgithub.com/gin-gonic/gin v1.9.1\n\tgithub.com/lib/pq v1.10.9\n)\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644))
+	return dir
+}
+
+// TestAnalyzer_Analyze_WithoutClient_UsesHeuristics verifica el análisis sin provider de IA.
+func TestAnalyzer_Analyze_WithoutClient_UsesHeuristics(t *testing.T) {
+	a := NewAnalyzer(newGoProject(t), nil)
+
+	analysis, err := a.Analyze()
+	require.NoError(t, err)
+
+	assert.Equal(t, AnalysisSourceHeuristic, analysis.Source)
+	assert.Equal(t, "shop", analysis.Name)
+	assert.Equal(t, "Go", analysis.Language)
+	assert.Equal(t, "Gin", analysis.Framework)
+	assert.Equal(t, "PostgreSQL", analysis.Database)
+	assert.Equal(t, "API REST", analysis.ProjectCategory)
+	assert.Equal(t, "Monolith", analysis.Architecture)
+	require.NotNil(t, analysis.Detection)
+}
+
+// TestAnalyzer_Analyze_AIFailure_FallsBackToHeuristics verifica que un fallo de la IA no pierde el análisis.
+func TestAnalyzer_Analyze_AIFailure_FallsBackToHeuristics(t *testing.T) {
+	client := &responseClient{err: fmt.Errorf("provider unavailable")}
+	a := NewAnalyzer(newGoProject(t), client)
+
+	analysis, err := a.Analyze()
+	require.NoError(t, err)
+
+	assert.Equal(t, AnalysisSourceHeuristic, analysis.Source)
+	assert.Equal(t, "Gin", analysis.Framework)
+}
+
+// TestAnalyzer_Analyze_AIRefinesDetection verifica que la IA recibe la detección y sus campos vacíos se completan.
+func TestAnalyzer_Analyze_AIRefinesDetection(t *testing.T) {
+	client := &responseClient{response: `{"name":"shop","description":"Online shop","language":"Go","architecture":"Hexagonal","project_category":"API REST","business_context":"E-commerce"}`}
+	a := NewAnalyzer(newGoProject(t), client)
+
+	analysis, err := a.Analyze()
+	require.NoError(t, err)
+
+	assert.Contains(t, client.lastPrompt, "Deterministic detection")
+	assert.Contains(t, client.lastPrompt, "- Frameworks: Gin")
+	assert.Equal(t, AnalysisSourceAI, analysis.Source)
+	assert.Equal(t, "Hexagonal", analysis.Architecture)
+	assert.Equal(t, "Gin", analysis.Framework, "los campos que la IA deja vacíos se completan con la detección")
+	assert.Equal(t, "PostgreSQL", analysis.Database)
+}
+
+// TestAnalyzer_Analyze_SendsSourceSamples verifica que la IA recibe muestras de código y retorna convenciones.
+func TestAnalyzer_Analyze_SendsSourceSamples(t *testing.T) {
+	client := &responseClient{response: `{"name":"shop","language":"Go","architecture":"Layered","project_category":"API REST","business_context":"E-commerce","conventions":["Errors are wrapped with fmt.Errorf and %w"]}`}
+	dir := newGoProject(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "handlers"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "handlers", "orders.go"), []byte("package handlers\n\nfunc ListOrders() {}\n"), 0644))
+	a := NewAnalyzer(dir, client)
+
+	analysis, err := a.Analyze()
+	require.NoError(t, err)
+
+	assert.Contains(t, client.lastPrompt, "Representative source samples")
+	assert.Contains(t, client.lastPrompt, "--- handlers/orders.go (router) ---\npackage handlers\n\nfunc ListOrders() {}")
+	assert.Equal(t, []string{"main.go", "handlers/orders.go"}, analysis.SampledFiles)
+	assert.Equal(t, []string{"Errors are wrapped with fmt.Errorf and %w"}, analysis.Conventions)
+}
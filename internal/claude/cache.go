@@ -0,0 +1,157 @@
+package claude
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/drossan/claude-init/internal/detector"
+	"github.com/drossan/claude-init/internal/scanner"
+)
+
+// cacheVersion se incluye en la clave para invalidar las cachés al cambiar el formato del análisis.
+const cacheVersion = "1"
+
+// stateFiles son los archivos, además de los manifiestos, cuyo contenido invalida la caché.
+var stateFiles = []string{"go.work", "pnpm-workspace.yaml", ".gitignore", scanner.IgnoreFile}
+
+// AnalysisCache guarda el último análisis de un proyecto para no repetir el escaneo ni la
+// llamada a la IA mientras el repositorio no cambie. La clave combina el HEAD de git, el
+// hash de los manifiestos y las opciones de escaneo.
+type AnalysisCache struct {
+	projectPath string
+	dir         string
+}
+
+// CacheEntry es el contenido del archivo de caché de un proyecto.
+type CacheEntry struct {
+	Key          string           `json:"key"`
+	GitHead      string           `json:"git_head,omitempty"`
+	ManifestHash string           `json:"manifest_hash"`
+	CreatedAt    time.Time        `json:"created_at"`
+	Analysis     *ProjectAnalysis `json:"analysis"`
+}
+
+// NewAnalysisCache crea la caché de análisis de un proyecto en el directorio de caché del
+// usuario (~/.cache/claude-init/analysis en Linux).
+func NewAnalysisCache(projectPath string) (*AnalysisCache, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user cache directory: %w", err)
+	}
+	return NewAnalysisCacheWithDir(projectPath, filepath.Join(dir, "claude-init", "analysis")), nil
+}
+
+// NewAnalysisCacheWithDir crea la caché de análisis de un proyecto en el directorio indicado.
+func NewAnalysisCacheWithDir(projectPath, dir string) *AnalysisCache {
+	if abs, err := filepath.Abs(projectPath); err == nil {
+		projectPath = abs
+	}
+	return &AnalysisCache{projectPath: projectPath, dir: dir}
+}
+
+// Path retorna el archivo de caché del proyecto. Cada proyecto tiene un único archivo,
+// nombrado por el hash de su ruta absoluta.
+func (c *AnalysisCache) Path() string {
+	sum := sha256.Sum256([]byte(c.projectPath))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:8])+".json")
+}
+
+// Key calcula la clave del estado actual del repositorio para las opciones de escaneo dadas.
+func (c *AnalysisCache) Key(opts scanner.Options) (key, head, manifestHash string) {
+	head, manifestHash = c.state()
+	options, _ := json.Marshal(opts)
+	sum := sha256.Sum256([]byte(strings.Join([]string{cacheVersion, head, manifestHash, string(options)}, "\x00")))
+	return hex.EncodeToString(sum[:]), head, manifestHash
+}
+
+// state retorna el HEAD de git y el hash de los manifiestos del proyecto.
+func (c *AnalysisCache) state() (head, manifestHash string) {
+	return gitHead(c.projectPath), DependencyHash(c.projectPath)
+}
+
+// DependencyHash retorna el hash de los manifiestos de dependencias del proyecto y de los
+// archivos de workspace e ignore. Cambia cuando se añaden o actualizan dependencias.
+func DependencyHash(projectPath string) string {
+	manifests := sha256.New()
+	files := append(detector.ManifestFiles(projectPath), stateFiles...)
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join(projectPath, filepath.FromSlash(file)))
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(manifests, "%s\x00%d\x00", file, len(content))
+		manifests.Write(content)
+	}
+	return hex.EncodeToString(manifests.Sum(nil))
+}
+
+// Load retorna el análisis guardado si su clave coincide con la indicada.
+func (c *AnalysisCache) Load(key string) (*CacheEntry, bool) {
+	entry, ok := c.read()
+	if !ok || entry.Key != key {
+		return nil, false
+	}
+	return entry, true
+}
+
+// Current retorna el análisis guardado si el repositorio no ha cambiado desde entonces,
+// sean cuales sean las opciones de escaneo con las que se hizo.
+func (c *AnalysisCache) Current() (*CacheEntry, bool) {
+	entry, ok := c.read()
+	if !ok {
+		return nil, false
+	}
+	if head, manifestHash := c.state(); entry.GitHead != head || entry.ManifestHash != manifestHash {
+		return nil, false
+	}
+	return entry, true
+}
+
+// read lee el archivo de caché del proyecto.
+func (c *AnalysisCache) read() (*CacheEntry, bool) {
+	content, err := os.ReadFile(c.Path())
+	if err != nil {
+		return nil, false
+	}
+
+	var entry CacheEntry
+	if err := json.Unmarshal(content, &entry); err != nil || entry.Analysis == nil {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// Save guarda el análisis con la clave indicada, sustituyendo el anterior.
+func (c *AnalysisCache) Save(entry *CacheEntry) error {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	content, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cached analysis: %w", err)
+	}
+
+	if err := os.WriteFile(c.Path(), content, 0644); err != nil {
+		return fmt.Errorf("failed to write cached analysis: %w", err)
+	}
+	return nil
+}
+
+// gitHead retorna el commit actual del repositorio, o "" si no es un repositorio git.
+func gitHead(projectPath string) string {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = projectPath
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
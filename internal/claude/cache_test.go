@@ -0,0 +1,89 @@
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAnalyzer_Analyze_UsesCache verifica que el segundo análisis se sirve de la caché sin
+// llamar a la IA, y que --reanalyze la ignora.
+func TestAnalyzer_Analyze_UsesCache(t *testing.T) {
+	dir := newGoProject(t)
+	cache := NewAnalysisCacheWithDir(dir, t.TempDir())
+	client := &responseClient{response: `{"name":"shop","language":"Go","architecture":"Hexagonal","project_category":"API REST","business_context":"E-commerce"}`}
+
+	a := NewAnalyzer(dir, client)
+	a.SetCache(cache, false)
+	first, err := a.Analyze()
+	require.NoError(t, err)
+	assert.False(t, first.Cached)
+	assert.FileExists(t, cache.Path())
+
+	client.lastPrompt = ""
+	a = NewAnalyzer(dir, client)
+	a.SetCache(cache, false)
+	second, err := a.Analyze()
+	require.NoError(t, err)
+	assert.True(t, second.Cached)
+	assert.Empty(t, client.lastPrompt, "un análisis en caché no debe llamar a la IA")
+	assert.Equal(t, "Hexagonal", second.Architecture)
+	require.NotNil(t, second.Detection)
+	assert.Equal(t, first.Detection.Frameworks, second.Detection.Frameworks)
+
+	a = NewAnalyzer(dir, client)
+	a.SetCache(cache, true)
+	third, err := a.Analyze()
+	require.NoError(t, err)
+	assert.False(t, third.Cached)
+	assert.NotEmpty(t, client.lastPrompt)
+}
+
+// TestAnalyzer_Analyze_DoesNotCacheHeuristics verifica que un análisis heurístico tras un
+// fallo de la IA no se guarda en la caché.
+func TestAnalyzer_Analyze_DoesNotCacheHeuristics(t *testing.T) {
+	dir := newGoProject(t)
+	cache := NewAnalysisCacheWithDir(dir, t.TempDir())
+
+	a := NewAnalyzer(dir, &responseClient{response: "not json"})
+	a.SetCache(cache, false)
+	analysis, err := a.Analyze()
+	require.NoError(t, err)
+
+	assert.Equal(t, AnalysisSourceHeuristic, analysis.Source)
+	assert.NoFileExists(t, cache.Path())
+}
+
+// TestAnalysisCache_Key verifica que la clave cambia con los manifiestos y las opciones de
+// escaneo, y que Current ignora las opciones.
+func TestAnalysisCache_Key(t *testing.T) {
+	dir := newGoProject(t)
+	cache := NewAnalysisCacheWithDir(dir, t.TempDir())
+
+	key, head, manifestHash := cache.Key(scanner.Options{})
+	again, _, _ := cache.Key(scanner.Options{})
+	assert.Equal(t, key, again)
+
+	withOptions, _, _ := cache.Key(scanner.Options{Exclude: []string{"vendor/"}})
+	assert.NotEqual(t, key, withOptions)
+
+	require.NoError(t, cache.Save(&CacheEntry{Key: key, GitHead: head, ManifestHash: manifestHash, Analysis: &ProjectAnalysis{Name: "shop"}}))
+	entry, ok := cache.Load(key)
+	require.True(t, ok)
+	assert.Equal(t, "shop", entry.Analysis.Name)
+	_, ok = cache.Load(withOptions)
+	assert.False(t, ok)
+	_, ok = cache.Current()
+	assert.True(t, ok)
+
+	// Un cambio en go.mod invalida la caché
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/shop\n\nrequire github.com/labstack/echo/v4 v4.11.0\n"), 0644))
+	changed, _, _ := cache.Key(scanner.Options{})
+	assert.NotEqual(t, key, changed)
+	_, ok = cache.Current()
+	assert.False(t, ok)
+}
@@ -8,9 +8,16 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/drossan/claude-init/internal/ai"
+	"github.com/drossan/claude-init/internal/config"
+	"github.com/drossan/claude-init/internal/detector"
+	"github.com/drossan/claude-init/internal/hooks"
 	"github.com/drossan/claude-init/internal/logger"
+	"github.com/drossan/claude-init/internal/mcp"
+	"github.com/drossan/claude-init/internal/scanner"
 	"github.com/drossan/claude-init/internal/survey"
 )
 
@@ -44,11 +51,12 @@ type AgentInfo struct {
 
 // SkillInfo contiene información extraída de un archivo de skill.
 type SkillInfo struct {
-	Name        string // Nombre de la skill (ej: "go-expert")
-	Category    string // Categoría: "language", "framework", "base"
-	Description string // Descripción de la skill
-	Purpose     string // Propósito de la skill
-	FilePath    string // Ruta al archivo .md
+	Name        string   // Nombre de la skill (ej: "go-expert")
+	Category    string   // Categoría: "language", "framework", "base"
+	Description string   // Descripción de la skill
+	Purpose     string   // Propósito de la skill
+	FilePath    string   // Ruta al archivo .md
+	Files       []string // Archivos de apoyo del directorio de la skill
 }
 
 // CommandInfo contiene información extraída de un archivo de comando.
@@ -62,22 +70,43 @@ type CommandInfo struct {
 // Generator es un wrapper para generar configuraciones usando un Client de IA.
 type Generator struct {
 	projectPath    string
+	outputDir      string // Raíz donde se escriben los archivos generados; el proyecto o un staging
 	answers        *survey.Answers
 	logger         *logger.Logger
 	promptBuilder  *PromptBuilder
 	templateLoader *TemplateLoader
 	client         ai.Client
+	settings       *config.ProjectSettings
+	detectOnce     sync.Once
+	detection      *detector.Result // Detección heurística del proyecto, calculada una sola vez
+	analysisCache  *AnalysisCache
+	scanOptions    scanner.Options
+	docsOnce       sync.Once
+	documents      []scanner.Document // Resúmenes de la documentación del proyecto, calculados una sola vez
+	manifest       *Manifest          // Archivos generados, cargado al escribir el primero
+	toolVersion    string
+	lastPromptHash string // Hash del último prompt enviado a la IA, para el manifiesto
+	hashOnce       sync.Once
+	answersHash    string
+	dependencyHash string
+	update         *updateRun   // Actualización en curso, nil al generar desde cero
+	lintRetries    int          // Intentos de corregir con la IA un archivo generado no válido
+	hooks          []hooks.Hook // Hooks elegidos que se añaden a settings.json
+	mcpServers     []mcp.Server // Servidores MCP elegidos que se añaden a .mcp.json
+	references     bool         // Generar con IA los archivos de apoyo de cada skill
 }
 
 // NewGenerator crea una nueva instancia de Generator.
 func NewGenerator(projectPath string, answers *survey.Answers, client ai.Client) *Generator {
 	return &Generator{
 		projectPath:    projectPath,
+		outputDir:      projectPath,
 		answers:        answers,
 		logger:         logger.New(nil, logger.WARNLevel),
 		promptBuilder:  NewPromptBuilder(answers),
 		templateLoader: NewTemplateLoader(),
 		client:         client,
+		settings:       &config.ProjectSettings{},
 	}
 }
 
@@ -86,6 +115,50 @@ func (g *Generator) SetLogger(l *logger.Logger) {
 	g.logger = l
 }
 
+// SetOutputDir establece el directorio raíz donde se escriben .claude/ y los CLAUDE.md, en
+// lugar del proyecto. Se usa para generar en un staging que luego se intercambia con el
+// proyecto; el análisis sigue leyendo el proyecto original.
+func (g *Generator) SetOutputDir(dir string) {
+	g.outputDir = dir
+}
+
+// SetAnalysisCache establece la caché de análisis. Si el repositorio no ha cambiado desde
+// el último análisis, se reutiliza su detección en lugar de volver a recorrer el proyecto.
+func (g *Generator) SetAnalysisCache(cache *AnalysisCache) {
+	g.analysisCache = cache
+}
+
+// SetToolVersion establece la versión de claude-init que se registra en el manifiesto.
+func (g *Generator) SetToolVersion(version string) {
+	g.toolVersion = version
+}
+
+// SetScanOptions establece los globs de inclusión/exclusión y los presupuestos de tokens
+// con los que se recorre el proyecto, entre ellos el de la documentación.
+func (g *Generator) SetScanOptions(opts scanner.Options) {
+	g.scanOptions = opts
+}
+
+// Packages retorna los paquetes del monorepo indicados en las respuestas, cuyos CLAUDE.md
+// también genera.
+func (g *Generator) Packages() []string {
+	return g.answers.Packages
+}
+
+// SetProjectSettings establece la configuración del proyecto (.claude-init.yaml).
+// Las listas include/exclude se aplican sobre los items base y recomendados,
+// y los templates declarados reemplazan la búsqueda por defecto de claude_examples/.
+func (g *Generator) SetProjectSettings(settings *config.ProjectSettings) {
+	if settings == nil {
+		settings = &config.ProjectSettings{}
+	}
+	g.settings = settings
+
+	if paths := settings.TemplatePaths(g.projectPath); len(paths) > 0 {
+		g.templateLoader = NewTemplateLoaderWithPaths(paths)
+	}
+}
+
 // GenerateAgent genera un archivo de agente usando templates base o Claude CLI.
 //
 // Primero intenta usar un template base de claude_examples/ adaptado al proyecto.
@@ -94,7 +167,7 @@ func (g *Generator) GenerateAgent(agentType string) error {
 	g.logger.Debug("Generando agent %s para %s", agentType, g.answers.ProjectName)
 
 	// Crear directorio de agentes
-	agentsDir := filepath.Join(g.projectPath, ".claude", "agents")
+	agentsDir := filepath.Join(g.outputDir, ".claude", "agents")
 	if err := os.MkdirAll(agentsDir, 0755); err != nil {
 		return fmt.Errorf("error creando directorio agents: %w", err)
 	}
@@ -102,6 +175,7 @@ func (g *Generator) GenerateAgent(agentType string) error {
 	// Sanitizar el nombre del agente a kebab-case
 	safeAgentName := sanitizeFilename(agentType)
 	outputPath := filepath.Join(agentsDir, safeAgentName+".md")
+	entry := ManifestEntry{Kind: ArtifactAgent, Name: safeAgentName, Source: SourceAI}
 	var content string
 	var err error
 
@@ -112,6 +186,7 @@ func (g *Generator) GenerateAgent(agentType string) error {
 		if templateErr == nil {
 			// Adaptar template al proyecto actual
 			content = g.templateLoader.AdaptTemplate(template, g.answers)
+			entry.setTemplate(template)
 			err = nil
 		} else {
 			err = templateErr
@@ -122,6 +197,9 @@ func (g *Generator) GenerateAgent(agentType string) error {
 	if content == "" {
 		g.logger.Debug("Usando template incrustado para agent %s", agentType)
 		content = g.getEmbeddedAgentTemplate(agentType)
+		if content != "" {
+			entry.Source = SourceTemplate
+		}
 	}
 
 	// 3. Si todo falla, usar Claude CLI para generar
@@ -135,8 +213,8 @@ func (g *Generator) GenerateAgent(agentType string) error {
 	}
 
 	// Escribir archivo
-	content = g.cleanMarkdownOutput(content)
-	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+	content = g.appendDocReferences(agentType, g.appendGitHabits(agentType, g.cleanMarkdownOutput(content)))
+	if err := g.writeGenerated(outputPath, content, entry); err != nil {
 		return fmt.Errorf("error escribiendo archivo agent %s: %w", agentType, err)
 	}
 
@@ -144,23 +222,25 @@ func (g *Generator) GenerateAgent(agentType string) error {
 	return nil
 }
 
-// GenerateSkill genera un archivo de skill usando templates base o Claude CLI.
+// GenerateSkill genera una skill con el formato de directorio de Claude Code:
+// skills/<name>/SKILL.md y, opcionalmente, sus archivos de apoyo.
 //
 // Primero intenta usar un template base de claude_examples/ adaptado al proyecto.
 // Si no existe, usa templates incrustados o Claude CLI para generarlo.
 func (g *Generator) GenerateSkill(skillType, skillName string) error {
 	g.logger.Debug("Generando skill %s:%s", skillType, skillName)
 
-	// Crear directorio de skills (raíz, sin subdirectorios)
-	skillsDir := filepath.Join(g.projectPath, ".claude", "skills")
-	if err := os.MkdirAll(skillsDir, 0755); err != nil {
-		return fmt.Errorf("error creando directorio skills: %w", err)
+	// Sanitizar el nombre para que sea un directorio válido
+	safeFileName := sanitizeFilename(skillName)
+	skillDir := filepath.Join(g.outputDir, ".claude", "skills", safeFileName)
+	if err := os.MkdirAll(skillDir, 0755); err != nil {
+		return fmt.Errorf("error creando directorio de la skill %s: %w", skillName, err)
 	}
 
-	// Sanitizar el nombre del archivo para que sea válido
-	safeFileName := sanitizeFilename(skillName)
-	outputPath := filepath.Join(skillsDir, safeFileName+".md")
+	outputPath := filepath.Join(skillDir, SkillFile)
+	entry := ManifestEntry{Kind: ArtifactSkill, Name: safeFileName, Source: SourceAI}
 	var content string
+	var files map[string]string // Archivos de apoyo del template
 	var err error
 
 	// 1. Intentar usar template base primero
@@ -171,6 +251,8 @@ func (g *Generator) GenerateSkill(skillType, skillName string) error {
 		if templateErr == nil {
 			// Adaptar template al proyecto actual
 			content = g.templateLoader.AdaptTemplate(template, g.answers)
+			files = template.Files
+			entry.setTemplate(template)
 			err = nil
 		} else {
 			err = templateErr
@@ -181,6 +263,9 @@ func (g *Generator) GenerateSkill(skillType, skillName string) error {
 	if content == "" {
 		g.logger.Debug("Usando template incrustado para skill %s", skillName)
 		content = g.getEmbeddedSkillTemplate(skillName, skillType)
+		if content != "" {
+			entry.Source = SourceTemplate
+		}
 	}
 
 	// 3. Si todo falla, usar Claude CLI para generar
@@ -193,12 +278,19 @@ func (g *Generator) GenerateSkill(skillType, skillName string) error {
 		}
 	}
 
-	// Limpiar y asegurar que el frontmatter tenga la categoría correcta
+	// Limpiar y asegurar que el frontmatter tenga el nombre y la categoría correctos
 	content = g.cleanMarkdownOutput(content)
 	content = g.ensureSkillCategory(content, skillType, safeFileName)
 
+	// Archivos de apoyo: los del template o, si se piden, las referencias generadas con IA
+	if len(files) > 0 {
+		g.writeSkillFiles(safeFileName, files, entry)
+	} else if g.wantsSkillReferences(safeFileName) {
+		content = g.generateSkillReferences(safeFileName, content)
+	}
+
 	// Escribir archivo
-	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+	if err := g.writeGenerated(outputPath, content, entry); err != nil {
 		return fmt.Errorf("error escribiendo archivo skill %s: %w", skillName, err)
 	}
 
@@ -229,15 +321,17 @@ func (g *Generator) cleanMarkdownOutput(content string) string {
 	return content
 }
 
-// ensureSkillCategory asegura que el frontmatter tenga el campo category correcto.
-// SOBRESCRIBE cualquier category existente con el skillType correcto.
+// ensureSkillCategory asegura que el frontmatter tenga el nombre de la skill, que Claude
+// Code exige que coincida con su directorio, y la categoría en metadata.category.
+// SOBRESCRIBE cualquier name o category existente, incluido el category de primer nivel
+// del formato anterior.
 func (g *Generator) ensureSkillCategory(content, skillType, skillName string) string {
 	// Buscar el inicio del frontmatter
 	startIdx := strings.Index(content, "---")
 	if startIdx == -1 {
 		// No hay frontmatter, crear uno básico con categoría
-		return fmt.Sprintf("---\nname: %s\ncategory: %s\ndescription: Skill for %s\n---\n\n%s",
-			skillName, skillType, skillName, content)
+		return fmt.Sprintf("---\nname: %s\ndescription: Skill for %s\nmetadata:\n  category: %s\n---\n\n%s",
+			skillName, skillName, skillType, content)
 	}
 
 	// Buscar el fin del frontmatter
@@ -251,39 +345,52 @@ func (g *Generator) ensureSkillCategory(content, skillType, skillName string) st
 	frontmatterEnd := startIdx + 3 + endIdx
 	frontmatter := content[frontmatterStart:frontmatterEnd]
 
-	// Reconstruir frontmatter SOBREESCRIBIENDO category
+	// Reconstruir frontmatter SOBREESCRIBIENDO name y quitando category, que se añade a metadata
 	lines := strings.Split(frontmatter, "\n")
 	var newLines []string
-	categoryFound := false
+	nameFound := false
+	metadataPos := -1
+	inMetadata, metadataInline := false, false
 
 	for _, line := range lines {
 		trimmedLine := strings.TrimSpace(line)
-		if strings.HasPrefix(trimmedLine, "category:") {
-			// Sobrescribir category con el valor correcto
-			newLines = append(newLines, fmt.Sprintf("category: %s", skillType))
-			categoryFound = true
-		} else if trimmedLine != "" {
+		topLevel := line == strings.TrimLeft(line, " \t")
+		if topLevel && trimmedLine != "" {
+			inMetadata = false
+		}
+		switch {
+		case trimmedLine == "":
+			continue
+		case topLevel && strings.HasPrefix(trimmedLine, "name:"):
+			newLines = append(newLines, fmt.Sprintf("name: %s", skillName))
+			nameFound = true
+		case topLevel && strings.HasPrefix(trimmedLine, "category:"):
+			continue
+		case topLevel && (trimmedLine == "metadata:" || trimmedLine == "metadata: {}"):
+			newLines = append(newLines, "metadata:")
+			metadataPos = len(newLines)
+			inMetadata = true
+		case inMetadata && strings.HasPrefix(trimmedLine, "category:"):
+			continue
+		case topLevel && strings.HasPrefix(trimmedLine, "metadata:"):
+			// metadata en línea: se conserva sin añadir la categoría
+			newLines = append(newLines, line)
+			metadataInline = true
+		default:
 			newLines = append(newLines, line)
 		}
 	}
 
-	// Si no se encontró category, añadirlo
-	if !categoryFound {
-		// Buscar dónde insertar (después de name o al principio)
-		insertPos := 0
-		for i, line := range newLines {
-			if strings.HasPrefix(strings.TrimSpace(line), "name:") {
-				insertPos = i + 1
-				break
-			}
-		}
-
-		categoryLine := fmt.Sprintf("category: %s", skillType)
-		var temp []string
-		temp = append(temp, newLines[:insertPos]...)
-		temp = append(temp, categoryLine)
-		temp = append(temp, newLines[insertPos:]...)
-		newLines = temp
+	categoryLine := fmt.Sprintf("  category: %s", skillType)
+	switch {
+	case metadataInline:
+	case metadataPos == -1:
+		newLines = append(newLines, "metadata:", categoryLine)
+	default:
+		newLines = append(newLines[:metadataPos], append([]string{categoryLine}, newLines[metadataPos:]...)...)
+	}
+	if !nameFound {
+		newLines = append([]string{fmt.Sprintf("name: %s", skillName)}, newLines...)
 	}
 
 	newFrontmatter := strings.Join(newLines, "\n")
@@ -292,7 +399,7 @@ func (g *Generator) ensureSkillCategory(content, skillType, skillName string) st
 	beforeFrontmatter := content[:startIdx]
 	afterFrontmatter := content[frontmatterEnd:]
 
-	return beforeFrontmatter + "---\n" + newFrontmatter + afterFrontmatter
+	return beforeFrontmatter + "---\n" + newFrontmatter + "\n" + afterFrontmatter
 }
 
 // GenerateCommand genera un archivo de comando usando templates base o Claude CLI.
@@ -312,7 +419,7 @@ func (g *Generator) GenerateCommandWithContext(commandType, agentsContext, skill
 	g.logger.Debug("Generando command %s", commandType)
 
 	// Crear directorio de comandos
-	commandsDir := filepath.Join(g.projectPath, ".claude", "commands")
+	commandsDir := filepath.Join(g.outputDir, ".claude", "commands")
 	if err := os.MkdirAll(commandsDir, 0755); err != nil {
 		return fmt.Errorf("error creando directorio commands: %w", err)
 	}
@@ -320,6 +427,7 @@ func (g *Generator) GenerateCommandWithContext(commandType, agentsContext, skill
 	// Sanitizar el nombre del comando a kebab-case
 	safeCommandName := sanitizeFilename(commandType)
 	outputPath := filepath.Join(commandsDir, safeCommandName+".md")
+	entry := ManifestEntry{Kind: ArtifactCommand, Name: safeCommandName, Source: SourceAI}
 	var content string
 	var err error
 
@@ -336,6 +444,7 @@ func (g *Generator) GenerateCommandWithContext(commandType, agentsContext, skill
 			if templateErr == nil {
 				// Adaptar template al proyecto actual
 				content = g.templateLoader.AdaptTemplate(template, g.answers)
+				entry.setTemplate(template)
 				err = nil
 			} else {
 				err = templateErr
@@ -346,6 +455,9 @@ func (g *Generator) GenerateCommandWithContext(commandType, agentsContext, skill
 		if content == "" {
 			g.logger.Debug("Usando template incrustado para command %s", commandType)
 			content = g.getEmbeddedCommandTemplate(commandType)
+			if content != "" {
+				entry.Source = SourceTemplate
+			}
 		}
 	}
 
@@ -361,7 +473,7 @@ func (g *Generator) GenerateCommandWithContext(commandType, agentsContext, skill
 
 	// Escribir archivo
 	content = g.cleanMarkdownOutput(content)
-	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+	if err := g.writeGenerated(outputPath, content, entry); err != nil {
 		return fmt.Errorf("error escribiendo archivo command %s: %w", commandType, err)
 	}
 
@@ -442,19 +554,18 @@ func (g *Generator) GenerateAll(rec *Recommendation) error {
 		g.logger.Warn("Error generando CLAUDE.md: %v", err)
 	}
 
+	// PASO 1.5: En monorepos, generar el CLAUDE.md de cada paquete seleccionado
+	if err := g.GeneratePackageClaudeMDs(); err != nil {
+		g.logger.Warn("Error generando los CLAUDE.md de los paquetes: %v", err)
+	}
+
 	// Crear directorio base .claude
-	configDir := filepath.Join(g.projectPath, ".claude")
+	configDir := filepath.Join(g.outputDir, ".claude")
 	if err := os.MkdirAll(configDir, 0755); err != nil {
 		return fmt.Errorf("error creando directorio .claude: %w", err)
 	}
 
-	// Obtener items base que siempre deben estar presentes
-	baseItems := GetBaseItems()
-
-	// Combinar recomendaciones de IA con items base (sin duplicados)
-	agents := g.combineUnique(rec.Agents, baseItems.Agents)
-	commands := g.combineUnique(rec.Commands, baseItems.Commands)
-	skills := g.combineUnique(rec.Skills, baseItems.Skills)
+	agents, commands, skills := g.plannedItems(rec)
 
 	// Generar agentes
 	g.logger.Info("Generando %d agentes...", len(agents))
@@ -504,16 +615,45 @@ func (g *Generator) GenerateAll(rec *Recommendation) error {
 		// Continuar aunque falle la generación del README
 	}
 
+	// PASO 5.6: Generar settings.json con los permisos derivados de las herramientas
+	if err := g.GenerateSettings(); err != nil {
+		g.logger.Warn("Error generando settings.json: %v", err)
+	}
+
+	// PASO 5.7: Generar .mcp.json con los servidores MCP elegidos
+	if err := g.GenerateMCP(); err != nil {
+		g.logger.Warn("Error generando .mcp.json: %v", err)
+	}
+
 	// PASO 6: Generar development_guide.md CON CONTEXTO COMPLETO
 	// Ahora tenemos toda la estructura creada, podemos pasar contexto al development guide
 	if err := g.GenerateDevelopmentGuideWithContext(agents, commands, skills); err != nil {
 		g.logger.Warn("Error generando development_guide.md: %v", err)
 	}
 
+	if err := g.SaveManifest(); err != nil {
+		g.logger.Warn("Error guardando el manifiesto de generación: %v", err)
+	}
+
 	g.logger.Info("Estructura .claude/ generada exitosamente")
 	return nil
 }
 
+// plannedItems retorna los agentes, comandos y skills a generar: la recomendación combinada
+// con los items base obligatorios y filtrada con include/exclude de .claude-init.yaml.
+func (g *Generator) plannedItems(rec *Recommendation) (agents, commands, skills []string) {
+	// Obtener items base que siempre deben estar presentes
+	baseItems := GetBaseItems()
+
+	// Combinar recomendaciones de IA con items base (sin duplicados)
+	agents = g.combineUnique(rec.Agents, baseItems.Agents)
+	commands = g.combineUnique(rec.Commands, baseItems.Commands)
+	skills = g.combineUnique(rec.Skills, baseItems.Skills)
+
+	// Aplicar include/exclude de .claude-init.yaml
+	return g.settings.Agents.Apply(agents), g.settings.Commands.Apply(commands), g.settings.Skills.Apply(skills)
+}
+
 // combineUnique combina dos slices eliminando duplicados.
 func (g *Generator) combineUnique(recommended, base []string) []string {
 	seen := make(map[string]bool)
@@ -554,15 +694,137 @@ func (g *Generator) determineSkillType(skillName string) string {
 		return "language"
 	}
 
-	// Si coincide con el framework
-	if g.answers.Framework != "" && strings.Contains(strings.ToLower(g.answers.Framework), strings.ToLower(skillName)) {
-		return "framework"
+	// Si coincide con alguno de los frameworks
+	for _, framework := range g.answers.Frameworks() {
+		if strings.Contains(strings.ToLower(framework), strings.ToLower(skillName)) {
+			return "framework"
+		}
 	}
 
 	// Por defecto, language
 	return "language"
 }
 
+// writeGenerated escribe un archivo generado y lo registra en el manifiesto con su
+// procedencia: provider, modelo y hash del prompt si lo generó la IA, o el template usado.
+// La versión generada se guarda también en BaseDir como base de futuras fusiones. Durante
+// una actualización, el contenido que se escribe lo decide la actualización en curso. Los
+// agents, skills y commands se validan antes de escribirlos (ver lintGenerated).
+func (g *Generator) writeGenerated(outputPath, content string, entry ManifestEntry) error {
+	if rel, err := filepath.Rel(g.outputDir, outputPath); err == nil {
+		entry.Path = filepath.ToSlash(rel)
+	} else {
+		entry.Path = filepath.ToSlash(outputPath)
+	}
+	content = g.lintGenerated(&entry, content)
+
+	written := content
+	if g.update != nil {
+		var ok bool
+		if written, ok = g.update.resolve(entry.Path, content); !ok {
+			return nil
+		}
+	}
+	if err := os.WriteFile(outputPath, []byte(written), 0644); err != nil {
+		return err
+	}
+
+	basePath := BasePath(g.outputDir, entry.Path)
+	if err := os.MkdirAll(filepath.Dir(basePath), 0755); err == nil {
+		if err := os.WriteFile(basePath, []byte(content), 0644); err != nil {
+			g.logger.Warn("No se pudo guardar la base de %s: %v", entry.Path, err)
+		}
+	}
+
+	entry.ContentHash = HashContent([]byte(content))
+	entry.AnswersHash, entry.DependencyHash = g.inputHashes()
+	entry.ToolVersion = g.toolVersion
+	entry.GeneratedAt = time.Now().UTC()
+	if entry.Source == SourceAI {
+		if g.client != nil {
+			entry.Provider = string(g.client.Provider())
+			entry.Model = ai.ModelOf(g.client)
+		}
+		entry.PromptHash = g.lastPromptHash
+	}
+
+	g.loadManifest().Record(entry)
+	return nil
+}
+
+// loadManifest retorna el manifiesto del proyecto, cargándolo la primera vez.
+func (g *Generator) loadManifest() *Manifest {
+	if g.manifest == nil {
+		manifest, err := LoadManifest(g.outputDir)
+		if err != nil {
+			g.logger.Warn("No se pudo leer el manifiesto, se crea uno nuevo: %v", err)
+			manifest = &Manifest{Version: manifestVersion}
+		}
+		g.manifest = manifest
+	}
+	return g.manifest
+}
+
+// inputHashes retorna los hashes de las respuestas y de los manifiestos de dependencias con
+// los que se genera, calculados una sola vez. Si cambian, update regenera los archivos.
+func (g *Generator) inputHashes() (answers, dependencies string) {
+	g.hashOnce.Do(func() {
+		g.answersHash = hashAnswers(g.answers)
+		g.dependencyHash = DependencyHash(g.projectPath)
+	})
+	return g.answersHash, g.dependencyHash
+}
+
+// hashAnswers retorna el hash de las respuestas que se guardan en project.yaml. El provider
+// de IA no cuenta: cambiarlo no obliga a regenerar.
+func hashAnswers(answers *survey.Answers) string {
+	content, _ := json.Marshal(struct {
+		ProjectName, Description, Language, Framework, Architecture string
+		Database, ProjectCategory, BusinessContext                  string
+		FollowUps                                                   []survey.FollowUp
+		Conventions, Packages                                       []string
+		Extra                                                       map[string]string
+	}{
+		answers.ProjectName, answers.Description, answers.Language, answers.Framework, answers.Architecture,
+		answers.Database, answers.ProjectCategory, answers.BusinessContext,
+		answers.FollowUps,
+		answers.Conventions, answers.Packages,
+		answers.Extra,
+	})
+	return HashContent(content)
+}
+
+// canOverwrite indica si se puede escribir un archivo que no se sobrescribe por defecto
+// (CLAUDE.md, development_guide.md): si no existe o, durante una actualización, si lo
+// generó claude-init.
+func (g *Generator) canOverwrite(outputPath string) bool {
+	if _, err := os.Stat(outputPath); err != nil {
+		return true
+	}
+	if g.update == nil {
+		return false
+	}
+	rel, err := filepath.Rel(g.outputDir, outputPath)
+	if err != nil {
+		return false
+	}
+	_, ok := g.update.previous.Entry(filepath.ToSlash(rel))
+	return ok
+}
+
+// SaveManifest guarda en .claude/ el manifiesto con los archivos generados por este
+// generador, combinados con los de generaciones anteriores. No hace nada si no se ha
+// generado ningún archivo.
+func (g *Generator) SaveManifest() error {
+	if g.manifest == nil {
+		return nil
+	}
+	if g.toolVersion != "" {
+		g.manifest.ToolVersion = g.toolVersion
+	}
+	return g.manifest.Save(g.outputDir)
+}
+
 // generateWithClaude ejecuta el cliente de IA con el prompt dado y retorna la salida.
 // Incluye el contexto del CLAUDE.md si existe.
 func (g *Generator) generateWithClaude(prompt string, extraFlags map[string]string) (string, error) {
@@ -571,6 +833,21 @@ func (g *Generator) generateWithClaude(prompt string, extraFlags map[string]stri
 	// Construir system prompt con contexto del proyecto si está disponible
 	systemPrompt := g.buildSystemPrompt()
 
+	// Agregar las respuestas a las preguntas de seguimiento de la validación
+	if followUps := g.answers.FollowUpContext(); followUps != "" {
+		systemPrompt += "\n\n" + followUps
+	}
+
+	// Agregar las convenciones observadas en el código del proyecto existente
+	if conventions := g.answers.ConventionsContext(); conventions != "" {
+		systemPrompt += "\n\n" + conventions
+	}
+
+	// Agregar las respuestas a las preguntas personalizadas del survey
+	if extra := g.answers.ExtraContext(); extra != "" {
+		systemPrompt += "\n\n" + extra
+	}
+
 	// Intentar leer CLAUDE.md para agregar contexto adicional
 	if claudeContext := g.readClaudeMDContext(); claudeContext != "" {
 		systemPrompt += `
@@ -579,6 +856,7 @@ func (g *Generator) generateWithClaude(prompt string, extraFlags map[string]stri
 	}
 
 	// Enviar mensaje al cliente de IA
+	g.lastPromptHash = HashContent([]byte(systemPrompt + "\x00" + prompt))
 	response, err := g.client.SendMessage(systemPrompt, prompt)
 	if err != nil {
 		return "", fmt.Errorf("AI client error: %w", err)
@@ -589,7 +867,7 @@ func (g *Generator) generateWithClaude(prompt string, extraFlags map[string]stri
 
 // readClaudeMDContext lee el archivo CLAUDE.md si existe y retorna su contenido como contexto.
 func (g *Generator) readClaudeMDContext() string {
-	claudeMDPath := filepath.Join(g.projectPath, "CLAUDE.md")
+	claudeMDPath := filepath.Join(g.outputDir, "CLAUDE.md")
 	content, err := os.ReadFile(claudeMDPath)
 	if err != nil {
 		// El archivo no existe o no se puede leer, no hay problema
@@ -613,9 +891,9 @@ FIN DEL CONTEXTO DEL PROYECTO
 func (g *Generator) getReadmeContent(readmeType string) string {
 	var readmePath string
 	if readmeType == "agents" {
-		readmePath = filepath.Join(g.projectPath, ".claude", "agents", "README.md")
+		readmePath = filepath.Join(g.outputDir, ".claude", "agents", "README.md")
 	} else if readmeType == "skills" {
-		readmePath = filepath.Join(g.projectPath, ".claude", "skills", "README.md")
+		readmePath = filepath.Join(g.outputDir, ".claude", "skills", "README.md")
 	} else {
 		return ""
 	}
@@ -631,11 +909,18 @@ func (g *Generator) getReadmeContent(readmeType string) string {
 
 // buildSystemPrompt construye el system prompt para Claude.
 func (g *Generator) buildSystemPrompt() string {
-	return `Eres un experto en desarrollo de software y generacion de configuraciones para proyectos.
+	systemPrompt := `Eres un experto en desarrollo de software y generacion de configuraciones para proyectos.
 
 Tu tarea es generar archivos de configuracion de claude code para agentes, comandos y skills de desarrollo asistido por IA.
 
 Debes ser preciso y generar contenido que sea directamente utilizable sin necesidad de edicion posterior.`
+
+	// Idioma de salida fijado en .claude-init.yaml
+	if g.settings != nil && g.settings.Language != "" {
+		systemPrompt += fmt.Sprintf("\n\nEscribe todo el contenido generado en el idioma: %s.", g.settings.Language)
+	}
+
+	return systemPrompt
 }
 
 // extractJSON extrae un objeto JSON de un string que puede contener texto adicional.
@@ -663,13 +948,18 @@ func (g *Generator) getDefaultRecommendation() *Recommendation {
 	}
 
 	// Agregar agent de debugger si es un proyecto complejo
-	if g.answers.Architecture != "" && g.answers.Architecture != "monolith" {
+	if g.answers.Architecture != "" && survey.NormalizeChoice("architecture", g.answers.Architecture) != "Monolito" {
 		rec.Agents = append(rec.Agents, "debugger")
 	}
 
-	// Agregar skill de framework si existe (normalizado a kebab-case)
-	if g.answers.Framework != "" {
-		rec.Skills = append(rec.Skills, sanitizeFilename(g.answers.Framework))
+	// Agregar una skill por framework y base de datos (normalizados a kebab-case)
+	for _, framework := range g.answers.Frameworks() {
+		rec.Skills = append(rec.Skills, sanitizeFilename(framework))
+	}
+	for _, database := range g.answers.Databases() {
+		if skill := sanitizeFilename(database); !contains(rec.Skills, skill) {
+			rec.Skills = append(rec.Skills, skill)
+		}
 	}
 
 	// Detectar y agregar skills adicionales basadas en dependencias del proyecto
@@ -684,114 +974,22 @@ func (g *Generator) getDefaultRecommendation() *Recommendation {
 	return rec
 }
 
-// detectAdditionalSkills detecta skills adicionales basadas en las dependencias del proyecto.
-// Detecta bases de datos, ORMs y librerías importantes que deberían tener su propia skill.
+// detectAdditionalSkills detecta skills adicionales a partir de las librerías declaradas en
+// los manifiestos del proyecto (y de sus paquetes, en un monorepo). Usa el registro de
+// manifiestos del detector, que compara identidades de módulo exactas.
 func (g *Generator) detectAdditionalSkills() []string {
-	var skills []string
-
-	// Analizar package.json si existe (Node.js/TypeScript)
-	if pkgInfo := g.analyzePackageJSONDependencies(); pkgInfo != "" {
-		skills = append(skills, g.detectPackageJSONSkills(pkgInfo)...)
-	}
-
-	// Analizar go.mod si existe (Go)
-	if goDeps := g.analyzeGoMod(); goDeps != "" {
-		skills = append(skills, g.detectGoSkills(goDeps)...)
-	}
-
-	// Analizar requirements.txt si existe (Python)
-	if reqs := g.analyzeRequirementsTxt(); reqs != "" {
-		skills = append(skills, g.detectPythonSkills(reqs)...)
-	}
-
-	return skills
-}
-
-// analyzePackageJSONDependencies analiza las dependencias del package.json.
-func (g *Generator) analyzePackageJSONDependencies() string {
-	pkgPath := filepath.Join(g.projectPath, "package.json")
-	content, err := os.ReadFile(pkgPath)
-	if err != nil {
-		return ""
-	}
-
-	var pkg struct {
-		Dependencies    map[string]string `json:"dependencies"`
-		DevDependencies map[string]string `json:"devDependencies"`
-	}
-
-	if err := json.Unmarshal(content, &pkg); err != nil {
-		return ""
+	result := g.projectDetection()
+	if result == nil {
+		return nil
 	}
 
-	var deps []string
-	for name := range pkg.Dependencies {
-		deps = append(deps, name)
-	}
-	for name := range pkg.DevDependencies {
-		deps = append(deps, name)
-	}
-
-	return strings.Join(deps, ",")
-}
-
-// detectPackageJSONSkills detecta skills basadas en dependencias de Node.js/TypeScript.
-func (g *Generator) detectPackageJSONSkills(depsStr string) []string {
-	deps := strings.Split(depsStr, ",")
-	var skills []string
-	skillMap := make(map[string]bool)
-
-	// Mapeo de dependencias a skills
-	dependencySkills := map[string]string{
-		// Bases de datos
-		"mysql":          "mysql",
-		"pg":             "postgresql",
-		"postgres":       "postgresql",
-		"mongoose":       "mongodb",
-		"mongodb":        "mongodb",
-		"redis":          "redis",
-		"sqlite3":        "sqlite",
-		"better-sqlite3": "sqlite",
-
-		// ORMs
-		"typeorm":   "typeorm",
-		"prisma":    "prisma",
-		"sequelize": "sequelize",
-		"mikro-orm": "mikro-orm",
-
-		// Validación
-		"zod":             "zod",
-		"joi":             "joi",
-		"yup":             "yup",
-		"class-validator": "class-validator",
-
-		// Testing
-		"jest":                   "jest",
-		"vitest":                 "vitest",
-		"mocha":                  "mocha",
-		"cypress":                "cypress",
-		"playwright":             "playwright",
-		"@testing-library/react": "testing-library",
-		"@testing-library/vue":   "testing-library",
-
-		// Frameworks
-		"react":   "react",
-		"vue":     "vue",
-		"angular": "angular",
-		"next":    "nextjs",
-		"nuxt":    "nuxtjs",
-		"svelte":  "svelte",
-		"express": "express",
-		"fastify": "fastify",
-		"nest":    "nestjs",
-	}
-
-	for _, dep := range deps {
-		dep = strings.TrimSpace(strings.ToLower(dep))
-		for key, skill := range dependencySkills {
-			if strings.Contains(dep, key) && !skillMap[skill] {
-				skills = append(skills, skill)
-				skillMap[skill] = true
+	skills := result.Skills
+	if result.Workspace != nil {
+		for _, pkg := range result.Workspace.Packages {
+			for _, skill := range pkg.Skills {
+				if !contains(skills, skill) {
+					skills = append(skills, skill)
+				}
 			}
 		}
 	}
@@ -799,150 +997,46 @@ func (g *Generator) detectPackageJSONSkills(depsStr string) []string {
 	return skills
 }
 
-// analyzeGoMod analiza go.mod si existe.
-func (g *Generator) analyzeGoMod() string {
-	modPath := filepath.Join(g.projectPath, "go.mod")
-	content, err := os.ReadFile(modPath)
-	if err != nil {
-		return ""
-	}
-
-	// Extraer dependencias buscando líneas que empiezan con requerimientos
-	var deps []string
-	lines := strings.Split(string(content), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "require ") || strings.HasPrefix(line, "\trequire ") {
-			// Extraer el nombre del paquete (primer elemento después de require)
-			parts := strings.Fields(line)
-			if len(parts) >= 2 {
-				// Eliminar comillas del nombre
-				depName := strings.Trim(parts[1], `"`)
-				deps = append(deps, depName)
+// projectDetection retorna la detección heurística del proyecto (dependencias, comandos,
+// CI e infraestructura), o nil si no se pudo analizar.
+func (g *Generator) projectDetection() *detector.Result {
+	g.detectOnce.Do(func() {
+		if g.analysisCache != nil {
+			if entry, ok := g.analysisCache.Current(); ok && entry.Analysis.Detection != nil {
+				g.logger.Debug("Usando la detección del análisis en caché")
+				g.detection = entry.Analysis.Detection
+				return
 			}
 		}
-	}
 
-	return strings.Join(deps, ",")
-}
-
-// detectGoSkills detecta skills basadas en dependencias de Go.
-func (g *Generator) detectGoSkills(depsStr string) []string {
-	deps := strings.Split(depsStr, ",")
-	var skills []string
-	skillMap := make(map[string]bool)
-
-	dependencySkills := map[string]string{
-		// Bases de datos
-		"mysql":    "mysql",
-		"postgres": "postgresql",
-		"pq":       "postgresql",
-		"pgx":      "postgresql",
-		"mongo":    "mongodb",
-		"mongodb":  "mongodb",
-		"redis":    "redis",
-		"sqlite":   "sqlite",
-
-		// ORMs
-		"gorm": "gorm",
-		"sqlx": "sqlx",
-		"ent":  "ent",
-		"sqlc": "sqlc",
-
-		// Frameworks
-		"gin":   "gin",
-		"echo":  "echo",
-		"fiber": "fiber",
-		"chi":   "chi",
-	}
-
-	for _, dep := range deps {
-		dep = strings.TrimSpace(dep)
-		// Extraer solo el nombre base (sin versión)
-		if parts := strings.Split(dep, " "); len(parts) > 0 {
-			dep = parts[0]
-		}
-		for key, skill := range dependencySkills {
-			if strings.Contains(dep, key) && !skillMap[skill] {
-				skills = append(skills, skill)
-				skillMap[skill] = true
-			}
+		result, err := detector.NewDetectorWithOptions(g.projectPath, g.scanOptions).Detect()
+		if err != nil {
+			g.logger.Debug("No se pudo analizar el proyecto: %v", err)
+			return
 		}
-	}
-
-	return skills
+		g.detection = result
+	})
+	return g.detection
 }
 
-// analyzeRequirementsTxt analiza requirements.txt si existe.
-func (g *Generator) analyzeRequirementsTxt() string {
-	reqPath := filepath.Join(g.projectPath, "requirements.txt")
-	content, err := os.ReadFile(reqPath)
-	if err != nil {
-		return ""
-	}
-
-	lines := strings.Split(string(content), "\n")
-	var deps []string
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		// Ignorar comentarios y líneas vacías
-		if line != "" && !strings.HasPrefix(line, "#") {
-			// Extraer nombre del paquete (antes de ==)
-			if parts := strings.Split(line, "=="); len(parts) > 0 {
-				deps = append(deps, strings.ToLower(parts[0]))
-			} else {
-				deps = append(deps, strings.ToLower(line))
-			}
-		}
+// projectCommands retorna los comandos reales de build, test o lint detectados en el
+// Makefile, los scripts de package.json, el CI o el manifiesto del proyecto.
+func (g *Generator) projectCommands(kind string) []string {
+	result := g.projectDetection()
+	if result == nil {
+		return nil
 	}
-
-	return strings.Join(deps, ",")
+	return result.Tooling.CommandsFor(kind)
 }
 
-// detectPythonSkills detecta skills basadas en dependencias de Python.
-func (g *Generator) detectPythonSkills(depsStr string) []string {
-	deps := strings.Split(depsStr, ",")
-	var skills []string
-	skillMap := make(map[string]bool)
-
-	dependencySkills := map[string]string{
-		// Bases de datos
-		"mysql":    "mysql",
-		"postgres": "postgresql",
-		"psycopg2": "postgresql",
-		"pymongo":  "mongodb",
-		"redis":    "redis",
-		"sqlite3":  "sqlite",
-
-		// ORMs
-		"sqlalchemy":       "sqlalchemy",
-		"django":           "django",
-		"flask-sqlalchemy": "flask-sqlalchemy",
-		"tortoise":         "tortoise-orm",
-
-		// Frameworks
-		"flask":   "flask",
-		"fastapi": "fastapi",
-
-		// Validación
-		"pydantic":    "pydantic",
-		"marshmallow": "marshmallow",
-
-		// Testing
-		"pytest": "pytest",
-	}
-
-	for _, dep := range deps {
-		dep = strings.TrimSpace(dep)
-		for key, skill := range dependencySkills {
-			if strings.Contains(dep, key) && !skillMap[skill] {
-				skills = append(skills, skill)
-				skillMap[skill] = true
-			}
-		}
+// projectCommandsStep retorna el paso del flujo que fija los comandos reales del proyecto,
+// o "" si no se detectaron comandos de ese tipo.
+func (g *Generator) projectCommandsStep(kind string) string {
+	commands := g.projectCommands(kind)
+	if len(commands) == 0 {
+		return ""
 	}
-
-	return skills
+	return "\n- Usar exactamente los comandos del proyecto: `" + strings.Join(commands, "`, `") + "`"
 }
 
 // contains verifica si un slice contiene un string.
@@ -1023,6 +1117,10 @@ func (g *Generator) parseAgentFrontmatter(filePath string) (*AgentInfo, error) {
 	// Extraer nombre del archivo
 	fileName := filepath.Base(filePath)
 	name := strings.TrimSuffix(fileName, ".md")
+	if fileName == SkillFile {
+		// Formato de directorio: el nombre es el del directorio de la skill
+		name = filepath.Base(filepath.Dir(filePath))
+	}
 
 	info := &AgentInfo{
 		Name:     name,
@@ -1289,7 +1387,7 @@ func parseListValue(value string) []string {
 func (g *Generator) GenerateAgentsReadme(agentNames []string) error {
 	g.logger.Debug("Generando agents/README.md")
 
-	agentsDir := filepath.Join(g.projectPath, ".claude", "agents")
+	agentsDir := filepath.Join(g.outputDir, ".claude", "agents")
 	outputPath := filepath.Join(agentsDir, "README.md")
 
 	// Parsear todos los agentes para obtener su metadata
@@ -1308,7 +1406,7 @@ func (g *Generator) GenerateAgentsReadme(agentNames []string) error {
 	content := g.buildAgentsReadmeContent(agents)
 
 	// Escribir archivo
-	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+	if err := g.writeGenerated(outputPath, content, ManifestEntry{Kind: ArtifactReadme, Name: "agents", Source: SourceBuiltin}); err != nil {
 		return fmt.Errorf("error escribiendo agents/README.md: %w", err)
 	}
 
@@ -1368,14 +1466,15 @@ func (g *Generator) buildAgentsReadmeContent(agents []*AgentInfo) string {
 }
 
 // GenerateSkillsReadme genera un README.md en el directorio skills/ que lista todas las skills.
-// Escanea todos los archivos .md en skills/ (excepto README.md) y los agrupa por categoría.
+// Escanea las skills de skills/<name>/SKILL.md y los archivos .md planos del formato anterior
+// (excepto README.md) y las agrupa por categoría.
 func (g *Generator) GenerateSkillsReadme(_ []string) error {
 	g.logger.Debug("Generando skills/README.md")
 
-	skillsDir := filepath.Join(g.projectPath, ".claude", "skills")
+	skillsDir := filepath.Join(g.outputDir, ".claude", "skills")
 	outputPath := filepath.Join(skillsDir, "README.md")
 
-	// Escanear todos los archivos .md en el directorio skills/
+	// Escanear las skills del directorio skills/
 	entries, err := os.ReadDir(skillsDir)
 	if err != nil {
 		return fmt.Errorf("error leyendo directorio skills: %w", err)
@@ -1384,22 +1483,25 @@ func (g *Generator) GenerateSkillsReadme(_ []string) error {
 	// Parsear todas las skills para obtener su metadata
 	var skills []*SkillInfo
 	for _, entry := range entries {
-		// Ignorar README.md y directorios
-		if entry.IsDir() || entry.Name() == "README.md" {
-			continue
-		}
-
-		// Solo procesar archivos .md
-		if !strings.HasSuffix(entry.Name(), ".md") {
+		skillPath := filepath.Join(skillsDir, entry.Name())
+		if entry.IsDir() {
+			skillPath = filepath.Join(skillPath, SkillFile)
+			if _, err := os.Stat(skillPath); err != nil {
+				continue
+			}
+		} else if entry.Name() == "README.md" || !strings.HasSuffix(entry.Name(), ".md") {
+			// Solo procesar archivos .md, excepto README.md
 			continue
 		}
 
-		skillPath := filepath.Join(skillsDir, entry.Name())
 		info, err := g.parseSkillFrontmatter(skillPath)
 		if err != nil {
 			g.logger.Warn("Error parsing skill %s: %v", entry.Name(), err)
 			continue
 		}
+		if entry.IsDir() {
+			info.Files = skillFiles(filepath.Dir(skillPath))
+		}
 		skills = append(skills, info)
 	}
 
@@ -1407,7 +1509,7 @@ func (g *Generator) GenerateSkillsReadme(_ []string) error {
 	content := g.buildSkillsReadmeContent(skills)
 
 	// Escribir archivo
-	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+	if err := g.writeGenerated(outputPath, content, ManifestEntry{Kind: ArtifactReadme, Name: "skills", Source: SourceBuiltin}); err != nil {
 		return fmt.Errorf("error escribiendo skills/README.md: %w", err)
 	}
 
@@ -1462,11 +1564,17 @@ func (g *Generator) buildSkillsReadmeContent(skills []*SkillInfo) string {
 				sb.WriteString(fmt.Sprintf("**Propósito**: %s\n\n", skill.Purpose))
 			}
 
+			if len(skill.Files) > 0 {
+				sb.WriteString(fmt.Sprintf("**Archivos**: %s\n\n", strings.Join(skill.Files, ", ")))
+			}
+
 			sb.WriteString("---\n\n")
 		}
 	}
 
 	sb.WriteString("## Cómo Funcionan las Skills\n\n")
+	sb.WriteString("Cada skill es un directorio `<name>/` con su `SKILL.md` y, opcionalmente, archivos de apoyo ")
+	sb.WriteString("(referencias, ejemplos, scripts) que Claude solo lee cuando los necesita.\n\n")
 	sb.WriteString("Las skills se inyectan en los agentes mediante el frontmatter YAML:\n\n")
 	sb.WriteString("```yaml\n")
 	sb.WriteString("---\n")
@@ -1573,6 +1681,36 @@ func (g *Generator) getAgentGuidelines(agentType string) string {
 	return strings.Join(baseGuidelines, "\n")
 }
 
+// appendGitHabits añade a los agentes que escriben o revisan código las pautas derivadas
+// del historial de git (convención de commits, ramas, directorios más activos y tests).
+func (g *Generator) appendGitHabits(agentType, content string) string {
+	name := strings.ToLower(agentType)
+	if !strings.Contains(name, "develop") && !strings.Contains(name, "review") {
+		return content
+	}
+
+	result := g.projectDetection()
+	if result == nil {
+		return content
+	}
+	guidelines := result.History.Guidelines()
+	if len(guidelines) == 0 {
+		return content
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.TrimRight(content, "\n"))
+	sb.WriteString("\n\n## Team Git Habits\n\nObserved in the repository history; follow them")
+	if strings.Contains(name, "review") {
+		sb.WriteString(" and flag changes that do not")
+	}
+	sb.WriteString(":\n\n")
+	for _, guideline := range guidelines {
+		sb.WriteString("- " + guideline + "\n")
+	}
+	return sb.String()
+}
+
 // getAgentTools retorna las herramientas para un tipo de agente.
 func (g *Generator) getAgentTools(agentType string) string {
 	baseTools := []string{
@@ -2075,7 +2213,9 @@ Generate the skill file following the template structure from the guide above. T
 The skill file MUST start with YAML frontmatter that contains **ALL** fields from the "Template Oficial de Skills" section in the guide above.
 
 **DO NOT omit any field**. The frontmatter MUST include at minimum:
-- name, description, license, version, author, category, tags
+- name, description, license, version, author, metadata.category, tags
+
+The file is saved as skills/%s/SKILL.md: **name** MUST be exactly "%s", and the category goes under **metadata**, never as a top-level field.
 
 **Copy the EXACT structure** from the "Template Oficial de Skills" in the guide, replacing placeholders with actual values.
 
@@ -2109,6 +2249,8 @@ Your output should be directly copyable as a .md file without any modifications.
 		skillDesc,
 		skillTitle,
 		skillGuide,
+		sanitizeFilename(skillName),
+		sanitizeFilename(skillName),
 	)
 
 	return prompt
@@ -2251,7 +2393,7 @@ func (g *Generator) getCommandFlow(commandType string) string {
 		return fmt.Sprintf(`### 1. Ejecutar Tests
 - **Agente**: tester
 - **Skills**: %s-expert, qa-engineer
-- Ejecutar suite de tests completa o específica
+- Ejecutar suite de tests completa o específica%s
 
 ### 2. Análisis de Resultados
 - Revisar tests fallidos
@@ -2261,13 +2403,13 @@ func (g *Generator) getCommandFlow(commandType string) string {
 ### 3. Reporte
 - Generar reporte de cobertura
 - Listar tests fallidos con errores
-- Sugerir mejoras`, langSkill)
+- Sugerir mejoras`, langSkill, g.projectCommandsStep(detector.CommandTest))
 
 	case "lint":
 		return fmt.Sprintf(`### 1. Ejecutar Linters
 - **Agente**: reviewer
 - **Skills**: code-reviewer, %s-expert
-- Ejecutar linters del proyecto
+- Ejecutar linters del proyecto%s
 
 ### 2. Análisis de Problemas
 - Revisar advertencias y errores
@@ -2276,23 +2418,23 @@ func (g *Generator) getCommandFlow(commandType string) string {
 
 ### 3. Corrección Automática
 - Aplicar auto-fix cuando sea posible
-- Generar reporte con sugerencias`, langSkill)
+- Generar reporte con sugerencias`, langSkill, g.projectCommandsStep(detector.CommandLint))
 
 	case "build":
-		return `### 1. Preparación
+		return fmt.Sprintf(`### 1. Preparación
 - **Agente**: developer
 - Verificar dependencias
 - Limpiar artefactos previos
 
 ### 2. Compilación
-- Compilar para el entorno objetivo
+- Compilar para el entorno objetivo%s
 - Verificar que no haya errores de compilación
 - Generar artefactos
 
 ### 3. Validación
 - Ejecutar tests básicos
 - Verificar tamaño de artefactos
-- Generar reporte de build`
+- Generar reporte de build`, g.projectCommandsStep(detector.CommandBuild))
 
 	case "new-feature":
 		return fmt.Sprintf(`### 1. Planificación
@@ -2369,10 +2511,10 @@ func (g *Generator) getCommandFlow(commandType string) string {
 
 // GenerateDevelopmentGuide genera el archivo development_guide.md.
 func (g *Generator) GenerateDevelopmentGuide() error {
-	outputPath := filepath.Join(g.projectPath, ".claude", "development_guide.md")
+	outputPath := filepath.Join(g.outputDir, ".claude", "development_guide.md")
 
 	// Verificar si ya existe y no estamos en modo force
-	if _, err := os.Stat(outputPath); err == nil {
+	if !g.canOverwrite(outputPath) {
 		// Ya existe, no sobrescribir
 		return nil
 	}
@@ -2382,7 +2524,7 @@ func (g *Generator) GenerateDevelopmentGuide() error {
 		return fmt.Errorf("error generando development_guide.md: %w", err)
 	}
 
-	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+	if err := g.writeGenerated(outputPath, content, ManifestEntry{Kind: ArtifactGuide, Name: "development_guide", Source: SourceAI}); err != nil {
 		return fmt.Errorf("error escribiendo development_guide.md: %w", err)
 	}
 
@@ -2394,7 +2536,7 @@ func (g *Generator) GenerateDevelopmentGuide() error {
 // A diferencia de GenerateDevelopmentGuide, esta versión acepta información sobre agents, commands y skills
 // para incluir en la guía de desarrollo.
 func (g *Generator) GenerateDevelopmentGuideWithContext(agentNames, commandNames, skillNames []string) error {
-	outputPath := filepath.Join(g.projectPath, ".claude", "development_guide.md")
+	outputPath := filepath.Join(g.outputDir, ".claude", "development_guide.md")
 
 	// Generar contenido con contexto
 	content, err := g.getDevelopmentGuideTemplateWithContext(agentNames, commandNames, skillNames)
@@ -2402,7 +2544,7 @@ func (g *Generator) GenerateDevelopmentGuideWithContext(agentNames, commandNames
 		return fmt.Errorf("error generando development_guide.md: %w", err)
 	}
 
-	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+	if err := g.writeGenerated(outputPath, content, ManifestEntry{Kind: ArtifactGuide, Name: "development_guide", Source: SourceAI}); err != nil {
 		return fmt.Errorf("error escribiendo development_guide.md: %w", err)
 	}
 
@@ -2413,10 +2555,10 @@ func (g *Generator) GenerateDevelopmentGuideWithContext(agentNames, commandNames
 // GenerateClaudeMD genera el archivo CLAUDE.md con contexto del proyecto.
 // Este archivo se genera PRIMERO para proporcionar contexto a las generaciones posteriores.
 func (g *Generator) GenerateClaudeMD() error {
-	outputPath := filepath.Join(g.projectPath, "CLAUDE.md")
+	outputPath := filepath.Join(g.outputDir, "CLAUDE.md")
 
 	// Verificar si ya existe
-	if _, err := os.Stat(outputPath); err == nil {
+	if !g.canOverwrite(outputPath) {
 		g.logger.Debug("CLAUDE.md ya existe, no se sobrescribe")
 		return nil
 	}
@@ -2426,7 +2568,7 @@ func (g *Generator) GenerateClaudeMD() error {
 		return fmt.Errorf("error generando CLAUDE.md: %w", err)
 	}
 
-	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+	if err := g.writeGenerated(outputPath, content, ManifestEntry{Kind: ArtifactClaudeMD, Name: "CLAUDE", Source: SourceAI}); err != nil {
 		return fmt.Errorf("error escribiendo CLAUDE.md: %w", err)
 	}
 
@@ -2502,7 +2644,13 @@ This file provides guidance to Claude Code (claude.ai/code) when working with co
 ## Component/Module Guidelines
 [Guías específicas para el desarrollo de componentes/módulos]
 
-Genera el contenido completo en markdown, específico y detallado basado en la información del proyecto. NO uses placeholders como "..." o comandos genéricos. Si hay información específica disponible (como los scripts de package.json), ÚSALA.`,
+## Architecture Decisions & API Contracts
+[Decisiones de arquitectura (ADRs, con su estado) y contratos de API (especificaciones OpenAPI/AsyncAPI con sus rutas principales) listados en "Documentation", referenciando la ruta de cada archivo; omite la sección si no hay]
+
+## Git Workflow
+[Convención de mensajes de commit, nombres de rama, referencias a tickets y directorios más activos según "Team git habits"; omite la sección si no hay historial]
+
+Genera el contenido completo en markdown, específico y detallado basado en la información del proyecto. NO uses placeholders como "..." o comandos genéricos. Si hay información específica disponible (como los scripts de package.json), ÚSALA. En "Essential Commands" usa EXACTAMENTE los comandos listados en "Build, test and CI tooling" (targets de Make, scripts y pasos de CI) y no inventes otros. Cita las decisiones y contratos REALES de "Documentation" (ADRs, especificaciones de API, CONTRIBUTING) en lugar de describirlos de forma genérica. En "Git Workflow" describe los hábitos reales de "Team git habits" con sus ejemplos.`,
 		g.answers.ProjectName,
 		g.answers.Description,
 		g.answers.Language,
@@ -2545,6 +2693,22 @@ func (g *Generator) analyzeProjectContext() string {
 		context.WriteString("\n\n")
 	}
 
+	// Comandos reales, CI, contenedores e infraestructura
+	if toolingInfo := g.analyzeTooling(); toolingInfo != "" {
+		context.WriteString("**Build, test and CI tooling:**\n")
+		context.WriteString(toolingInfo)
+		context.WriteString("\n\n")
+	}
+
+	// Hábitos del equipo según el historial de git
+	if result := g.projectDetection(); result != nil {
+		if historyInfo := result.History.Summary(); historyInfo != "" {
+			context.WriteString("**Team git habits (from the git history):**\n")
+			context.WriteString(historyInfo)
+			context.WriteString("\n\n")
+		}
+	}
+
 	// Analizar directorios de documentación
 	if docInfo := g.analyzeDocumentation(); docInfo != "" {
 		context.WriteString("**Documentation:**\n")
@@ -2559,6 +2723,24 @@ func (g *Generator) analyzeProjectContext() string {
 	return result
 }
 
+// analyzeTooling resume los comandos de build, test y lint, los sistemas de CI, los
+// contenedores y la infraestructura detectados en el proyecto.
+func (g *Generator) analyzeTooling() string {
+	result := g.projectDetection()
+	if result == nil {
+		return ""
+	}
+
+	var info strings.Builder
+	if len(result.CI) > 0 {
+		info.WriteString(fmt.Sprintf("- CI: %s\n", strings.Join(result.CI, ", ")))
+	}
+	if tooling := result.Tooling.Summary(); tooling != "" {
+		info.WriteString(tooling + "\n")
+	}
+	return strings.TrimRight(info.String(), "\n")
+}
+
 // analyzePackageJSON analiza el package.json si existe.
 func (g *Generator) analyzePackageJSON() string {
 	pkgPath := filepath.Join(g.projectPath, "package.json")
@@ -2722,100 +2904,82 @@ func (g *Generator) analyzeCodeQualityConfigs() string {
 	return info.String()
 }
 
-// analyzeDocumentation analiza directorios de documentación del proyecto.
+// analyzeDocumentation resume la documentación del proyecto: CONTRIBUTING, documentos de
+// arquitectura, ADRs, especificaciones OpenAPI/AsyncAPI y los documentos de los directorios
+// de documentación, recorridos de forma recursiva.
 func (g *Generator) analyzeDocumentation() string {
 	var info strings.Builder
+	info.WriteString(strings.TrimPrefix(scanner.FormatDocuments(g.projectDocuments()), "\n"))
 
-	// Directorios comunes de documentación a buscar
-	commonDocDirs := []string{"docs", "documentation", "guide", "guides", "wiki", "help"}
-
-	// Recopilar todos los directorios de documentación a analizar
-	dirsToAnalyze := make([]string, 0, len(commonDocDirs)+len(g.answers.DocumentationDirs))
-	dirsToAnalyze = append(dirsToAnalyze, commonDocDirs...)
-	dirsToAnalyze = append(dirsToAnalyze, g.answers.DocumentationDirs...)
-
-	foundAny := false
-
-	for _, docDir := range dirsToAnalyze {
-		if docDir == "" {
-			continue
-		}
-
-		docPath := filepath.Join(g.projectPath, docDir)
-		if stat, err := os.Stat(docPath); err != nil || !stat.IsDir() {
-			continue
-		}
+	if _, err := os.Stat(filepath.Join(g.projectPath, "README.md")); err == nil {
+		info.WriteString("\n- README.md encontrado en la raíz del proyecto\n")
+	}
 
-		if !foundAny {
-			foundAny = true
-			info.WriteString(fmt.Sprintf("- Directorio '%s/' encontrado:\n", docDir))
-		} else {
-			info.WriteString(fmt.Sprintf("\n- Directorio '%s/' encontrado:\n", docDir))
-		}
+	return strings.TrimLeft(info.String(), "\n")
+}
 
-		// Listar archivos de documentación importantes en este directorio
-		if entries, err := os.ReadDir(docPath); err == nil {
-			var mdFiles []string
-			for _, entry := range entries {
-				name := entry.Name()
-				if !entry.IsDir() && (strings.HasSuffix(name, ".md") || strings.HasSuffix(name, ".txt")) {
-					mdFiles = append(mdFiles, name)
-				}
-			}
+// projectDocuments retorna los resúmenes de la documentación del proyecto dentro del
+// presupuesto de tokens. Se calculan una sola vez por generador.
+func (g *Generator) projectDocuments() []scanner.Document {
+	g.docsOnce.Do(func() {
+		g.documents = scanner.NewScanner(g.projectPath, g.scanOptions).Documents(g.documentationDirs())
+		g.logger.Debug("Resumidos %d documentos del proyecto", len(g.documents))
+	})
+	return g.documents
+}
 
-			if len(mdFiles) > 0 {
-				info.WriteString("  Archivos de documentación:\n")
-				for _, file := range mdFiles {
-					// Leer las primeras líneas del archivo para obtener un resumen
-					filePath := filepath.Join(docPath, file)
-					if summary := g.getDocFileSummary(filePath); summary != "" {
-						info.WriteString(fmt.Sprintf("  - %s: %s\n", file, summary))
-					} else {
-						info.WriteString(fmt.Sprintf("  - %s\n", file))
-					}
-				}
-			}
-		}
+// documentationDirs retorna los directorios de documentación detectados en el proyecto más
+// los indicados en las respuestas, sin repetir.
+func (g *Generator) documentationDirs() []string {
+	dirs := detector.DocumentationDirs(g.projectPath)
+	if g.answers == nil {
+		return dirs
 	}
-
-	// También buscar README.md en la raíz
-	if _, err := os.Stat(filepath.Join(g.projectPath, "README.md")); err == nil {
-		if !foundAny {
-			info.WriteString("- README.md encontrado en la raíz del proyecto\n")
-		} else {
-			info.WriteString("\n- README.md encontrado en la raíz del proyecto\n")
+	for _, dir := range g.answers.DocumentationDirs {
+		if dir != "" && !contains(dirs, dir) {
+			dirs = append(dirs, dir)
 		}
 	}
-
-	return info.String()
+	return dirs
 }
 
-// getDocFileSummary obtiene un resumen breve de un archivo de documentación.
-func (g *Generator) getDocFileSummary(filePath string) string {
-	content, err := os.ReadFile(filePath)
-	if err != nil {
-		return ""
+// appendDocReferences añade al agente architect las decisiones de arquitectura (ADRs) y los
+// contratos de API del proyecto, para que sus propuestas partan de ellos.
+func (g *Generator) appendDocReferences(agentType, content string) string {
+	if !strings.Contains(strings.ToLower(agentType), "architect") {
+		return content
 	}
 
-	// Leer primeras líneas para obtener título o descripción
-	lines := strings.Split(string(content), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		// Buscar línea que parezca un título (empieza con #)
-		if strings.HasPrefix(line, "#") {
-			title := strings.TrimPrefix(line, "#")
-			title = strings.TrimSpace(title)
-			if title != "" && len(title) < 100 {
-				return title
-			}
+	var decisions, contracts []string
+	for _, doc := range g.projectDocuments() {
+		reference := fmt.Sprintf("- `%s`", doc.Path)
+		if doc.Title != "" {
+			reference += ": " + doc.Title
 		}
-		// Si encontramos una línea de texto sustancioso
-		if !strings.HasPrefix(line, "#") && !strings.HasPrefix(line, "---") && len(line) > 10 && len(line) < 100 {
-			return line
+		switch doc.Kind {
+		case scanner.DocADR:
+			decisions = append(decisions, reference)
+		case scanner.DocAPISpec:
+			contracts = append(contracts, reference)
+		case scanner.DocArchitecture:
+			decisions = append([]string{reference}, decisions...)
 		}
 	}
+	if len(decisions) == 0 && len(contracts) == 0 {
+		return content
+	}
 
-	return ""
+	var sb strings.Builder
+	sb.WriteString(strings.TrimRight(content, "\n"))
+	if len(decisions) > 0 {
+		sb.WriteString("\n\n## Architectural Decisions\n\nRead these documents before proposing a design; do not contradict an accepted decision without proposing a new ADR:\n\n")
+		sb.WriteString(strings.Join(decisions, "\n") + "\n")
+	}
+	if len(contracts) > 0 {
+		sb.WriteString("\n## API Contracts\n\nKeep changes compatible with these specifications, or update them in the same change:\n\n")
+		sb.WriteString(strings.Join(contracts, "\n") + "\n")
+	}
+	return sb.String()
 }
 
 // isImportantDependency determina si una dependencia es importante de mencionar.
@@ -3256,7 +3420,7 @@ func (g *Generator) parseCommandFrontmatter(filePath string) (*CommandInfo, erro
 func (g *Generator) GenerateCommandsReadme(commandNames []string) error {
 	g.logger.Debug("Generando commands/README.md")
 
-	commandsDir := filepath.Join(g.projectPath, ".claude", "commands")
+	commandsDir := filepath.Join(g.outputDir, ".claude", "commands")
 	outputPath := filepath.Join(commandsDir, "README.md")
 
 	// Parsear todos los comandos para obtener su metadata
@@ -3275,7 +3439,7 @@ func (g *Generator) GenerateCommandsReadme(commandNames []string) error {
 	content := g.buildCommandsReadmeContent(commands)
 
 	// Escribir archivo
-	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+	if err := g.writeGenerated(outputPath, content, ManifestEntry{Kind: ArtifactReadme, Name: "commands", Source: SourceBuiltin}); err != nil {
 		return fmt.Errorf("error escribiendo commands/README.md: %w", err)
 	}
 
@@ -1,7 +1,14 @@
 package claude
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/drossan/claude-init/internal/detector"
+	"github.com/drossan/claude-init/internal/scanner"
+	"github.com/drossan/claude-init/internal/survey"
 )
 
 // TestSanitizeFilename verifies that sanitizeFilename correctly converts various naming formats to kebab-case.
@@ -102,3 +109,235 @@ func TestSanitizeFilename(t *testing.T) {
 		})
 	}
 }
+
+// TestGenerator_GeneratePackageClaudeMD verifica la generación del CLAUDE.md de un paquete.
+func TestGenerator_GeneratePackageClaudeMD(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "apps", "api"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	client := &responseClient{response: "# CLAUDE.md\n\nAPI package"}
+	g := NewGenerator(dir, &survey.Answers{ProjectName: "acme"}, client)
+
+	pkg := detector.Package{Name: "api", Path: "apps/api", Language: "Go", Commands: []string{"go test ./..."}}
+	if err := g.GeneratePackageClaudeMD(pkg); err != nil {
+		t.Fatalf("GeneratePackageClaudeMD() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "apps", "api", "CLAUDE.md"))
+	if err != nil {
+		t.Fatalf("CLAUDE.md not written: %v", err)
+	}
+	if string(content) != client.response {
+		t.Errorf("CLAUDE.md = %q, want %q", content, client.response)
+	}
+	for _, want := range []string{"apps/api", "go test ./...", "acme"} {
+		if !strings.Contains(client.lastPrompt, want) {
+			t.Errorf("prompt does not contain %q", want)
+		}
+	}
+
+	// No se sobrescribe un CLAUDE.md existente
+	client.response = "changed"
+	if err := g.GeneratePackageClaudeMD(pkg); err != nil {
+		t.Fatalf("GeneratePackageClaudeMD() second call error = %v", err)
+	}
+	content, _ = os.ReadFile(filepath.Join(dir, "apps", "api", "CLAUDE.md"))
+	if string(content) == "changed" {
+		t.Error("existing CLAUDE.md was overwritten")
+	}
+}
+
+// TestGenerator_detectAdditionalSkills verifica que las skills se detectan por identidad exacta
+// de módulo (sin falsos positivos por substring) y que incluyen las de los paquetes del monorepo.
+func TestGenerator_detectAdditionalSkills(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.work":          "go 1.22\n\nuse (\n\t./api\n\t./tools\n)\n",
+		"api/go.mod":       "module example.com/api\n\nrequire (\n\tentgo.io/ent v0.12.0\n\tgithub.com/rentals/client v1.0.0\n\tgithub.com/go-chi/chi/v5 v5.0.0\n)\n",
+		"tools/Cargo.toml": "[package]\nname = \"tools\"\n\n[dependencies]\nserde = \"1\"\n",
+	}
+	for rel, content := range files {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	g := NewGenerator(dir, &survey.Answers{ProjectName: "acme"}, &mockClient{})
+	skills := g.detectAdditionalSkills()
+
+	want := []string{"ent", "chi", "serde"}
+	if strings.Join(skills, ",") != strings.Join(want, ",") {
+		t.Errorf("detectAdditionalSkills() = %v, want %v", skills, want)
+	}
+}
+
+// TestGenerator_ProjectCommands verifica que el flujo de los comandos test, lint y build
+// y el contexto del CLAUDE.md usan los comandos reales del Makefile y del CI.
+func TestGenerator_ProjectCommands(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":                   "module example.com/api\n",
+		"Makefile":                 "test:\n\tgo test -race ./...\n\nlint:\n\tgolangci-lint run\n",
+		".github/workflows/ci.yml": "jobs:\n  ci:\n    steps:\n      - run: go test ./...\n",
+		"Dockerfile":               "FROM golang:1.25\n",
+	}
+	for rel, content := range files {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	g := NewGenerator(dir, &survey.Answers{ProjectName: "acme", Language: "Go"}, &mockClient{})
+
+	if flow := g.getCommandFlow("test"); !strings.Contains(flow, "Usar exactamente los comandos del proyecto: `make test`, `go test ./...`") {
+		t.Errorf("test flow does not use the project commands:\n%s", flow)
+	}
+	if flow := g.getCommandFlow("lint"); !strings.Contains(flow, "`make lint`") {
+		t.Errorf("lint flow does not use the project commands:\n%s", flow)
+	}
+	if flow := g.getCommandFlow("build"); !strings.Contains(flow, "`go build ./...`") {
+		t.Errorf("build flow does not fall back to the ecosystem command:\n%s", flow)
+	}
+
+	context := g.analyzeProjectContext()
+	for _, want := range []string{"**Build, test and CI tooling:**", "- CI: GitHub Actions", "- Containers: Dockerfile: Dockerfile"} {
+		if !strings.Contains(context, want) {
+			t.Errorf("analyzeProjectContext() missing %q:\n%s", want, context)
+		}
+	}
+}
+
+// TestGenerator_ProjectDetection_ScanOptions verifica que la detección sin caché respeta los
+// globs de --include/--exclude, igual que el análisis.
+func TestGenerator_ProjectDetection_ScanOptions(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":           "module example.com/api\n",
+		"main.go":          "package main",
+		"scripts/a.py":     "",
+		"scripts/b.py":     "",
+		"scripts/tools.py": "",
+	}
+	for rel, content := range files {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	g := NewGenerator(dir, &survey.Answers{ProjectName: "acme", Language: "Go"}, &mockClient{})
+	g.SetScanOptions(scanner.Options{Exclude: []string{"scripts/"}})
+
+	result := g.projectDetection()
+	if result == nil {
+		t.Fatal("projectDetection() = nil")
+	}
+	for _, share := range result.Languages {
+		if share.Language == "Python" {
+			t.Errorf("excluded scripts/ counted in the detected languages: %+v", result.Languages)
+		}
+	}
+}
+
+// TestGenerator_AppendGitHabits verifica que los agentes developer y reviewer reciben los
+// hábitos del historial de git y el resto de agentes no.
+func TestGenerator_AppendGitHabits(t *testing.T) {
+	g := NewGenerator(t.TempDir(), &survey.Answers{ProjectName: "acme", Language: "Go"}, &mockClient{})
+	g.detectOnce.Do(func() {
+		g.detection = &detector.Result{History: &detector.History{
+			Commits:          40,
+			CommitConvention: detector.CommitConventional,
+			CommitTypes:      []string{"feat", "fix"},
+			BranchPrefixes:   []string{"feature/"},
+			TestChanges:      10,
+			CodeChanges:      20,
+		}}
+	})
+
+	reviewer := g.appendGitHabits("reviewer", "# Reviewer\n")
+	for _, want := range []string{
+		"## Team Git Habits",
+		"follow them and flag changes that do not",
+		"- Write commit messages as Conventional Commits (`type(scope): summary`) using the team's types: feat, fix",
+		"- Name branches with the prefixes feature/",
+		"- Code changes usually come with test changes",
+	} {
+		if !strings.Contains(reviewer, want) {
+			t.Errorf("reviewer agent missing %q:\n%s", want, reviewer)
+		}
+	}
+
+	if developer := g.appendGitHabits("backend-developer", "# Developer\n"); !strings.Contains(developer, "## Team Git Habits") {
+		t.Errorf("developer agent missing the git habits:\n%s", developer)
+	}
+	if architect := g.appendGitHabits("architect", "# Architect\n"); architect != "# Architect\n" {
+		t.Errorf("architect agent should not change:\n%s", architect)
+	}
+
+	if context := g.analyzeProjectContext(); !strings.Contains(context, "**Team git habits (from the git history):**\n- Commit convention: Conventional Commits") {
+		t.Errorf("analyzeProjectContext() missing the git habits:\n%s", context)
+	}
+}
+
+// TestGenerator_Documentation verifica que la documentación se recorre de forma recursiva
+// y que el agente architect referencia los ADRs y los contratos de API.
+func TestGenerator_Documentation(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"README.md":                       "# acme\n",
+		"CONTRIBUTING.md":                 "# Contributing\n\nOne change per pull request.\n",
+		"docs/adr/0002-event-sourcing.md": "# 2. Use event sourcing for orders\n\n## Status\n\nAccepted\n",
+		"docs/api/openapi.json":           `{"openapi": "3.1.0", "info": {"title": "Orders API", "version": "2.0"}, "paths": {"/orders": {"get": {"summary": "List orders"}}}}`,
+	}
+	for rel, content := range files {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	g := NewGenerator(dir, &survey.Answers{ProjectName: "acme", Language: "Go"}, &mockClient{})
+
+	context := g.analyzeDocumentation()
+	for _, want := range []string{
+		"--- CONTRIBUTING.md (contributing: Contributing) ---\nOne change per pull request.",
+		"--- docs/adr/0002-event-sourcing.md (adr: 2. Use event sourcing for orders) ---\n- Status: Accepted",
+		"- GET /orders: List orders",
+		"- README.md encontrado en la raíz del proyecto",
+	} {
+		if !strings.Contains(context, want) {
+			t.Errorf("analyzeDocumentation() missing %q:\n%s", want, context)
+		}
+	}
+
+	architect := g.appendDocReferences("architect", "# Architect\n")
+	for _, want := range []string{
+		"## Architectural Decisions",
+		"- `docs/adr/0002-event-sourcing.md`: 2. Use event sourcing for orders",
+		"## API Contracts",
+		"- `docs/api/openapi.json`: OpenAPI 3.1.0: Orders API v2.0",
+	} {
+		if !strings.Contains(architect, want) {
+			t.Errorf("architect agent missing %q:\n%s", want, architect)
+		}
+	}
+	if developer := g.appendDocReferences("developer", "# Developer\n"); developer != "# Developer\n" {
+		t.Errorf("developer agent should not change:\n%s", developer)
+	}
+}
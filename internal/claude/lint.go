@@ -0,0 +1,73 @@
+package claude
+
+import (
+	"strings"
+
+	"github.com/drossan/claude-init/internal/lint"
+)
+
+// DefaultLintRetries es el número de intentos por defecto de corregir con la IA un archivo
+// generado no válido.
+const DefaultLintRetries = 2
+
+// lintKinds asocia los tipos de artefacto que se validan con su tipo en el paquete lint.
+var lintKinds = map[string]string{
+	ArtifactAgent:   lint.KindAgent,
+	ArtifactSkill:   lint.KindSkill,
+	ArtifactCommand: lint.KindCommand,
+}
+
+// SetLintRetries establece cuántas veces se pide a la IA que corrija un agent, skill o
+// command generado cuyo frontmatter no es válido. Con 0 solo se avisa de los problemas.
+func (g *Generator) SetLintRetries(retries int) {
+	g.lintRetries = retries
+}
+
+// lintGenerated valida el contenido generado para entry y, mientras tenga errores y queden
+// intentos, pide a la IA que lo corrija. Retorna el contenido final; los problemas que
+// queden se registran como avisos.
+func (g *Generator) lintGenerated(entry *ManifestEntry, content string) string {
+	kind, ok := lintKinds[entry.Kind]
+	if !ok {
+		return content
+	}
+
+	issues := lint.Content(kind, entry.Path, content)
+	for attempt := 1; lint.HasErrors(issues) && attempt <= g.lintRetries && g.client != nil; attempt++ {
+		g.logger.Warn("%s no es válido, se regenera (intento %d de %d)", entry.Path, attempt, g.lintRetries)
+		fixed, err := g.generateWithClaude(buildRevisionPrompt(entry.Path, content, buildLintInstructions(issues)), nil)
+		if err != nil {
+			g.logger.Warn("No se pudo regenerar %s: %v", entry.Path, err)
+			break
+		}
+
+		// El resultado ya no es el template adaptado, sino la corrección de la IA
+		content = g.cleanMarkdownOutput(fixed)
+		entry.Source = SourceAI
+		entry.Template, entry.TemplateHash = "", ""
+		issues = lint.Content(kind, entry.Path, content)
+	}
+
+	for _, issue := range issues {
+		g.logger.Warn("%s", issue)
+	}
+	return content
+}
+
+// buildLintInstructions convierte los problemas de validación en instrucciones para la IA.
+func buildLintInstructions(issues []lint.Issue) string {
+	var b strings.Builder
+	b.WriteString("Fix the YAML frontmatter so that Claude Code accepts the file. The validator reported:\n")
+	for _, issue := range issues {
+		if issue.Severity != lint.SeverityError {
+			continue
+		}
+		b.WriteString("- ")
+		if issue.Field != "" {
+			b.WriteString(issue.Field + ": ")
+		}
+		b.WriteString(issue.Message + "\n")
+	}
+	b.WriteString("The file must start with a --- line, contain valid YAML and close the frontmatter with another --- line.")
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/survey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sequenceClient responde con cada respuesta de la lista por orden; la última se repite.
+type sequenceClient struct {
+	responseClient
+	responses []string
+	calls     int
+}
+
+func (c *sequenceClient) SendMessage(systemPrompt, userMessage string) (string, error) {
+	c.lastPrompt = userMessage
+	response := c.responses[min(c.calls, len(c.responses)-1)]
+	c.calls++
+	return response, nil
+}
+
+// TestGenerator_LintRetries verifica que un command generado con frontmatter no válido se
+// regenera hasta que es válido, y que sin reintentos se escribe tal cual.
+func TestGenerator_LintRetries(t *testing.T) {
+	invalid := "---\ndescription: Deploy\nallowed-tools: Terminal\n---\n# Deploy"
+	valid := "---\ndescription: Deploy\nallowed-tools: Bash(make deploy)\n---\n# Deploy"
+
+	tests := []struct {
+		name     string
+		retries  int
+		expected string
+		calls    int
+	}{
+		{name: "con reintentos", retries: 2, expected: valid, calls: 2},
+		{name: "sin reintentos", retries: 0, expected: invalid, calls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			client := &sequenceClient{responses: []string{invalid, valid}}
+			g := NewGenerator(dir, &survey.Answers{ProjectName: "acme", Language: "Go"}, client)
+			g.SetLintRetries(tt.retries)
+
+			require.NoError(t, g.GenerateCommand("ship-release"))
+			content, err := os.ReadFile(filepath.Join(dir, ".claude", "commands", "ship-release.md"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(content))
+			assert.Equal(t, tt.calls, client.calls)
+			if tt.retries > 0 {
+				assert.Contains(t, client.lastPrompt, `unknown tool "Terminal"`)
+			}
+		})
+	}
+}
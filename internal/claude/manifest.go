@@ -0,0 +1,229 @@
+package claude
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/drossan/claude-init/internal/mcp"
+)
+
+// ManifestFile es el archivo, dentro de .claude/, que registra los archivos generados.
+const ManifestFile = ".claude-init-manifest.json"
+
+// BaseDir es el directorio, dentro de .claude/, que guarda la última versión generada de
+// cada archivo. Es la base de la fusión a tres bandas cuando el usuario lo ha editado.
+const BaseDir = ".claude-init-base"
+
+// manifestVersion es la versión del formato del manifiesto.
+const manifestVersion = 1
+
+// Tipos de artefacto registrados en el manifiesto.
+const (
+	ArtifactClaudeMD  = "claude-md"
+	ArtifactAgent     = "agent"
+	ArtifactSkill     = "skill"
+	ArtifactSkillFile = "skill-file" // Archivo de apoyo de una skill (skills/<name>/...)
+	ArtifactCommand   = "command"
+	ArtifactReadme    = "readme"
+	ArtifactGuide     = "guide"
+)
+
+// Orígenes del contenido de un archivo generado.
+const (
+	SourceAI       = "ai"       // Generado por el provider de IA a partir de un prompt
+	SourceTemplate = "template" // Template base adaptado al proyecto
+	SourceBuiltin  = "builtin"  // Construido por claude-init sin IA (índices README)
+)
+
+// Estados de un archivo generado respecto a su contenido registrado.
+const (
+	FileUnchanged = "unchanged"
+	FileModified  = "modified"
+	FileMissing   = "missing"
+)
+
+// Manifest registra los archivos que claude-init ha generado en un proyecto, con su
+// procedencia y el hash de su contenido, para distinguirlos de los editados a mano.
+type Manifest struct {
+	Version     int             `json:"version"`
+	ToolVersion string          `json:"tool_version,omitempty"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	Files       []ManifestEntry `json:"files"`
+}
+
+// ManifestEntry es la procedencia de un archivo generado.
+type ManifestEntry struct {
+	Path           string    `json:"path"` // Relativo al proyecto, con "/"
+	Kind           string    `json:"kind"`
+	Name           string    `json:"name,omitempty"`
+	Source         string    `json:"source"`
+	Provider       string    `json:"provider,omitempty"`
+	Model          string    `json:"model,omitempty"`
+	PromptHash     string    `json:"prompt_hash,omitempty"` // Hash del system prompt y el prompt enviados a la IA
+	Template       string    `json:"template,omitempty"`    // Relativo al directorio de templates, con "/"
+	TemplateHash   string    `json:"template_hash,omitempty"`
+	AnswersHash    string    `json:"answers_hash,omitempty"`    // Hash de las respuestas del proyecto al generarlo
+	DependencyHash string    `json:"dependency_hash,omitempty"` // Hash de los manifiestos de dependencias al generarlo
+	ToolVersion    string    `json:"tool_version,omitempty"`
+	ContentHash    string    `json:"content_hash"`
+	GeneratedAt    time.Time `json:"generated_at"`
+}
+
+// setTemplate marca la entrada como adaptada del template indicado. Se guarda la ruta del
+// template relativa al directorio de templates para que no dependa de dónde se ejecute.
+func (e *ManifestEntry) setTemplate(template *Template) {
+	e.Source = SourceTemplate
+	e.Template = template.Rel
+	e.TemplateHash = HashContent([]byte(template.Content))
+}
+
+// FileStatus es el estado actual de un archivo registrado en el manifiesto.
+type FileStatus struct {
+	Entry ManifestEntry
+	State string // FileUnchanged, FileModified o FileMissing
+}
+
+// ManifestPath retorna la ruta del manifiesto del proyecto.
+func ManifestPath(projectPath string) string {
+	return filepath.Join(projectPath, ".claude", ManifestFile)
+}
+
+// BasePath retorna la ruta de la última versión generada de un archivo del manifiesto.
+func BasePath(projectPath, path string) string {
+	return filepath.Join(projectPath, ".claude", BaseDir, filepath.FromSlash(path))
+}
+
+// ManagedPaths retorna las rutas del proyecto, relativas y con "/", que claude-init puede
+// escribir: .claude/, .mcp.json, el CLAUDE.md de la raíz y el de cada paquete, tanto de los
+// indicados como de los registrados en el manifiesto. Son las rutas que se preparan en
+// staging y se guardan en cada instantánea.
+func ManagedPaths(projectPath string, packages []string) []string {
+	paths := []string{".claude", "CLAUDE.md", mcp.File}
+	seen := map[string]bool{".claude": true, "CLAUDE.md": true, mcp.File: true}
+	add := func(path string) {
+		path = filepath.ToSlash(filepath.Clean(path))
+		if seen[path] || path == "." || strings.HasPrefix(path, "../") || strings.HasPrefix(path, ".claude/") {
+			return
+		}
+		seen[path] = true
+		paths = append(paths, path)
+	}
+
+	for _, pkg := range packages {
+		add(filepath.Join(pkg, "CLAUDE.md"))
+	}
+	if manifest, err := LoadManifest(projectPath); err == nil {
+		for _, entry := range manifest.Files {
+			if entry.Kind == ArtifactClaudeMD {
+				add(entry.Path)
+			}
+		}
+	}
+	return paths
+}
+
+// InternalPaths retorna las rutas, relativas y con "/", de los registros internos de
+// claude-init (el manifiesto y las versiones base), que no forman parte de la configuración.
+func InternalPaths() []string {
+	return []string{".claude/" + ManifestFile, ".claude/" + BaseDir}
+}
+
+// LoadManifest carga el manifiesto del proyecto. Si no existe, retorna uno vacío.
+func LoadManifest(projectPath string) (*Manifest, error) {
+	content, err := os.ReadFile(ManifestPath(projectPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Manifest{Version: manifestVersion}, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", ManifestFile, err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ManifestFile, err)
+	}
+	return &manifest, nil
+}
+
+// Save escribe el manifiesto en .claude/ con los archivos ordenados por ruta.
+func (m *Manifest) Save(projectPath string) error {
+	m.Version = manifestVersion
+	m.UpdatedAt = time.Now().UTC()
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].Path < m.Files[j].Path })
+
+	content, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", ManifestFile, err)
+	}
+
+	manifestPath := ManifestPath(projectPath)
+	if err := os.MkdirAll(filepath.Dir(manifestPath), 0755); err != nil {
+		return fmt.Errorf("failed to create .claude directory: %w", err)
+	}
+	if err := os.WriteFile(manifestPath, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ManifestFile, err)
+	}
+	return nil
+}
+
+// Record añade un archivo al manifiesto o reemplaza su entrada anterior.
+func (m *Manifest) Record(entry ManifestEntry) {
+	for i := range m.Files {
+		if m.Files[i].Path == entry.Path {
+			m.Files[i] = entry
+			return
+		}
+	}
+	m.Files = append(m.Files, entry)
+}
+
+// Remove elimina un archivo del manifiesto.
+func (m *Manifest) Remove(path string) {
+	for i := range m.Files {
+		if m.Files[i].Path == path {
+			m.Files = append(m.Files[:i], m.Files[i+1:]...)
+			return
+		}
+	}
+}
+
+// Entry retorna la entrada de un archivo, por su ruta relativa al proyecto.
+func (m *Manifest) Entry(path string) (ManifestEntry, bool) {
+	for _, entry := range m.Files {
+		if entry.Path == path {
+			return entry, true
+		}
+	}
+	return ManifestEntry{}, false
+}
+
+// Status compara cada archivo registrado con su contenido actual para detectar los que
+// se han editado a mano o eliminado desde que se generaron.
+func (m *Manifest) Status(projectPath string) []FileStatus {
+	statuses := make([]FileStatus, 0, len(m.Files))
+	for _, entry := range m.Files {
+		status := FileStatus{Entry: entry, State: FileUnchanged}
+		content, err := os.ReadFile(filepath.Join(projectPath, filepath.FromSlash(entry.Path)))
+		switch {
+		case err != nil:
+			status.State = FileMissing
+		case HashContent(content) != entry.ContentHash:
+			status.State = FileModified
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// HashContent retorna el hash del contenido con el formato del manifiesto ("sha256:...").
+func HashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,86 @@
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/config"
+	"github.com/drossan/claude-init/internal/survey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerator_Manifest verifica que los archivos generados se registran con su
+// procedencia y que el manifiesto detecta las ediciones y los borrados.
+func TestGenerator_Manifest(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "tpl", "agents"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tpl", "agents", "reviewer.md"), []byte("---\nname: reviewer\n---\n# Reviewer\n"), 0644))
+
+	client := &responseClient{response: "---\nname: test\ndescription: Run tests\n---\n# Test\n", model: "claude-sonnet-4"}
+	g := NewGenerator(dir, &survey.Answers{ProjectName: "acme", Language: "Go"}, client)
+	g.SetProjectSettings(&config.ProjectSettings{Templates: []string{"tpl"}})
+	g.SetToolVersion("1.2.3")
+
+	require.NoError(t, g.GenerateCommand("test"))
+	require.NoError(t, g.GenerateAgent("reviewer"))
+	require.NoError(t, g.SaveManifest())
+
+	manifest, err := LoadManifest(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", manifest.ToolVersion)
+	require.Len(t, manifest.Files, 2)
+
+	command, ok := manifest.Entry(".claude/commands/test.md")
+	require.True(t, ok)
+	assert.Equal(t, ArtifactCommand, command.Kind)
+	assert.Equal(t, SourceAI, command.Source)
+	assert.Equal(t, "mock", command.Provider)
+	assert.Equal(t, "claude-sonnet-4", command.Model)
+	assert.NotEmpty(t, command.PromptHash)
+	assert.Equal(t, "1.2.3", command.ToolVersion)
+	content, err := os.ReadFile(filepath.Join(dir, ".claude", "commands", "test.md"))
+	require.NoError(t, err)
+	assert.Equal(t, HashContent(content), command.ContentHash)
+
+	agent, ok := manifest.Entry(".claude/agents/reviewer.md")
+	require.True(t, ok)
+	assert.Equal(t, SourceTemplate, agent.Source)
+	assert.Equal(t, "agents/reviewer.md", agent.Template)
+	assert.NotEmpty(t, agent.TemplateHash)
+	assert.Empty(t, agent.Provider)
+	assert.Empty(t, agent.PromptHash)
+
+	// Una edición a mano y un borrado se detectan como deriva
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".claude", "commands", "test.md"), []byte("# Edited\n"), 0644))
+	require.NoError(t, os.Remove(filepath.Join(dir, ".claude", "agents", "reviewer.md")))
+	states := make(map[string]string)
+	for _, status := range manifest.Status(dir) {
+		states[status.Entry.Path] = status.State
+	}
+	assert.Equal(t, map[string]string{".claude/commands/test.md": FileModified, ".claude/agents/reviewer.md": FileMissing}, states)
+}
+
+// TestManifest_RecordReplaces verifica que regenerar un archivo reemplaza su entrada.
+func TestManifest_RecordReplaces(t *testing.T) {
+	dir := t.TempDir()
+	manifest, err := LoadManifest(dir)
+	require.NoError(t, err)
+	assert.Empty(t, manifest.Files)
+
+	manifest.Record(ManifestEntry{Path: "CLAUDE.md", Kind: ArtifactClaudeMD, ContentHash: "sha256:a"})
+	manifest.Record(ManifestEntry{Path: ".claude/agents/a.md", Kind: ArtifactAgent, ContentHash: "sha256:b"})
+	manifest.Record(ManifestEntry{Path: "CLAUDE.md", Kind: ArtifactClaudeMD, ContentHash: "sha256:c"})
+	require.NoError(t, manifest.Save(dir))
+
+	loaded, err := LoadManifest(dir)
+	require.NoError(t, err)
+	require.Len(t, loaded.Files, 2)
+	assert.Equal(t, ".claude/agents/a.md", loaded.Files[0].Path, "los archivos se guardan ordenados")
+	assert.Equal(t, "sha256:c", loaded.Files[1].ContentHash)
+
+	loaded.Remove("CLAUDE.md")
+	_, ok := loaded.Entry("CLAUDE.md")
+	assert.False(t, ok)
+}
@@ -0,0 +1,69 @@
+package claude
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/drossan/claude-init/internal/mcp"
+	"github.com/drossan/claude-init/internal/survey"
+)
+
+// AvailableMCPServers retorna los servidores MCP del catálogo que aplican al proyecto, según
+// sus bases de datos (respondidas o detectadas), sus directorios de documentación y el
+// remoto de git.
+func (g *Generator) AvailableMCPServers() []mcp.Server {
+	project := mcp.Project{
+		DocDirs:    g.documentationDirs(),
+		RemoteHost: mcp.RemoteHost(g.projectPath),
+	}
+	if g.answers != nil {
+		project.Databases = append(project.Databases, g.answers.Databases()...)
+	}
+	if result := g.projectDetection(); result != nil {
+		project.Databases = append(project.Databases, result.Databases...)
+	}
+	for i, database := range project.Databases {
+		project.Databases[i] = survey.NormalizeChoice("database", database)
+	}
+	return mcp.Available(project)
+}
+
+// SetMCPServers establece los servidores que GenerateMCP añade a .mcp.json. Por defecto no
+// se añade ninguno: cada servidor se escribe solo si se elige.
+func (g *Generator) SetMCPServers(servers []mcp.Server) {
+	g.mcpServers = servers
+}
+
+// GenerateMCP añade a .mcp.json, en la raíz del proyecto, los servidores elegidos con
+// SetMCPServers. Si el archivo ya existe, se conservan sus servidores y solo se añaden los
+// que faltan. Sin servidores elegidos no se escribe nada.
+func (g *Generator) GenerateMCP() error {
+	if len(g.mcpServers) == 0 {
+		return nil
+	}
+	outputPath := filepath.Join(g.outputDir, mcp.File)
+
+	existing, err := os.ReadFile(outputPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error leyendo %s: %w", mcp.File, err)
+	}
+	content, err := mcp.Merge(existing, g.mcpServers)
+	if err != nil {
+		return fmt.Errorf("no se puede fusionar %s: %w", mcp.File, err)
+	}
+	if string(content) == string(existing) {
+		g.logger.Debug("%s ya contiene los servidores MCP elegidos", mcp.File)
+		return nil
+	}
+	if err := os.WriteFile(outputPath, content, 0644); err != nil {
+		return fmt.Errorf("error escribiendo %s: %w", mcp.File, err)
+	}
+
+	g.logger.Info("%s generado con %d servidores MCP", mcp.File, len(g.mcpServers))
+	if variables := mcp.Variables(g.mcpServers); len(variables) > 0 {
+		g.logger.Info("Define estas variables de entorno antes de arrancar Claude Code: %s", strings.Join(variables, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/survey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerator_GenerateMCP verifica que se proponen los servidores de la base de datos y la
+// documentación del proyecto y que .mcp.json solo se escribe con servidores elegidos.
+func TestGenerator_GenerateMCP(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "docs"), 0755))
+
+	g := NewGenerator(dir, &survey.Answers{ProjectName: "acme", Language: "Go", Database: "postgres"}, nil)
+	available := g.AvailableMCPServers()
+	require.Len(t, available, 2)
+	assert.Equal(t, "postgres", available[0].Name)
+	assert.Equal(t, "docs", available[1].Name)
+
+	mcpPath := filepath.Join(dir, ".mcp.json")
+	require.NoError(t, g.GenerateMCP())
+	assert.NoFileExists(t, mcpPath)
+
+	g.SetMCPServers(available[:1])
+	require.NoError(t, g.GenerateMCP())
+	content, err := os.ReadFile(mcpPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"${DATABASE_URL}"`)
+	assert.NotContains(t, string(content), `"docs"`)
+
+	// Un .mcp.json que no es JSON válido no se sobrescribe
+	require.NoError(t, os.WriteFile(mcpPath, []byte("{broken"), 0644))
+	assert.Error(t, g.GenerateMCP())
+	content, err = os.ReadFile(mcpPath)
+	require.NoError(t, err)
+	assert.Equal(t, "{broken", string(content))
+}
+
+// TestGenerator_AvailableMCPServers_AnsweredDocs verifica que el servidor de documentación
+// incluye los directorios respondidos además de los detectados, sin repetir.
+func TestGenerator_AvailableMCPServers_AnsweredDocs(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "docs"), 0755))
+
+	g := NewGenerator(dir, &survey.Answers{ProjectName: "acme", Language: "Go", DocumentationDirs: []string{"docs", "handbook"}}, nil)
+	available := g.AvailableMCPServers()
+	require.Len(t, available, 1)
+	assert.Equal(t, "docs", available[0].Name)
+	assert.Equal(t, []string{"-y", "@modelcontextprotocol/server-filesystem", "docs", "handbook"}, available[0].Args)
+
+	// Solo con directorios respondidos también se propone
+	g = NewGenerator(t.TempDir(), &survey.Answers{ProjectName: "acme", Language: "Go", DocumentationDirs: []string{"handbook"}}, nil)
+	available = g.AvailableMCPServers()
+	require.Len(t, available, 1)
+	assert.Equal(t, []string{"-y", "@modelcontextprotocol/server-filesystem", "handbook"}, available[0].Args)
+}
@@ -0,0 +1,131 @@
+package claude
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/drossan/claude-init/internal/detector"
+)
+
+// GeneratePackageClaudeMDs genera un CLAUDE.md propio en cada paquete del monorepo
+// seleccionado en answers.Packages. El .claude/ de la raíz se mantiene común.
+func (g *Generator) GeneratePackageClaudeMDs() error {
+	if len(g.answers.Packages) == 0 {
+		return nil
+	}
+
+	result, err := detector.NewDetectorWithOptions(g.projectPath, g.scanOptions).Detect()
+	if err != nil {
+		return fmt.Errorf("error detectando el monorepo: %w", err)
+	}
+	if result.Workspace == nil {
+		return fmt.Errorf("no se detectó un monorepo en %s", g.projectPath)
+	}
+
+	g.logger.Info("Generando CLAUDE.md para %d paquetes...", len(g.answers.Packages))
+	for _, path := range g.answers.Packages {
+		pkg, ok := result.Workspace.Package(path)
+		if !ok {
+			g.logger.Warn("Paquete %s no encontrado en el monorepo", path)
+			continue
+		}
+		if err := g.GeneratePackageClaudeMD(pkg); err != nil {
+			g.logger.Warn("Error generando CLAUDE.md de %s: %v", path, err)
+		}
+	}
+
+	return nil
+}
+
+// GeneratePackageClaudeMD genera el CLAUDE.md de un paquete del monorepo.
+// Si el paquete ya tiene un CLAUDE.md, no se sobrescribe salvo al actualizarlo.
+func (g *Generator) GeneratePackageClaudeMD(pkg detector.Package) error {
+	outputPath := filepath.Join(g.outputDir, filepath.FromSlash(pkg.Path), "CLAUDE.md")
+
+	if !g.canOverwrite(outputPath) {
+		g.logger.Debug("%s ya existe, no se sobrescribe", outputPath)
+		return nil
+	}
+
+	content, err := g.generateWithClaude(g.buildPackageClaudeMDPrompt(pkg), nil)
+	if err != nil {
+		return fmt.Errorf("error generando CLAUDE.md: %w", err)
+	}
+
+	// En un staging el directorio del paquete no existe hasta que se escribe en él
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("error creando el directorio de %s: %w", pkg.Path, err)
+	}
+	if err := g.writeGenerated(outputPath, content, ManifestEntry{Kind: ArtifactClaudeMD, Name: pkg.Path, Source: SourceAI}); err != nil {
+		return fmt.Errorf("error escribiendo CLAUDE.md: %w", err)
+	}
+
+	g.logger.Info("CLAUDE.md generado en %s", outputPath)
+	return nil
+}
+
+// buildPackageClaudeMDPrompt construye el prompt del CLAUDE.md de un paquete.
+func (g *Generator) buildPackageClaudeMDPrompt(pkg detector.Package) string {
+	valueOr := func(values []string) string {
+		if len(values) == 0 {
+			return "No detectado"
+		}
+		return strings.Join(values, ", ")
+	}
+
+	language := pkg.Language
+	if language == "" {
+		language = "No detectado"
+	}
+
+	commands := "No detectados"
+	if len(pkg.Commands) > 0 {
+		commands = "\n```bash\n" + strings.Join(pkg.Commands, "\n") + "\n```"
+	}
+
+	return fmt.Sprintf(`Genera un archivo CLAUDE.md para un paquete del monorepo %s.
+
+El CLAUDE.md de la raíz ya describe las convenciones comunes del repositorio. Este archivo se coloca en el
+directorio del paquete y Claude Code lo carga al trabajar en él, así que debe centrarse SOLO en lo específico
+del paquete y no repetir el contenido de la raíz.
+
+**Paquete:**
+- **Nombre:** %s
+- **Ruta:** %s
+- **Lenguaje:** %s
+- **Frameworks:** %s
+- **Bases de datos:** %s
+- **Testing:** %s
+- **Comandos detectados:** %s
+
+Usa este formato:
+
+# CLAUDE.md
+
+This file provides guidance to Claude Code (claude.ai/code) when working with code in this package.
+
+## Package Overview
+[Propósito del paquete y su relación con el resto del monorepo]
+
+## Tech Stack
+[Stack propio del paquete]
+
+## Essential Commands
+[Comandos del paquete con bloques de código; indica si se ejecutan desde la raíz o desde el directorio del paquete]
+
+## Guidelines
+[Convenciones específicas del paquete]
+
+Usa los comandos detectados tal cual. NO inventes comandos ni uses placeholders.`,
+		g.answers.ProjectName,
+		pkg.Name,
+		pkg.Path,
+		language,
+		valueOr(pkg.Frameworks),
+		valueOr(pkg.Databases),
+		valueOr(pkg.TestFrameworks),
+		commands,
+	)
+}
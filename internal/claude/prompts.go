@@ -291,7 +291,8 @@ Architecture: {{.Architecture}}
 Database: {{.Database}}
 Project Category: {{.ProjectCategory}}
 Business Context: {{.BusinessContext}}
-
+{{range $id, $value := .Extra}}{{if $value}}{{$id}}: {{$value}}
+{{end}}{{end}}
 Please recommend:
 1. Which agents should be generated (list agent names)
 2. Which commands should be generated (list command names)
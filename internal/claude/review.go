@@ -0,0 +1,91 @@
+package claude
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Revise vuelve a generar con la IA un archivo ya generado (ruta relativa, con "/"),
+// partiendo de su contenido actual y de las instrucciones adicionales del usuario.
+func (g *Generator) Revise(path, instructions string) error {
+	entry, ok := g.loadManifest().Entry(path)
+	if !ok {
+		return fmt.Errorf("%s no es un archivo generado por claude-init", path)
+	}
+
+	outputPath := filepath.Join(g.outputDir, filepath.FromSlash(path))
+	current, err := os.ReadFile(outputPath)
+	if err != nil {
+		return fmt.Errorf("error leyendo %s: %w", path, err)
+	}
+
+	content, err := g.generateWithClaude(buildRevisionPrompt(path, string(current), instructions), nil)
+	if err != nil {
+		return fmt.Errorf("error regenerando %s: %w", path, err)
+	}
+
+	// El resultado ya no es el template adaptado, sino la revisión de la IA
+	entry.Source = SourceAI
+	entry.Template, entry.TemplateHash = "", ""
+	if err := g.writeGenerated(outputPath, g.cleanMarkdownOutput(content), entry); err != nil {
+		return fmt.Errorf("error escribiendo %s: %w", path, err)
+	}
+	return nil
+}
+
+// Discard descarta la versión generada de un archivo (ruta relativa, con "/"): lo devuelve
+// al estado que tiene en el proyecto, o lo elimina si no existía, junto con su versión base
+// y su entrada del manifiesto. Solo tiene sentido al generar en un staging.
+func (g *Generator) Discard(path string) error {
+	if g.outputDir == g.projectPath {
+		return fmt.Errorf("no se puede descartar %s: se está generando directamente en el proyecto", path)
+	}
+
+	restore := func(src, dst string) error {
+		content, err := os.ReadFile(src)
+		if os.IsNotExist(err) {
+			if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(dst, content, 0644)
+	}
+	rel := filepath.FromSlash(path)
+	if err := restore(filepath.Join(g.projectPath, rel), filepath.Join(g.outputDir, rel)); err != nil {
+		return fmt.Errorf("error descartando %s: %w", path, err)
+	}
+	if err := restore(BasePath(g.projectPath, path), BasePath(g.outputDir, path)); err != nil {
+		return fmt.Errorf("error descartando la base de %s: %w", path, err)
+	}
+
+	previous, err := LoadManifest(g.projectPath)
+	if err != nil {
+		return err
+	}
+	if entry, ok := previous.Entry(path); ok {
+		g.loadManifest().Record(entry)
+	} else {
+		g.loadManifest().Remove(path)
+	}
+	return nil
+}
+
+// buildRevisionPrompt construye el prompt para revisar un archivo generado.
+func buildRevisionPrompt(path, content, instructions string) string {
+	return fmt.Sprintf(`Revise the following Claude Code configuration file (%s) according to the user's instructions.
+
+## USER INSTRUCTIONS
+%s
+
+## CURRENT FILE
+%s
+
+## OUTPUT
+Reply with the complete revised file only, in Markdown, without explanations and without wrapping it in a code block.
+Keep the YAML frontmatter, if any, and everything the instructions do not ask to change.`, path, instructions, content)
+}
@@ -0,0 +1,72 @@
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/config"
+	"github.com/drossan/claude-init/internal/snapshot"
+	"github.com/drossan/claude-init/internal/survey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerator_ReviseAndDiscard verifica que un archivo generado en staging se puede
+// regenerar con instrucciones o devolver al estado que tiene en el proyecto.
+func TestGenerator_ReviseAndDiscard(t *testing.T) {
+	dir := t.TempDir()
+	base := GetBaseItems()
+	settings := &config.ProjectSettings{
+		Agents:   config.ItemSelection{Exclude: base.Agents},
+		Commands: config.ItemSelection{Exclude: base.Commands},
+		Skills:   config.ItemSelection{Exclude: base.Skills},
+	}
+	client := &responseClient{response: "---\nname: generated\ndescription: Generated\n---\n# Generated\n"}
+	newGenerator := func() *Generator {
+		g := NewGenerator(dir, &survey.Answers{ProjectName: "acme", Language: "Go"}, client)
+		g.SetProjectSettings(settings)
+		return g
+	}
+	require.NoError(t, newGenerator().GenerateAll(&Recommendation{Commands: []string{"test"}}))
+	original, err := os.ReadFile(filepath.Join(dir, ".claude", "commands", "test.md"))
+	require.NoError(t, err)
+
+	// Segunda generación en un staging, con un comando nuevo
+	stage, err := snapshot.NewPreviewStage(dir, ManagedPaths(dir, nil))
+	require.NoError(t, err)
+	defer stage.Discard()
+	g := newGenerator()
+	g.SetOutputDir(stage.Dir())
+	require.NoError(t, g.GenerateCommand("lint"))
+
+	// Regenerar con instrucciones parte del contenido actual
+	client.response = "```markdown\n# Lint\n\nRun golangci-lint.\n```"
+	require.NoError(t, g.Revise(".claude/commands/test.md", "Mention golangci-lint"))
+	assert.Contains(t, client.lastPrompt, "Mention golangci-lint")
+	assert.Contains(t, client.lastPrompt, string(original))
+	revised, err := os.ReadFile(filepath.Join(stage.Dir(), ".claude", "commands", "test.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# Lint\n\nRun golangci-lint.", string(revised))
+	entry, ok := g.loadManifest().Entry(".claude/commands/test.md")
+	require.True(t, ok)
+	assert.Equal(t, SourceAI, entry.Source)
+	assert.Equal(t, HashContent(revised), entry.ContentHash)
+
+	// Descartar devuelve el archivo existente a su estado en el proyecto...
+	require.NoError(t, g.Discard(".claude/commands/test.md"))
+	restored, err := os.ReadFile(filepath.Join(stage.Dir(), ".claude", "commands", "test.md"))
+	require.NoError(t, err)
+	assert.Equal(t, string(original), string(restored))
+	entry, _ = g.loadManifest().Entry(".claude/commands/test.md")
+	assert.Equal(t, HashContent(original), entry.ContentHash)
+
+	// ...y elimina el nuevo, con su base y su entrada
+	require.NoError(t, g.Discard(".claude/commands/lint.md"))
+	assert.NoFileExists(t, filepath.Join(stage.Dir(), ".claude", "commands", "lint.md"))
+	assert.NoFileExists(t, BasePath(stage.Dir(), ".claude/commands/lint.md"))
+	_, ok = g.loadManifest().Entry(".claude/commands/lint.md")
+	assert.False(t, ok)
+
+	assert.Error(t, newGenerator().Discard(".claude/commands/test.md"), "sin staging no hay nada que descartar")
+}
@@ -0,0 +1,81 @@
+package claude
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/drossan/claude-init/internal/hooks"
+	"github.com/drossan/claude-init/internal/settings"
+)
+
+// AvailableHooks retorna los hooks que se pueden generar para el proyecto, según sus
+// formateadores y sus comandos de lint y test.
+func (g *Generator) AvailableHooks() []hooks.Hook {
+	return hooks.Available(g.projectPath, g.projectDetection())
+}
+
+// SetHooks establece los hooks que GenerateSettings añade a settings.json. Por defecto no se
+// añade ninguno: cada hook se genera solo si se elige.
+func (g *Generator) SetHooks(list []hooks.Hook) {
+	g.hooks = list
+}
+
+// GenerateSettings genera .claude/settings.json con los permisos y las variables de entorno
+// derivados de las herramientas detectadas: permite los comandos de build, test y lint del
+// proyecto y deniega los destructivos. Si el archivo ya existe, se fusiona con él sin quitar
+// ni cambiar nada de lo que contiene. Se añaden también los hooks elegidos con SetHooks.
+func (g *Generator) GenerateSettings() error {
+	outputPath := filepath.Join(g.outputDir, ".claude", settings.File)
+	rel := ".claude/" + settings.File
+
+	existing, err := os.ReadFile(outputPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error leyendo %s: %w", rel, err)
+	}
+	created := os.IsNotExist(err)
+
+	rules := settings.Derive(g.projectDetection())
+	rules.Hooks = g.hooks
+	content, err := settings.Merge(existing, rules)
+	if err != nil {
+		return fmt.Errorf("no se puede fusionar %s: %w", rel, err)
+	}
+
+	if !created && string(content) == string(existing) {
+		g.reportSettings(rel, UpdateUnchanged, "no new permission rules or hooks")
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("error creando directorio .claude: %w", err)
+	}
+	if err := os.WriteFile(outputPath, content, 0644); err != nil {
+		return fmt.Errorf("error escribiendo %s: %w", rel, err)
+	}
+
+	if created {
+		g.reportSettings(rel, UpdateCreated, fmt.Sprintf("%d allow and %d deny rules", len(rules.Allow), len(rules.Deny)))
+		g.logger.Info("%s generado con %d reglas allow, %d deny y %d hooks", rel, len(rules.Allow), len(rules.Deny), len(rules.Hooks))
+	} else {
+		g.reportSettings(rel, UpdateMerged, "new permission rules or hooks merged")
+		g.logger.Info("Reglas de permisos y hooks nuevos fusionados en %s", rel)
+	}
+	if len(rules.Env) > 0 {
+		keys := make([]string, 0, len(rules.Env))
+		for key := range rules.Env {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		g.logger.Debug("Variables de entorno de %s: %s", rel, strings.Join(keys, ", "))
+	}
+	return nil
+}
+
+// reportSettings registra el resultado de settings.json durante una actualización.
+func (g *Generator) reportSettings(path, outcome, detail string) {
+	if g.update != nil {
+		g.update.report(path, outcome, detail)
+	}
+}
@@ -0,0 +1,49 @@
+package claude
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/survey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerator_GenerateSettings verifica que settings.json permite los comandos detectados
+// del proyecto y se fusiona con el archivo existente.
+func TestGenerator_GenerateSettings(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/acme\n\ngo 1.25\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Makefile"), []byte("build:\n\tgo build ./...\n\ntest:\n\tgo test ./...\n"), 0644))
+	settingsPath := filepath.Join(dir, ".claude", "settings.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(settingsPath), 0755))
+	require.NoError(t, os.WriteFile(settingsPath, []byte(`{"permissions": {"allow": ["Bash(make lint)"]}, "model": "opus"}`), 0644))
+
+	g := NewGenerator(dir, &survey.Answers{ProjectName: "acme", Language: "Go"}, nil)
+	require.NoError(t, g.GenerateSettings())
+
+	content, err := os.ReadFile(settingsPath)
+	require.NoError(t, err)
+	var doc struct {
+		Model       string `json:"model"`
+		Permissions struct {
+			Allow []string `json:"allow"`
+			Deny  []string `json:"deny"`
+		} `json:"permissions"`
+	}
+	require.NoError(t, json.Unmarshal(content, &doc))
+	assert.Equal(t, "opus", doc.Model)
+	assert.Equal(t, "Bash(make lint)", doc.Permissions.Allow[0])
+	assert.Contains(t, doc.Permissions.Allow, "Bash(make build)")
+	assert.Contains(t, doc.Permissions.Allow, "Bash(make test)")
+	assert.Contains(t, doc.Permissions.Deny, "Bash(git push --force:*)")
+
+	// Un settings.json que no es JSON válido no se sobrescribe
+	require.NoError(t, os.WriteFile(settingsPath, []byte("{broken"), 0644))
+	assert.Error(t, g.GenerateSettings())
+	content, err = os.ReadFile(settingsPath)
+	require.NoError(t, err)
+	assert.Equal(t, "{broken", string(content))
+}
@@ -0,0 +1,245 @@
+package claude
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/drossan/claude-init/internal/lint"
+)
+
+// SkillFile es el archivo principal de una skill, dentro de su directorio skills/<name>/.
+const SkillFile = lint.SkillFile
+
+// skillReference es un archivo de apoyo que se genera con IA para cada skill.
+type skillReference struct {
+	file    string // Ruta relativa al directorio de la skill
+	title   string
+	summary string // Qué contiene, para el enlace desde SKILL.md
+	request string // Qué se pide a la IA
+}
+
+// skillReferences son los archivos de apoyo que se generan con SetSkillReferences.
+var skillReferences = []skillReference{
+	{
+		file:    "references/cheatsheet.md",
+		title:   "Cheat sheet",
+		summary: "commands, APIs and conventions at a glance",
+		request: "a one-page cheat sheet: the most used commands, APIs, options and conventions, as short tables and lists",
+	},
+	{
+		file:    "references/examples.md",
+		title:   "Examples",
+		summary: "example snippets of the patterns this skill describes",
+		request: "example snippets: short, complete code examples of the patterns the skill describes, each one with a heading and one sentence of context",
+	},
+}
+
+// SetSkillReferences indica si se generan con IA los archivos de apoyo de cada skill (una
+// chuleta y ejemplos de código en references/), enlazados desde su SKILL.md.
+func (g *Generator) SetSkillReferences(enabled bool) {
+	g.references = enabled
+}
+
+// wantsSkillReferences indica si se generan las referencias de una skill: si se han pedido
+// o, al actualizar, si ya se generaron la última vez.
+func (g *Generator) wantsSkillReferences(name string) bool {
+	if g.references {
+		return true
+	}
+	if g.update == nil {
+		return false
+	}
+	for _, entry := range g.update.previous.Files {
+		if entry.Kind == ArtifactSkillFile && entry.Source == SourceAI && strings.HasPrefix(entry.Name, name+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// generateSkillReferences genera con IA los archivos de apoyo de una skill a partir de su
+// contenido y retorna el SKILL.md con los enlaces a los que se han generado.
+func (g *Generator) generateSkillReferences(name, content string) string {
+	var links []string
+	for _, ref := range skillReferences {
+		generated, err := g.generateWithClaude(buildSkillReferencePrompt(name, ref, content), nil)
+		if err != nil {
+			g.logger.Warn("No se pudo generar %s de la skill %s: %v", ref.file, name, err)
+			continue
+		}
+		entry := ManifestEntry{Kind: ArtifactSkillFile, Name: name + "/" + ref.file, Source: SourceAI}
+		if err := g.writeSkillFile(name, ref.file, g.cleanMarkdownOutput(generated), entry); err != nil {
+			g.logger.Warn("No se pudo escribir %s de la skill %s: %v", ref.file, name, err)
+			continue
+		}
+		links = append(links, fmt.Sprintf("- [%s](%s): %s", ref.title, ref.file, ref.summary))
+	}
+
+	if len(links) == 0 || strings.Contains(content, "## Additional Resources") {
+		return content
+	}
+	return strings.TrimRight(content, "\n") + "\n\n## Additional Resources\n\nRead these files only when you need the details:\n\n" + strings.Join(links, "\n") + "\n"
+}
+
+// writeSkillFiles escribe los archivos de apoyo del template de una skill. entry es la
+// entrada del SKILL.md, de la que se toma el origen.
+func (g *Generator) writeSkillFiles(name string, files map[string]string, entry ManifestEntry) {
+	rels := make([]string, 0, len(files))
+	for rel := range files {
+		rels = append(rels, rel)
+	}
+	sort.Strings(rels)
+
+	for _, rel := range rels {
+		fileEntry := ManifestEntry{Kind: ArtifactSkillFile, Name: name + "/" + rel, Source: entry.Source}
+		if entry.Template != "" {
+			fileEntry.Template = path.Join(path.Dir(entry.Template), rel)
+			fileEntry.TemplateHash = HashContent([]byte(files[rel]))
+		}
+		if err := g.writeSkillFile(name, rel, files[rel], fileEntry); err != nil {
+			g.logger.Warn("No se pudo escribir %s de la skill %s: %v", rel, name, err)
+		}
+	}
+}
+
+// writeSkillFile escribe un archivo de apoyo (ruta relativa, con "/") en el directorio de
+// una skill.
+func (g *Generator) writeSkillFile(name, rel, content string, entry ManifestEntry) error {
+	outputPath := filepath.Join(g.outputDir, ".claude", "skills", name, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return err
+	}
+	return g.writeGenerated(outputPath, content, entry)
+}
+
+// buildSkillReferencePrompt construye el prompt para generar un archivo de apoyo de una skill.
+func buildSkillReferencePrompt(name string, ref skillReference, skill string) string {
+	return fmt.Sprintf(`Write %s for the "%s" Claude Code skill below. The file is saved as %s inside the skill directory and Claude reads it only when it needs the details, so do not repeat the explanations of SKILL.md.
+
+## SKILL.md
+%s
+
+## OUTPUT
+Reply with the Markdown file only, starting with a "# %s" heading, without YAML frontmatter, without explanations and without wrapping it in a code block.`, ref.request, name, ref.file, skill, ref.title)
+}
+
+// MigrateSkills migra las skills planas del formato anterior (.claude/skills/<name>.md) al
+// formato de directorio de Claude Code (.claude/skills/<name>/SKILL.md): mueve cada archivo
+// con su versión base y su entrada del manifiesto, y pasa su category a metadata.category.
+// Una skill que ya existe con el nuevo formato no se toca. Retorna las skills migradas.
+func (g *Generator) MigrateSkills() ([]string, error) {
+	skillsDir := filepath.Join(g.outputDir, ".claude", "skills")
+	files, err := os.ReadDir(skillsDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error leyendo directorio skills: %w", err)
+	}
+
+	var migrated []string
+	for _, file := range files {
+		if file.IsDir() || file.Name() == "README.md" || filepath.Ext(file.Name()) != ".md" {
+			continue
+		}
+		name := sanitizeFilename(strings.TrimSuffix(file.Name(), ".md"))
+		oldRel := ".claude/skills/" + file.Name()
+		newRel := artifactPath(ArtifactSkill, name)
+		moved, err := g.migrateSkill(oldRel, newRel, name)
+		if err != nil {
+			return migrated, err
+		}
+		if !moved {
+			g.logger.Warn("No se migra %s: ya existe %s", oldRel, newRel)
+			continue
+		}
+		migrated = append(migrated, name)
+	}
+
+	if len(migrated) > 0 {
+		g.logger.Info("%d skills migradas al formato skills/<name>/%s: %s", len(migrated), SkillFile, strings.Join(migrated, ", "))
+		if err := g.SaveManifest(); err != nil {
+			return migrated, err
+		}
+	}
+	return migrated, nil
+}
+
+// migrateSkill mueve una skill plana (oldRel) a su directorio (newRel). Retorna false si
+// la skill ya existe con el nuevo formato.
+func (g *Generator) migrateSkill(oldRel, newRel, name string) (bool, error) {
+	oldPath := filepath.Join(g.outputDir, filepath.FromSlash(oldRel))
+	newPath := filepath.Join(g.outputDir, filepath.FromSlash(newRel))
+	if _, err := os.Stat(newPath); err == nil {
+		return false, nil
+	}
+
+	info, err := g.parseSkillFrontmatter(oldPath)
+	if err != nil {
+		return false, fmt.Errorf("error leyendo %s: %w", oldRel, err)
+	}
+	content, err := os.ReadFile(oldPath)
+	if err != nil {
+		return false, fmt.Errorf("error leyendo %s: %w", oldRel, err)
+	}
+	if info.Category == "unknown" {
+		info.Category = g.determineSkillType(name)
+	}
+	converted := g.ensureSkillCategory(string(content), info.Category, name)
+	if err := os.MkdirAll(filepath.Dir(newPath), 0755); err != nil {
+		return false, fmt.Errorf("error creando directorio de la skill %s: %w", name, err)
+	}
+	if err := os.WriteFile(newPath, []byte(converted), 0644); err != nil {
+		return false, fmt.Errorf("error escribiendo %s: %w", newRel, err)
+	}
+	if err := os.Remove(oldPath); err != nil {
+		return false, fmt.Errorf("error eliminando %s: %w", oldRel, err)
+	}
+
+	// La versión base se migra igual, para que la fusión a tres bandas siga funcionando
+	baseHash := ""
+	if base, err := os.ReadFile(BasePath(g.outputDir, oldRel)); err == nil {
+		convertedBase := g.ensureSkillCategory(string(base), info.Category, name)
+		basePath := BasePath(g.outputDir, newRel)
+		if err := os.MkdirAll(filepath.Dir(basePath), 0755); err == nil && os.WriteFile(basePath, []byte(convertedBase), 0644) == nil {
+			_ = os.Remove(BasePath(g.outputDir, oldRel))
+			baseHash = HashContent([]byte(convertedBase))
+		}
+	}
+
+	manifest := g.loadManifest()
+	if entry, ok := manifest.Entry(oldRel); ok {
+		unedited := HashContent(content) == entry.ContentHash
+		manifest.Remove(oldRel)
+		entry.Path, entry.Name = newRel, name
+		switch {
+		case baseHash != "":
+			entry.ContentHash = baseHash
+		case unedited:
+			entry.ContentHash = HashContent([]byte(converted))
+		}
+		manifest.Record(entry)
+	}
+	return true, nil
+}
+
+// skillFiles retorna los archivos de apoyo del directorio de una skill, relativos a él y
+// con "/".
+func skillFiles(dir string) []string {
+	var rels []string
+	_ = filepath.WalkDir(dir, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		if rel, err := filepath.Rel(dir, p); err == nil && rel != SkillFile {
+			rels = append(rels, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	return rels
+}
@@ -0,0 +1,146 @@
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/config"
+	"github.com/drossan/claude-init/internal/survey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerator_EnsureSkillCategory verifica que el nombre coincide con el directorio de la
+// skill y que la categoría queda en metadata.category.
+func TestGenerator_EnsureSkillCategory(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "without frontmatter",
+			content: "# Go\n",
+			want:    "---\nname: go\ndescription: Skill for go\nmetadata:\n  category: language\n---\n\n# Go\n",
+		},
+		{
+			name:    "top-level category",
+			content: "---\nname: Go Expert\ncategory: base\ndescription: Go idioms\n---\n# Go\n",
+			want:    "---\nname: go\ndescription: Go idioms\nmetadata:\n  category: language\n---\n# Go\n",
+		},
+		{
+			name:    "existing metadata",
+			content: "---\nname: go\ndescription: Go idioms\nmetadata:\n  owner: platform\n  category: base\ntags: [go]\n---\n# Go\n",
+			want:    "---\nname: go\ndescription: Go idioms\nmetadata:\n  category: language\n  owner: platform\ntags: [go]\n---\n# Go\n",
+		},
+		{
+			name:    "inline metadata",
+			content: "---\ndescription: Go idioms\nmetadata: {owner: platform}\n---\n# Go\n",
+			want:    "---\nname: go\ndescription: Go idioms\nmetadata: {owner: platform}\n---\n# Go\n",
+		},
+	}
+
+	g := NewGenerator(t.TempDir(), &survey.Answers{ProjectName: "acme", Language: "Go"}, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, g.ensureSkillCategory(tt.content, "language", "go"))
+		})
+	}
+}
+
+// TestGenerator_GenerateSkill verifica que la skill se escribe en skills/<name>/SKILL.md con
+// sus referencias generadas y que el README la lista con sus archivos de apoyo.
+func TestGenerator_GenerateSkill(t *testing.T) {
+	dir := t.TempDir()
+	client := &sequenceClient{responses: []string{
+		"---\nname: go\ndescription: Go idioms for acme\n---\n# Go\n\nUse table-driven tests.\n",
+		"# Cheat sheet\n\n| Command | Use |\n",
+		"# Examples\n\n## Table-driven test\n",
+	}}
+	g := NewGenerator(dir, &survey.Answers{ProjectName: "acme", Language: "Go"}, client)
+	g.SetProjectSettings(&config.ProjectSettings{})
+	g.SetSkillReferences(true)
+
+	require.NoError(t, g.GenerateSkill("language", "go"))
+	require.NoError(t, g.GenerateSkillsReadme(nil))
+
+	skillDir := filepath.Join(dir, ".claude", "skills", "go")
+	content, err := os.ReadFile(filepath.Join(skillDir, SkillFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "metadata:\n  category: language\n")
+	assert.Contains(t, string(content), "- [Cheat sheet](references/cheatsheet.md)")
+	assert.Contains(t, string(content), "- [Examples](references/examples.md)")
+	assert.FileExists(t, filepath.Join(skillDir, "references", "cheatsheet.md"))
+	assert.FileExists(t, filepath.Join(skillDir, "references", "examples.md"))
+	assert.NoFileExists(t, filepath.Join(dir, ".claude", "skills", "go.md"))
+
+	readme, err := os.ReadFile(filepath.Join(dir, ".claude", "skills", "README.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(readme), "#### go\n")
+	assert.Contains(t, string(readme), "**Archivos**: references/cheatsheet.md, references/examples.md")
+
+	manifest := g.loadManifest()
+	entry, ok := manifest.Entry(".claude/skills/go/references/examples.md")
+	require.True(t, ok)
+	assert.Equal(t, ArtifactSkillFile, entry.Kind)
+	assert.Equal(t, "go/references/examples.md", entry.Name)
+}
+
+// TestGenerator_MigrateSkills verifica que una skill plana se mueve a su directorio con su
+// versión base y su entrada del manifiesto, y que update la migra antes de podarla.
+func TestGenerator_MigrateSkills(t *testing.T) {
+	dir := t.TempDir()
+	flat := "---\nname: go\ncategory: language\ndescription: Go idioms\n---\n# Go\n"
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".claude", "skills"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".claude", "skills", "go.md"), []byte(flat), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Dir(BasePath(dir, ".claude/skills/go.md")), 0755))
+	require.NoError(t, os.WriteFile(BasePath(dir, ".claude/skills/go.md"), []byte(flat), 0644))
+
+	manifest := &Manifest{}
+	manifest.Record(ManifestEntry{Path: ".claude/skills/go.md", Kind: ArtifactSkill, Name: "go", Source: SourceAI, ContentHash: HashContent([]byte(flat))})
+	manifest.Record(ManifestEntry{Path: ".claude/agents/reviewer.md", Kind: ArtifactAgent, Name: "reviewer", Source: SourceAI})
+	require.NoError(t, manifest.Save(dir))
+
+	newGenerator := func() *Generator {
+		g := NewGenerator(dir, &survey.Answers{ProjectName: "acme", Language: "Go"}, &responseClient{})
+		g.SetProjectSettings(&config.ProjectSettings{})
+		return g
+	}
+
+	// La migración está en el plan aunque no haya nada más que hacer
+	g := newGenerator()
+	plan, err := g.PlanUpdate(&Recommendation{Agents: []string{"reviewer"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{".claude/skills/go.md"}, plan.Migrate)
+	require.Len(t, plan.Obsolete, 1)
+	assert.Equal(t, ".claude/skills/go/SKILL.md", plan.Obsolete[0].Path)
+
+	migrated, err := newGenerator().MigrateSkills()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go"}, migrated)
+
+	newPath := filepath.Join(dir, ".claude", "skills", "go", SkillFile)
+	content, err := os.ReadFile(newPath)
+	require.NoError(t, err)
+	assert.Equal(t, "---\nname: go\ndescription: Go idioms\nmetadata:\n  category: language\n---\n# Go\n", string(content))
+	assert.NoFileExists(t, filepath.Join(dir, ".claude", "skills", "go.md"))
+	assert.FileExists(t, BasePath(dir, ".claude/skills/go/SKILL.md"))
+
+	manifest, err = LoadManifest(dir)
+	require.NoError(t, err)
+	_, ok := manifest.Entry(".claude/skills/go.md")
+	assert.False(t, ok)
+	entry, ok := manifest.Entry(".claude/skills/go/SKILL.md")
+	require.True(t, ok)
+	assert.Equal(t, HashContent(content), entry.ContentHash)
+
+	// Sin editar tras migrarla, la skill que ya no se recomienda se poda con su directorio
+	g = newGenerator()
+	plan, err = g.PlanUpdate(&Recommendation{Agents: []string{"reviewer"}})
+	require.NoError(t, err)
+	assert.Empty(t, plan.Migrate)
+	_, err = g.Update(plan, UpdateOptions{Prune: true})
+	require.NoError(t, err)
+	assert.NoDirExists(t, filepath.Join(dir, ".claude", "skills", "go"))
+}
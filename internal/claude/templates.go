@@ -2,6 +2,7 @@ package claude
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -34,11 +35,27 @@ func NewTemplateLoader() *TemplateLoader {
 	return &TemplateLoader{templatesPath: ""}
 }
 
+// NewTemplateLoaderWithPaths crea un TemplateLoader que usa el primer directorio existente
+// de la lista dada (por ejemplo, los templates declarados en .claude-init.yaml).
+// Si ninguno existe, se comporta como NewTemplateLoader.
+func NewTemplateLoaderWithPaths(paths []string) *TemplateLoader {
+	for _, path := range paths {
+		if dirExists(path) {
+			return &TemplateLoader{templatesPath: path}
+		}
+	}
+
+	return NewTemplateLoader()
+}
+
 // Template contiene el contenido de un template.
 type Template struct {
 	Name    string
 	Type    string // "agent", "command", "skill"
+	Path    string // Archivo del que se cargó el template
+	Rel     string // Ruta del archivo relativa al directorio de templates, con "/"
 	Content string
+	Files   map[string]string // Archivos de apoyo de una skill con formato de directorio, por ruta relativa
 }
 
 // LoadTemplate carga un template específico por tipo y nombre.
@@ -54,7 +71,7 @@ func (tl *TemplateLoader) LoadTemplate(templateType, name string) (*Template, er
 	case "command":
 		templatePath = filepath.Join(tl.templatesPath, "commands", name+".md")
 	case "skill":
-		templatePath = filepath.Join(tl.templatesPath, "skills", name+".md")
+		templatePath = tl.skillTemplatePath(name)
 	default:
 		return nil, fmt.Errorf("unknown template type: %s", templateType)
 	}
@@ -64,14 +81,73 @@ func (tl *TemplateLoader) LoadTemplate(templateType, name string) (*Template, er
 		return nil, fmt.Errorf("failed to read template %s: %w", templatePath, err)
 	}
 
-	return &Template{
+	template := &Template{
 		Name:    name,
 		Type:    templateType,
+		Path:    templatePath,
 		Content: string(content),
-	}, nil
+	}
+	if rel, err := filepath.Rel(tl.templatesPath, templatePath); err == nil {
+		template.Rel = filepath.ToSlash(rel)
+	}
+	if templateType == "skill" && filepath.Base(templatePath) == SkillFile {
+		if template.Files, err = loadSkillFiles(filepath.Dir(templatePath)); err != nil {
+			return nil, fmt.Errorf("failed to read template %s: %w", templatePath, err)
+		}
+	}
+	return template, nil
+}
+
+// ReadTemplateFile lee un archivo del directorio de templates a partir de su ruta relativa
+// con "/", la que se guarda en el manifiesto.
+func (tl *TemplateLoader) ReadTemplateFile(rel string) ([]byte, error) {
+	if tl.templatesPath == "" {
+		return nil, fmt.Errorf("templates path not found")
+	}
+	if !filepath.IsLocal(filepath.FromSlash(rel)) {
+		return nil, fmt.Errorf("invalid template path: %s", rel)
+	}
+	return os.ReadFile(filepath.Join(tl.templatesPath, filepath.FromSlash(rel)))
+}
+
+// skillTemplatePath retorna el archivo del template de una skill: skills/<name>/SKILL.md si
+// el template tiene formato de directorio, o skills/<name>.md.
+func (tl *TemplateLoader) skillTemplatePath(name string) string {
+	dirTemplate := filepath.Join(tl.templatesPath, "skills", filepath.FromSlash(name), SkillFile)
+	if _, err := os.Stat(dirTemplate); err == nil {
+		return dirTemplate
+	}
+	return filepath.Join(tl.templatesPath, "skills", name+".md")
+}
+
+// loadSkillFiles lee los archivos de apoyo del directorio de una skill (todos menos
+// SKILL.md), indexados por su ruta relativa con "/".
+func loadSkillFiles(dir string) (map[string]string, error) {
+	files := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == SkillFile {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(content)
+		return nil
+	})
+	return files, err
 }
 
+// extraPlaceholder reconoce las referencias {{.Extra.<id>}} a respuestas personalizadas.
+var extraPlaceholder = regexp.MustCompile(`\{\{\s*\.Extra\.([A-Za-z0-9_-]+)\s*\}\}`)
+
 // AdaptTemplate adapta un template al proyecto actual reemplazando placeholders.
+// Las referencias {{.Extra.<id>}} se sustituyen por la respuesta personalizada, o por nada
+// si no se ha respondido.
 func (tl *TemplateLoader) AdaptTemplate(template *Template, answers interface{}) string {
 	content := template.Content
 
@@ -90,6 +166,11 @@ func (tl *TemplateLoader) AdaptTemplate(template *Template, answers interface{})
 		content = tl.adaptSkillTemplate(content, ans)
 	}
 
+	// Sin text/template: los templates pueden contener otras llaves, como ${{ }} de GitHub Actions
+	content = extraPlaceholder.ReplaceAllStringFunc(content, func(match string) string {
+		return ans.Extra[extraPlaceholder.FindStringSubmatch(match)[1]]
+	})
+
 	return content
 }
 
@@ -202,7 +283,7 @@ func (tl *TemplateLoader) HasTemplate(templateType, name string) bool {
 	case "command":
 		templatePath = filepath.Join(tl.templatesPath, "commands", name+".md")
 	case "skill":
-		templatePath = filepath.Join(tl.templatesPath, "skills", name+".md")
+		templatePath = tl.skillTemplatePath(name)
 	default:
 		return false
 	}
@@ -277,6 +358,10 @@ func (tl *TemplateLoader) GetAvailableTemplates() map[string][]string {
 			name := strings.TrimSuffix(filepath.Base(f), ".md")
 			result["skills"] = append(result["skills"], name)
 		}
+		dirs, _ := filepath.Glob(filepath.Join(skillsDir, "*", SkillFile))
+		for _, f := range dirs {
+			result["skills"] = append(result["skills"], filepath.Base(filepath.Dir(f)))
+		}
 	}
 
 	return result
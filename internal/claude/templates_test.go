@@ -0,0 +1,21 @@
+package claude
+
+import (
+	"testing"
+
+	"github.com/drossan/claude-init/internal/survey"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTemplateLoader_AdaptTemplate_Extra verifica que las referencias a respuestas
+// personalizadas se sustituyen y que el resto de llaves no se tocan.
+func TestTemplateLoader_AdaptTemplate_Extra(t *testing.T) {
+	template := &Template{
+		Type:    "command",
+		Content: "Publish to {{.Extra.message_broker}} ({{ .Extra.compliance }}{{.Extra.missing}}).\nrun: echo ${{ github.sha }}\n",
+	}
+	answers := &survey.Answers{ProjectName: "acme", Extra: map[string]string{"message_broker": "Kafka", "compliance": "GDPR"}}
+
+	content := NewTemplateLoader().AdaptTemplate(template, answers)
+	assert.Equal(t, "Publish to Kafka (GDPR).\nrun: echo ${{ github.sha }}\n", content)
+}
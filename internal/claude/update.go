@@ -0,0 +1,415 @@
+package claude
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/drossan/claude-init/internal/merge"
+)
+
+// Resultados de actualizar un archivo generado.
+const (
+	UpdateCreated   = "created"   // No existía: se ha creado
+	UpdateUpdated   = "updated"   // Sin ediciones del usuario: se ha reemplazado
+	UpdateUnchanged = "unchanged" // Se ha regenerado con el mismo contenido
+	UpdateMerged    = "merged"    // Editado por el usuario: se han fusionado ambas versiones
+	UpdateConflict  = "conflict"  // Editado por el usuario: la fusión tiene marcadores de conflicto
+	UpdateKept      = "kept"      // Editado por el usuario o ajeno a claude-init: no se ha tocado
+	UpdateSkipped   = "skipped"   // Eliminado por el usuario: no se vuelve a crear
+	UpdateRemoved   = "removed"   // Ya no se recomienda y se ha eliminado (--prune)
+	UpdateObsolete  = "obsolete"  // Ya no se recomienda, pero se conserva
+	UpdateMigrated  = "migrated"  // Skill plana movida a skills/<name>/SKILL.md
+)
+
+// Motivos para regenerar un archivo.
+const (
+	ReasonNew          = "new recommendation"
+	ReasonAnswers      = "answers changed"
+	ReasonDependencies = "dependencies changed"
+	ReasonTemplate     = "template updated"
+	ReasonToolVersion  = "claude-init upgraded"
+)
+
+// UpdateOptions son las opciones de una actualización.
+type UpdateOptions struct {
+	Merge bool // Fusiona a tres bandas los archivos editados; si es false, los conserva
+	Prune bool // Elimina los archivos que ya no se recomiendan, si no se han editado
+}
+
+// UpdateItem es un archivo que se va a regenerar o crear.
+type UpdateItem struct {
+	Kind    string
+	Name    string
+	Path    string
+	Reasons []string
+}
+
+// UpdatePlan es lo que haría una actualización: qué archivos regenerar y cuáles ya no se
+// recomiendan.
+type UpdatePlan struct {
+	Items    []UpdateItem
+	Obsolete []ManifestEntry
+	Migrate  []string // Skills planas del formato anterior que se migran, con su ruta actual
+
+	agents, commands, skills []string // Items recomendados, para los README y la guía
+}
+
+// Empty indica si la actualización no tiene nada que hacer.
+func (p *UpdatePlan) Empty() bool {
+	return len(p.Items) == 0 && len(p.Obsolete) == 0 && len(p.Migrate) == 0
+}
+
+// UpdateResult es lo que una actualización ha hecho con un archivo.
+type UpdateResult struct {
+	Path    string
+	Outcome string
+	Detail  string
+}
+
+// updateRun es el estado de una actualización en curso. Decide qué se escribe en cada
+// archivo según haya sido editado o no desde la última generación.
+type updateRun struct {
+	outputDir string
+	previous  *Manifest // Manifiesto anterior a la actualización
+	merge     bool
+	results   []UpdateResult
+}
+
+// PlanUpdate compara el manifiesto con el estado actual del proyecto y decide qué archivos
+// regenerar: los generados con otras respuestas, otras dependencias o un template que ha
+// cambiado, y los recomendados que aún no existen.
+func (g *Generator) PlanUpdate(rec *Recommendation) (*UpdatePlan, error) {
+	manifest, err := LoadManifest(g.outputDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(manifest.Files) == 0 {
+		return nil, fmt.Errorf("no generation manifest found at %s (run 'claude-init init' first)", ManifestPath(g.outputDir))
+	}
+
+	plan := &UpdatePlan{}
+	plan.agents, plan.commands, plan.skills = g.plannedItems(rec)
+	planned := map[string][]string{
+		ArtifactAgent:   plan.agents,
+		ArtifactCommand: plan.commands,
+		ArtifactSkill:   plan.skills,
+	}
+	isPlanned := func(kind, name string) bool {
+		for _, item := range planned[kind] {
+			if sanitizeFilename(item) == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, entry := range manifest.Files {
+		if entry.Kind == ArtifactSkill && entry.Path != artifactPath(ArtifactSkill, entry.Name) {
+			// Skill plana: Update la migra antes de regenerarla o eliminarla
+			plan.Migrate = append(plan.Migrate, entry.Path)
+			entry.Path = artifactPath(ArtifactSkill, entry.Name)
+		}
+		switch entry.Kind {
+		case ArtifactAgent, ArtifactCommand, ArtifactSkill:
+			if !isPlanned(entry.Kind, entry.Name) {
+				plan.Obsolete = append(plan.Obsolete, entry)
+				continue
+			}
+		case ArtifactSkillFile:
+			// Se regeneran junto con su skill, y sobran si ella sobra
+			skill, _, _ := strings.Cut(entry.Name, "/")
+			if !isPlanned(ArtifactSkill, skill) {
+				plan.Obsolete = append(plan.Obsolete, entry)
+			}
+			continue
+		case ArtifactReadme, ArtifactGuide:
+			// Se regeneran junto con los archivos de los que dependen
+			continue
+		case ArtifactClaudeMD:
+			if entry.Name != "CLAUDE" && !contains(g.answers.Packages, entry.Name) {
+				continue
+			}
+		}
+		if reasons := g.staleReasons(entry); len(reasons) > 0 {
+			plan.Items = append(plan.Items, UpdateItem{Kind: entry.Kind, Name: entry.Name, Path: entry.Path, Reasons: reasons})
+		}
+	}
+
+	// Archivos recomendados que nunca se han generado
+	for _, kind := range []string{ArtifactAgent, ArtifactSkill, ArtifactCommand} {
+		for _, name := range planned[kind] {
+			path := artifactPath(kind, name)
+			if _, ok := manifest.Entry(path); !ok {
+				plan.Items = append(plan.Items, UpdateItem{Kind: kind, Name: name, Path: path, Reasons: []string{ReasonNew}})
+			}
+		}
+	}
+	for _, name := range append([]string{"CLAUDE"}, g.answers.Packages...) {
+		path := artifactPath(ArtifactClaudeMD, name)
+		if _, ok := manifest.Entry(path); ok {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(g.outputDir, filepath.FromSlash(path))); err != nil {
+			plan.Items = append(plan.Items, UpdateItem{Kind: ArtifactClaudeMD, Name: name, Path: path, Reasons: []string{ReasonNew}})
+		}
+	}
+
+	order := map[string]int{ArtifactClaudeMD: 0, ArtifactAgent: 1, ArtifactSkill: 2, ArtifactCommand: 3}
+	sort.SliceStable(plan.Items, func(i, j int) bool { return order[plan.Items[i].Kind] < order[plan.Items[j].Kind] })
+	return plan, nil
+}
+
+// InputsChanged indica si las respuestas o las dependencias del proyecto han cambiado desde
+// que se generó algún archivo del manifiesto. Si no, la recomendación sigue siendo válida.
+func (g *Generator) InputsChanged(manifest *Manifest) bool {
+	answersHash, dependencyHash := g.inputHashes()
+	for _, entry := range manifest.Files {
+		if (entry.AnswersHash != "" && entry.AnswersHash != answersHash) ||
+			(entry.DependencyHash != "" && entry.DependencyHash != dependencyHash) {
+			return true
+		}
+	}
+	return false
+}
+
+// Recommendation retorna la recomendación implícita en el manifiesto: los agentes, skills y
+// comandos generados hasta ahora.
+func (m *Manifest) Recommendation() *Recommendation {
+	rec := &Recommendation{}
+	for _, entry := range m.Files {
+		switch entry.Kind {
+		case ArtifactAgent:
+			rec.Agents = append(rec.Agents, entry.Name)
+		case ArtifactSkill:
+			rec.Skills = append(rec.Skills, entry.Name)
+		case ArtifactCommand:
+			rec.Commands = append(rec.Commands, entry.Name)
+		}
+	}
+	return rec
+}
+
+// staleReasons retorna por qué un archivo generado está desactualizado, o nada si está al día.
+func (g *Generator) staleReasons(entry ManifestEntry) []string {
+	answersHash, dependencyHash := g.inputHashes()
+
+	var reasons []string
+	if entry.AnswersHash != "" && entry.AnswersHash != answersHash {
+		reasons = append(reasons, ReasonAnswers)
+	}
+	if entry.DependencyHash != "" && entry.DependencyHash != dependencyHash {
+		reasons = append(reasons, ReasonDependencies)
+	}
+	if entry.Template != "" {
+		content, err := g.templateLoader.ReadTemplateFile(entry.Template)
+		if err != nil || HashContent(content) != entry.TemplateHash {
+			reasons = append(reasons, ReasonTemplate)
+		}
+	} else if entry.Source == SourceTemplate && g.toolVersion != "" && entry.ToolVersion != g.toolVersion {
+		// Los templates incrustados cambian con la versión de claude-init
+		reasons = append(reasons, ReasonToolVersion)
+	}
+	return reasons
+}
+
+// Update aplica el plan: migra las skills planas al formato de directorio, regenera sus
+// archivos, y los README y la guía de desarrollo que dependen de ellos. Los archivos sin
+// editar se reemplazan; los editados a mano se fusionan a tres bandas con su nueva versión
+// o, sin opts.Merge, se conservan. Los eliminados por el usuario no se vuelven a crear.
+// Guarda el manifiesto y retorna qué se ha hecho con cada archivo.
+func (g *Generator) Update(plan *UpdatePlan, opts UpdateOptions) ([]UpdateResult, error) {
+	migrated, err := g.MigrateSkills()
+	if err != nil {
+		return nil, err
+	}
+	previous, err := LoadManifest(g.outputDir)
+	if err != nil {
+		return nil, err
+	}
+	g.manifest = nil
+	g.update = &updateRun{outputDir: g.outputDir, previous: previous, merge: opts.Merge}
+	defer func() { g.update = nil }()
+	for _, name := range migrated {
+		g.update.report(artifactPath(ArtifactSkill, name), UpdateMigrated, "moved from .claude/skills/"+name+".md")
+	}
+
+	changed := make(map[string]bool)
+	generate := func(item UpdateItem) error {
+		switch item.Kind {
+		case ArtifactClaudeMD:
+			if item.Name == "CLAUDE" {
+				return g.GenerateClaudeMD()
+			}
+			workspace := g.projectDetection().Workspace
+			if workspace == nil {
+				return fmt.Errorf("no monorepo detected in %s", g.projectPath)
+			}
+			pkg, ok := workspace.Package(item.Name)
+			if !ok {
+				return fmt.Errorf("package %s not found in the monorepo", item.Name)
+			}
+			return g.GeneratePackageClaudeMD(pkg)
+		case ArtifactAgent:
+			return g.GenerateAgent(item.Name)
+		case ArtifactSkill:
+			return g.GenerateSkill(g.determineSkillType(item.Name), item.Name)
+		case ArtifactCommand:
+			return g.GenerateCommandWithContext(item.Name, g.getReadmeContent("agents"), g.getReadmeContent("skills"))
+		}
+		return nil
+	}
+
+	readmes := map[string]func() error{
+		ArtifactAgent:   func() error { return g.GenerateAgentsReadme(plan.agents) },
+		ArtifactSkill:   func() error { return g.GenerateSkillsReadme(plan.skills) },
+		ArtifactCommand: func() error { return g.GenerateCommandsReadme(plan.commands) },
+	}
+	for i, item := range plan.Items {
+		if err := generate(item); err != nil {
+			g.logger.Warn("Error regenerando %s: %v", item.Path, err)
+		}
+		changed[item.Kind] = true
+
+		// Al terminar cada tipo, regenerar su README antes de los comandos que lo usan
+		if readme, ok := readmes[item.Kind]; ok && (i+1 == len(plan.Items) || plan.Items[i+1].Kind != item.Kind) {
+			if err := readme(); err != nil {
+				g.logger.Warn("Error regenerando el README de %s: %v", item.Kind, err)
+			}
+		}
+	}
+
+	for _, entry := range plan.Obsolete {
+		g.pruneObsolete(entry, opts.Prune)
+		changed[entry.Kind] = true
+	}
+
+	if len(changed) > 0 {
+		if err := g.GenerateDevelopmentGuideWithContext(plan.agents, plan.commands, plan.skills); err != nil {
+			g.logger.Warn("Error regenerando development_guide.md: %v", err)
+		}
+	}
+
+	// Las herramientas del proyecto pueden haber cambiado: fusionar las reglas nuevas
+	if err := g.GenerateSettings(); err != nil {
+		g.logger.Warn("Error actualizando settings.json: %v", err)
+	}
+
+	results := g.update.results
+	if g.manifest != nil {
+		if err := g.SaveManifest(); err != nil {
+			return results, fmt.Errorf("failed to save %s: %w", ManifestFile, err)
+		}
+	}
+	return results, nil
+}
+
+// pruneObsolete elimina un archivo que ya no se recomienda si prune está activo y el
+// usuario no lo ha editado; si no, lo conserva.
+func (g *Generator) pruneObsolete(entry ManifestEntry, prune bool) {
+	outputPath := filepath.Join(g.outputDir, filepath.FromSlash(entry.Path))
+	content, err := os.ReadFile(outputPath)
+	switch {
+	case err != nil:
+		g.loadManifest().Remove(entry.Path)
+		g.update.report(entry.Path, UpdateRemoved, "no longer recommended, already deleted")
+		return
+	case !prune:
+		g.update.report(entry.Path, UpdateObsolete, "no longer recommended (use --prune to remove it)")
+		return
+	case HashContent(content) != entry.ContentHash:
+		g.update.report(entry.Path, UpdateObsolete, "no longer recommended, kept because it was edited")
+		return
+	}
+
+	if err := os.Remove(outputPath); err != nil {
+		g.update.report(entry.Path, UpdateObsolete, fmt.Sprintf("no longer recommended, could not remove it: %v", err))
+		return
+	}
+	_ = os.Remove(BasePath(g.outputDir, entry.Path))
+	if entry.Kind == ArtifactSkill || entry.Kind == ArtifactSkillFile {
+		// El directorio de la skill se elimina con su último archivo
+		removeEmptyDirs(filepath.Dir(outputPath), filepath.Join(g.outputDir, ".claude", "skills"))
+	}
+	g.loadManifest().Remove(entry.Path)
+	g.update.report(entry.Path, UpdateRemoved, "no longer recommended")
+}
+
+// removeEmptyDirs elimina dir y sus padres mientras estén vacíos, sin llegar a root.
+func removeEmptyDirs(dir, root string) {
+	for dir != root && strings.HasPrefix(dir, root+string(filepath.Separator)) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// resolve decide el contenido a escribir en un archivo regenerado. Retorna false si el
+// archivo no se debe escribir.
+func (u *updateRun) resolve(path, generated string) (string, bool) {
+	entry, tracked := u.previous.Entry(path)
+	current, err := os.ReadFile(filepath.Join(u.outputDir, filepath.FromSlash(path)))
+	switch {
+	case err != nil && tracked:
+		u.report(path, UpdateSkipped, "deleted by the user")
+		return "", false
+	case err != nil:
+		u.report(path, UpdateCreated, "")
+		return generated, true
+	case !tracked:
+		u.report(path, UpdateKept, "not generated by claude-init")
+		return "", false
+	case HashContent(current) == entry.ContentHash:
+		if string(current) == generated {
+			u.report(path, UpdateUnchanged, "")
+		} else {
+			u.report(path, UpdateUpdated, "")
+		}
+		return generated, true
+	case !u.merge:
+		u.report(path, UpdateKept, "edited by the user")
+		return "", false
+	}
+
+	base, err := os.ReadFile(BasePath(u.outputDir, path))
+	if err != nil {
+		u.report(path, UpdateKept, "edited by the user, no base version to merge with")
+		return "", false
+	}
+	result := merge.ThreeWay(string(base), string(current), generated)
+	switch {
+	case result.Conflicts > 0:
+		u.report(path, UpdateConflict, fmt.Sprintf("%d conflicting block(s) marked in the file", result.Conflicts))
+	case result.Content == string(current):
+		u.report(path, UpdateUnchanged, "edits preserved")
+	default:
+		u.report(path, UpdateMerged, "edits preserved")
+	}
+	return result.Content, true
+}
+
+// report registra lo que se ha hecho con un archivo.
+func (u *updateRun) report(path, outcome, detail string) {
+	u.results = append(u.results, UpdateResult{Path: path, Outcome: outcome, Detail: detail})
+}
+
+// artifactPath retorna la ruta, relativa al proyecto, de un archivo generado.
+func artifactPath(kind, name string) string {
+	switch kind {
+	case ArtifactAgent:
+		return ".claude/agents/" + sanitizeFilename(name) + ".md"
+	case ArtifactSkill:
+		return ".claude/skills/" + sanitizeFilename(name) + "/" + SkillFile
+	case ArtifactCommand:
+		return ".claude/commands/" + sanitizeFilename(name) + ".md"
+	case ArtifactClaudeMD:
+		if name == "CLAUDE" {
+			return "CLAUDE.md"
+		}
+		return filepath.ToSlash(filepath.Join(name, "CLAUDE.md"))
+	}
+	return name
+}
@@ -0,0 +1,137 @@
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/config"
+	"github.com/drossan/claude-init/internal/survey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerator_Update verifica que update regenera solo lo que ha cambiado, fusiona las
+// ediciones a mano, respeta los archivos eliminados y poda los que ya no se recomiendan.
+func TestGenerator_Update(t *testing.T) {
+	dir := t.TempDir()
+	templatePath := filepath.Join(dir, "tpl", "agents", "reviewer.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(templatePath), 0755))
+	require.NoError(t, os.WriteFile(templatePath, []byte("---\nname: reviewer\n---\n# Reviewer\n\n## Checklist\n- Tests pass\n"), 0644))
+
+	base := GetBaseItems()
+	settings := &config.ProjectSettings{
+		Templates: []string{"tpl"},
+		Agents:    config.ItemSelection{Exclude: base.Agents},
+		Commands:  config.ItemSelection{Exclude: base.Commands},
+		Skills:    config.ItemSelection{Exclude: base.Skills},
+	}
+	newGenerator := func() *Generator {
+		client := &responseClient{response: "---\nname: generated\ndescription: Generated\n---\n# Generated\n"}
+		g := NewGenerator(dir, &survey.Answers{ProjectName: "acme", Language: "Go"}, client)
+		g.SetProjectSettings(settings)
+		return g
+	}
+	read := func(path string) string {
+		content, err := os.ReadFile(filepath.Join(dir, path))
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	require.NoError(t, newGenerator().GenerateAll(&Recommendation{Agents: []string{"reviewer"}, Commands: []string{"test"}}))
+	assert.Equal(t, read(".claude/agents/reviewer.md"), read(".claude/"+BaseDir+"/.claude/agents/reviewer.md"))
+
+	// Sin cambios no hay nada que hacer
+	plan, err := newGenerator().PlanUpdate(&Recommendation{Agents: []string{"reviewer"}, Commands: []string{"test"}})
+	require.NoError(t, err)
+	assert.True(t, plan.Empty())
+
+	// El usuario edita el agente y borra el comando; el template y las dependencias cambian
+	edited := read(".claude/agents/reviewer.md") + "\n## Team notes\nPing @ops before deploys.\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".claude", "agents", "reviewer.md"), []byte(edited), 0644))
+	require.NoError(t, os.Remove(filepath.Join(dir, ".claude", "commands", "test.md")))
+	require.NoError(t, os.WriteFile(templatePath, []byte("---\nname: reviewer\n---\n# Code Reviewer\n\n## Checklist\n- Tests pass\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/acme\n"), 0644))
+
+	g := newGenerator()
+	rec := &Recommendation{Agents: []string{"reviewer", "security"}, Commands: []string{"test"}}
+	plan, err = g.PlanUpdate(rec)
+	require.NoError(t, err)
+	reasons := make(map[string][]string)
+	for _, item := range plan.Items {
+		reasons[item.Path] = item.Reasons
+	}
+	assert.Equal(t, []string{ReasonDependencies, ReasonTemplate}, reasons[".claude/agents/reviewer.md"])
+	assert.Equal(t, []string{ReasonNew}, reasons[".claude/agents/security.md"])
+	assert.Equal(t, []string{ReasonDependencies}, reasons["CLAUDE.md"])
+
+	results, err := g.Update(plan, UpdateOptions{Merge: true})
+	require.NoError(t, err)
+	outcomes := make(map[string]string)
+	for _, result := range results {
+		outcomes[result.Path] = result.Outcome
+	}
+	assert.Equal(t, UpdateMerged, outcomes[".claude/agents/reviewer.md"])
+	assert.Equal(t, UpdateCreated, outcomes[".claude/agents/security.md"])
+	assert.Equal(t, UpdateSkipped, outcomes[".claude/commands/test.md"])
+	assert.Equal(t, UpdateUnchanged, outcomes["CLAUDE.md"])
+
+	reviewer := read(".claude/agents/reviewer.md")
+	assert.Contains(t, reviewer, "# Code Reviewer")
+	assert.Contains(t, reviewer, "Ping @ops before deploys.")
+	assert.NoFileExists(t, filepath.Join(dir, ".claude", "commands", "test.md"))
+
+	// Ya no se recomiendan: el agente editado se conserva y el otro se elimina
+	g = newGenerator()
+	plan, err = g.PlanUpdate(&Recommendation{Commands: []string{"test"}})
+	require.NoError(t, err)
+	require.Len(t, plan.Obsolete, 2)
+	results, err = g.Update(plan, UpdateOptions{Merge: true, Prune: true})
+	require.NoError(t, err)
+	outcomes = make(map[string]string)
+	for _, result := range results {
+		outcomes[result.Path] = result.Outcome
+	}
+	assert.Equal(t, UpdateObsolete, outcomes[".claude/agents/reviewer.md"])
+	assert.Equal(t, UpdateRemoved, outcomes[".claude/agents/security.md"])
+	assert.FileExists(t, filepath.Join(dir, ".claude", "agents", "reviewer.md"))
+	assert.NoFileExists(t, filepath.Join(dir, ".claude", "agents", "security.md"))
+
+	manifest, err := LoadManifest(dir)
+	require.NoError(t, err)
+	_, ok := manifest.Entry(".claude/agents/security.md")
+	assert.False(t, ok)
+}
+
+// TestGenerator_PlanUpdate_TemplateMoved verifica que el template se resuelve en el
+// directorio de templates actual, de modo que moverlo no desactualiza lo generado.
+func TestGenerator_PlanUpdate_TemplateMoved(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "tpl", "agents"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tpl", "agents", "reviewer.md"), []byte("---\nname: reviewer\n---\n# Reviewer\n"), 0644))
+
+	base := GetBaseItems()
+	newGenerator := func(templates string) *Generator {
+		g := NewGenerator(dir, &survey.Answers{ProjectName: "acme", Language: "Go"}, &responseClient{})
+		g.SetProjectSettings(&config.ProjectSettings{
+			Templates: []string{templates},
+			Agents:    config.ItemSelection{Exclude: base.Agents},
+			Commands:  config.ItemSelection{Exclude: base.Commands},
+			Skills:    config.ItemSelection{Exclude: base.Skills},
+		})
+		return g
+	}
+	rec := &Recommendation{Agents: []string{"reviewer"}}
+	require.NoError(t, newGenerator("tpl").GenerateAll(rec))
+
+	require.NoError(t, os.Rename(filepath.Join(dir, "tpl"), filepath.Join(dir, "templates")))
+	plan, err := newGenerator("templates").PlanUpdate(rec)
+	require.NoError(t, err)
+	assert.True(t, plan.Empty(), plan.Items)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "templates", "agents", "reviewer.md"), []byte("---\nname: reviewer\n---\n# Code Reviewer\n"), 0644))
+	plan, err = newGenerator("templates").PlanUpdate(rec)
+	require.NoError(t, err)
+	require.Len(t, plan.Items, 1)
+	assert.Equal(t, []string{ReasonTemplate}, plan.Items[0].Reasons)
+}
@@ -0,0 +1,137 @@
+package claude
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/drossan/claude-init/internal/ai"
+	"github.com/drossan/claude-init/internal/survey"
+)
+
+// maxFollowUpQuestions limita las preguntas de seguimiento que se hacen al usuario.
+const maxFollowUpQuestions = 5
+
+// validationResponse es la respuesta JSON esperada del provider al validar las respuestas.
+type validationResponse struct {
+	IsValid     bool     `json:"is_valid"`
+	MissingInfo []string `json:"missing_info"`
+	Suggestions []string `json:"suggestions"`
+	Questions   []string `json:"questions"`
+}
+
+// Validator valida las respuestas del survey usando un Client de IA.
+//
+// La IA no infiere el proyecto: revisa la información proporcionada por el usuario,
+// detecta huecos y propone preguntas de seguimiento.
+type Validator struct {
+	client ai.Client
+	logger Logger
+}
+
+// NewValidator crea un nuevo Validator.
+func NewValidator(client ai.Client) *Validator {
+	return &Validator{
+		client: client,
+	}
+}
+
+// SetLogger establece el logger.
+func (v *Validator) SetLogger(logger Logger) {
+	v.logger = logger
+}
+
+// Validate envía las respuestas al provider y retorna sus sugerencias y preguntas de seguimiento.
+func (v *Validator) Validate(answers *survey.Answers) (*ai.ValidationResult, error) {
+	output, err := v.client.SendMessage(v.buildSystemPrompt(), v.buildValidationPrompt(answers))
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	v.logDebug("Validation response (raw): %s", truncateString(output, 500))
+
+	return v.parseValidation(output)
+}
+
+// buildSystemPrompt construye el system prompt para la validación.
+func (v *Validator) buildSystemPrompt() string {
+	return `You are an expert software architect reviewing the information a developer provided about their project.
+
+Do not guess or invent project details. Only point out missing or ambiguous information that would improve
+the generated Claude Code configuration (agents, skills and commands).`
+}
+
+// buildValidationPrompt construye el prompt con las respuestas del survey.
+func (v *Validator) buildValidationPrompt(answers *survey.Answers) string {
+	return fmt.Sprintf(`Review the following project information:
+
+Project Name: %s
+Description: %s
+Language: %s
+Framework: %s
+Architecture: %s
+Database: %s
+Project Category: %s
+Business Context: %s
+
+Respond with a JSON object using this exact structure:
+{
+  "is_valid": true,
+  "missing_info": ["information that is missing or ambiguous"],
+  "suggestions": ["short suggestions to improve the answers"],
+  "questions": ["follow-up questions to ask the user (at most %d)"]
+}
+
+Use empty arrays when there is nothing to report.
+CRITICAL: Respond with ONLY the raw JSON object. Do not include markdown code blocks, explanations, or any additional text.`,
+		answers.ProjectName,
+		answers.Description,
+		answers.Language,
+		answers.Framework,
+		answers.Architecture,
+		answers.Database,
+		answers.ProjectCategory,
+		answers.BusinessContext,
+		maxFollowUpQuestions,
+	)
+}
+
+// parseValidation extrae el ValidationResult de la respuesta del provider.
+func (v *Validator) parseValidation(output string) (*ai.ValidationResult, error) {
+	output = strings.TrimSpace(output)
+	start := strings.Index(output, "{")
+	end := strings.LastIndex(output, "}")
+	if start == -1 || end <= start {
+		return nil, fmt.Errorf("no JSON found in validation response")
+	}
+
+	var resp validationResponse
+	if err := json.Unmarshal([]byte(output[start:end+1]), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse validation JSON: %w", err)
+	}
+
+	questions := cleanList(resp.Questions)
+	if len(questions) > maxFollowUpQuestions {
+		questions = questions[:maxFollowUpQuestions]
+	}
+
+	return ai.NewValidationResult(resp.IsValid, cleanList(resp.MissingInfo), cleanList(resp.Suggestions), questions), nil
+}
+
+// cleanList elimina los elementos vacíos de una lista.
+func cleanList(items []string) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// logDebug logs debug messages if logger is set.
+func (v *Validator) logDebug(format string, args ...interface{}) {
+	if v.logger != nil {
+		v.logger.Debug(format, args...)
+	}
+}
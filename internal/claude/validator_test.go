@@ -0,0 +1,82 @@
+package claude
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/ai"
+	"github.com/drossan/claude-init/internal/survey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// responseClient es un ai.Client que retorna una respuesta fija y guarda el último prompt.
+type responseClient struct {
+	response   string
+	err        error
+	lastPrompt string
+	model      string
+}
+
+func (c *responseClient) SendMessage(systemPrompt, userMessage string) (string, error) {
+	c.lastPrompt = userMessage
+	return c.response, c.err
+}
+
+func (c *responseClient) Model() string {
+	return c.model
+}
+
+func (c *responseClient) SendSimpleMessage(message string) (string, error) {
+	return c.SendMessage("", message)
+}
+
+func (c *responseClient) Provider() ai.Provider {
+	return "mock"
+}
+
+func (c *responseClient) IsAvailable() (bool, error) {
+	return true, nil
+}
+
+func (c *responseClient) Close() error {
+	return nil
+}
+
+// TestValidator_Validate_ParsesResult verifica que se parsea la respuesta de validación.
+func TestValidator_Validate_ParsesResult(t *testing.T) {
+	client := &responseClient{response: "Here you go:\n" + `{
+		"is_valid": false,
+		"missing_info": ["authentication method", ""],
+		"suggestions": ["Describe the deployment target"],
+		"questions": ["Which auth provider do you use?", "q2", "q3", "q4", "q5", "q6"]
+	}`}
+
+	v := NewValidator(client)
+	result, err := v.Validate(&survey.Answers{ProjectName: "billing-api", Language: "Go"})
+
+	require.NoError(t, err)
+	assert.False(t, result.IsValid)
+	assert.Equal(t, []string{"authentication method"}, result.MissingInfo)
+	assert.Equal(t, []string{"Describe the deployment target"}, result.Suggestions)
+	assert.Len(t, result.Questions, maxFollowUpQuestions)
+	assert.Equal(t, "Which auth provider do you use?", result.Questions[0])
+	assert.Contains(t, client.lastPrompt, "billing-api")
+}
+
+// TestValidator_Validate_NoJSON_ReturnsError verifica el error cuando no hay JSON.
+func TestValidator_Validate_NoJSON_ReturnsError(t *testing.T) {
+	v := NewValidator(&responseClient{response: "looks good"})
+
+	_, err := v.Validate(&survey.Answers{})
+	assert.Error(t, err)
+}
+
+// TestValidator_Validate_ClientError_ReturnsError verifica que se propaga el error del cliente.
+func TestValidator_Validate_ClientError_ReturnsError(t *testing.T) {
+	v := NewValidator(&responseClient{err: fmt.Errorf("timeout")})
+
+	_, err := v.Validate(&survey.Answers{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timeout")
+}
@@ -62,6 +62,16 @@ func GetConfigPath() (string, error) {
 	return configPathFunc()
 }
 
+// GetQuestionsPath retorna el path del archivo de preguntas del usuario o equipo
+// (questions.yaml junto a config.yaml).
+func GetQuestionsPath() (string, error) {
+	configPath, err := configPathFunc()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), "questions.yaml"), nil
+}
+
 // Load carga la configuración global desde disco.
 func Load() (*GlobalConfig, error) {
 	configPath, err := GetConfigPath()
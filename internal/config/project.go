@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectSettingsFile es el nombre del archivo de configuración versionado en la raíz del proyecto.
+const ProjectSettingsFile = ".claude-init.yaml"
+
+// ProjectSettings representa la configuración de claude-init compartida por el equipo.
+//
+// Se guarda en .claude-init.yaml en la raíz del proyecto y se combina sobre la
+// configuración global del usuario, de modo que todo el equipo regenere la misma estructura.
+type ProjectSettings struct {
+	Provider  string        `yaml:"provider,omitempty"`  // Provider de IA fijado para el proyecto
+	Model     string        `yaml:"model,omitempty"`     // Modelo fijado para el provider (requiere provider)
+	Language  string        `yaml:"language,omitempty"`  // Idioma de salida del contenido generado
+	Templates []string      `yaml:"templates,omitempty"` // Directorios de templates (relativos al proyecto)
+	Questions string        `yaml:"questions,omitempty"` // Archivo de preguntas del survey (relativo al proyecto)
+	Agents    ItemSelection `yaml:"agents,omitempty"`
+	Skills    ItemSelection `yaml:"skills,omitempty"`
+	Commands  ItemSelection `yaml:"commands,omitempty"`
+}
+
+// ItemSelection define qué items incluir o excluir siempre de la generación.
+type ItemSelection struct {
+	Include []string `yaml:"include,omitempty"`
+	Exclude []string `yaml:"exclude,omitempty"`
+}
+
+// LoadProjectSettings carga .claude-init.yaml desde la raíz del proyecto.
+// Si el archivo no existe, retorna una configuración vacía. Un model sin provider es un
+// error: los modelos son de un provider concreto y el que se use puede elegirse después.
+func LoadProjectSettings(projectPath string) (*ProjectSettings, error) {
+	settingsPath := filepath.Join(projectPath, ProjectSettingsFile)
+
+	data, err := os.ReadFile(settingsPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &ProjectSettings{}, nil
+		}
+		return nil, fmt.Errorf("error reading %s: %w", ProjectSettingsFile, err)
+	}
+
+	var settings ProjectSettings
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", ProjectSettingsFile, err)
+	}
+	if settings.Model != "" && settings.Provider == "" {
+		return nil, fmt.Errorf("invalid %s: model %q requires provider", ProjectSettingsFile, settings.Model)
+	}
+
+	return &settings, nil
+}
+
+// TemplatePaths retorna los directorios de templates resueltos respecto al proyecto.
+func (s *ProjectSettings) TemplatePaths(projectPath string) []string {
+	paths := make([]string, 0, len(s.Templates))
+	for _, tpl := range s.Templates {
+		tpl = strings.TrimSpace(tpl)
+		if tpl == "" {
+			continue
+		}
+		if !filepath.IsAbs(tpl) {
+			tpl = filepath.Join(projectPath, tpl)
+		}
+		paths = append(paths, tpl)
+	}
+	return paths
+}
+
+// QuestionsPath retorna el archivo de preguntas del proyecto resuelto respecto al proyecto,
+// o un string vacío si no está configurado.
+func (s *ProjectSettings) QuestionsPath(projectPath string) string {
+	path := strings.TrimSpace(s.Questions)
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(projectPath, path)
+}
+
+// Apply añade los items incluidos y elimina los excluidos de la lista dada.
+// Las exclusiones tienen prioridad sobre las inclusiones.
+func (sel ItemSelection) Apply(items []string) []string {
+	excluded := make(map[string]bool, len(sel.Exclude))
+	for _, item := range sel.Exclude {
+		excluded[item] = true
+	}
+
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(items)+len(sel.Include))
+	for _, item := range append(append([]string{}, items...), sel.Include...) {
+		if item == "" || excluded[item] || seen[item] {
+			continue
+		}
+		seen[item] = true
+		result = append(result, item)
+	}
+
+	return result
+}
+
+// Merge retorna una copia de la configuración global con la configuración del proyecto aplicada encima.
+// El provider y el modelo fijados en el proyecto sobrescriben los del usuario; las API keys
+// siempre provienen de la configuración global.
+func (c *GlobalConfig) Merge(settings *ProjectSettings) *GlobalConfig {
+	merged := &GlobalConfig{
+		Provider:  c.Provider,
+		Providers: make(map[string]ProviderConfig, len(c.Providers)),
+	}
+	for name, providerCfg := range c.Providers {
+		merged.Providers[name] = providerCfg
+	}
+
+	if settings == nil {
+		return merged
+	}
+
+	if settings.Provider != "" {
+		merged.Provider = settings.Provider
+	}
+
+	// El modelo solo se aplica al provider fijado: en otro provider no tendría sentido
+	if settings.Model != "" && settings.Provider != "" {
+		providerCfg := merged.Providers[settings.Provider]
+		providerCfg.Model = settings.Model
+		merged.Providers[settings.Provider] = providerCfg
+	}
+
+	return merged
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadProjectSettings(t *testing.T) {
+	t.Run("missing file returns empty settings", func(t *testing.T) {
+		settings, err := LoadProjectSettings(t.TempDir())
+		require.NoError(t, err)
+		assert.Equal(t, &ProjectSettings{}, settings)
+	})
+
+	t.Run("valid file", func(t *testing.T) {
+		dir := t.TempDir()
+		content := `provider: openai
+model: gpt-4o
+language: English
+templates:
+  - ./templates
+agents:
+  include: [security-auditor]
+  exclude: [debugger]
+skills:
+  exclude: [technical-writer]
+`
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ProjectSettingsFile), []byte(content), 0644))
+
+		settings, err := LoadProjectSettings(dir)
+		require.NoError(t, err)
+		assert.Equal(t, "openai", settings.Provider)
+		assert.Equal(t, "gpt-4o", settings.Model)
+		assert.Equal(t, "English", settings.Language)
+		assert.Equal(t, []string{"./templates"}, settings.Templates)
+		assert.Equal(t, []string{"security-auditor"}, settings.Agents.Include)
+		assert.Equal(t, []string{"debugger"}, settings.Agents.Exclude)
+		assert.Equal(t, []string{"technical-writer"}, settings.Skills.Exclude)
+	})
+
+	t.Run("invalid yaml returns error", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ProjectSettingsFile), []byte("provider: [unclosed"), 0644))
+
+		_, err := LoadProjectSettings(dir)
+		assert.Error(t, err)
+	})
+
+	t.Run("model without provider returns error", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ProjectSettingsFile), []byte("model: gpt-4o\n"), 0644))
+
+		_, err := LoadProjectSettings(dir)
+		assert.ErrorContains(t, err, "requires provider")
+	})
+}
+
+func TestItemSelection_Apply(t *testing.T) {
+	sel := ItemSelection{
+		Include: []string{"security-auditor", "developer"},
+		Exclude: []string{"debugger", "security-auditor"},
+	}
+
+	result := sel.Apply([]string{"architect", "developer", "debugger"})
+	assert.Equal(t, []string{"architect", "developer"}, result)
+
+	assert.Equal(t, []string{"a", "b"}, ItemSelection{}.Apply([]string{"a", "b"}))
+}
+
+func TestProjectSettings_TemplatePaths(t *testing.T) {
+	abs := filepath.Join(t.TempDir(), "shared")
+	settings := &ProjectSettings{Templates: []string{"./templates", "", abs}}
+
+	paths := settings.TemplatePaths("/project")
+	assert.Equal(t, []string{filepath.Join("/project", "templates"), abs}, paths)
+}
+
+func TestGlobalConfig_Merge(t *testing.T) {
+	global := &GlobalConfig{
+		Provider: "cli",
+		Providers: map[string]ProviderConfig{
+			"openai": {APIKey: "sk-test", Model: "gpt-4o-mini"},
+		},
+	}
+
+	t.Run("project overrides provider and model", func(t *testing.T) {
+		merged := global.Merge(&ProjectSettings{Provider: "openai", Model: "gpt-4o"})
+		assert.Equal(t, "openai", merged.Provider)
+		assert.Equal(t, "gpt-4o", merged.Providers["openai"].Model)
+		assert.Equal(t, "sk-test", merged.Providers["openai"].APIKey)
+
+		// La configuración original no se modifica
+		assert.Equal(t, "cli", global.Provider)
+		assert.Equal(t, "gpt-4o-mini", global.Providers["openai"].Model)
+	})
+
+	t.Run("model without provider is not applied", func(t *testing.T) {
+		merged := global.Merge(&ProjectSettings{Model: "gpt-4o"})
+		assert.Equal(t, "gpt-4o-mini", merged.Providers["openai"].Model)
+		assert.NotContains(t, merged.Providers, "cli")
+	})
+
+	t.Run("nil settings keeps global values", func(t *testing.T) {
+		merged := global.Merge(nil)
+		assert.Equal(t, "cli", merged.Provider)
+		assert.Equal(t, "gpt-4o-mini", merged.Providers["openai"].Model)
+	})
+}
@@ -0,0 +1,108 @@
+package detector
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// extensionLanguages mapea extensiones de archivo a lenguajes (valores del catálogo del survey).
+var extensionLanguages = map[string]string{
+	".go":    "Go",
+	".ts":    "TypeScript",
+	".tsx":   "TypeScript",
+	".js":    "JavaScript",
+	".jsx":   "JavaScript",
+	".mjs":   "JavaScript",
+	".cjs":   "JavaScript",
+	".py":    "Python",
+	".java":  "Java",
+	".kt":    "Kotlin",
+	".kts":   "Kotlin",
+	".cs":    "C#",
+	".php":   "PHP",
+	".rb":    "Ruby",
+	".rs":    "Rust",
+	".ex":    "Elixir",
+	".exs":   "Elixir",
+	".swift": "Swift",
+	".dart":  "Dart",
+	".cpp":   "C++",
+	".cc":    "C++",
+	".hpp":   "C++",
+}
+
+// languageCensus cuenta archivos fuente por lenguaje y recuerda los nombres de archivo de test.
+type languageCensus struct {
+	counts    map[string]int
+	testFiles map[string]int // lenguaje -> número de archivos de test
+}
+
+// censusLanguages recorre el proyecto contando archivos fuente por extensión.
+func (d *Detector) censusLanguages() *languageCensus {
+	census := &languageCensus{
+		counts:    make(map[string]int),
+		testFiles: make(map[string]int),
+	}
+
+	_ = d.scanner.Walk(func(rel string, entry fs.DirEntry) error {
+		if entry.IsDir() {
+			return nil
+		}
+
+		language, ok := extensionLanguages[strings.ToLower(filepath.Ext(entry.Name()))]
+		if !ok {
+			return nil
+		}
+		census.counts[language]++
+		if isTestFile(entry.Name()) {
+			census.testFiles[language]++
+		}
+		return nil
+	})
+
+	return census
+}
+
+// shares retorna el censo ordenado de mayor a menor número de archivos.
+func (c *languageCensus) shares() []LanguageShare {
+	shares := make([]LanguageShare, 0, len(c.counts))
+	for language, files := range c.counts {
+		shares = append(shares, LanguageShare{Language: language, Files: files})
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].Files != shares[j].Files {
+			return shares[i].Files > shares[j].Files
+		}
+		return shares[i].Language < shares[j].Language
+	})
+	return shares
+}
+
+// isTestFile indica si el nombre de archivo sigue una convención de test conocida.
+func isTestFile(name string) bool {
+	lower := strings.ToLower(name)
+	base := strings.TrimSuffix(lower, filepath.Ext(lower))
+
+	return strings.HasSuffix(base, "_test") ||
+		strings.HasPrefix(base, "test_") ||
+		strings.HasSuffix(base, ".test") ||
+		strings.HasSuffix(base, ".spec") ||
+		strings.HasSuffix(base, "test") && (strings.HasSuffix(lower, ".java") || strings.HasSuffix(lower, ".cs") || strings.HasSuffix(lower, ".kt"))
+}
+
+// detectTestFiles deduce frameworks de testing integrados en el lenguaje a partir de los archivos de test.
+func (d *Detector) detectTestFiles(census *languageCensus) []string {
+	var frameworks []string
+	if census.testFiles["Go"] > 0 {
+		frameworks = append(frameworks, "go test")
+	}
+	if census.testFiles["Rust"] > 0 || d.exists("tests") && census.counts["Rust"] > 0 {
+		frameworks = append(frameworks, "cargo test")
+	}
+	if d.anyExists("pytest.ini", "conftest.py") {
+		frameworks = append(frameworks, "pytest")
+	}
+	return frameworks
+}
@@ -0,0 +1,359 @@
+// Package detector infiere información de un proyecto existente de forma determinista,
+// sin usar ningún provider de IA: lenguaje (por censo de extensiones), frameworks, bases
+// de datos, frameworks de testing, sistema de CI, comandos de build, test y lint,
+// contenedores, infraestructura, pistas de arquitectura y hábitos del equipo según el
+// historial local de git.
+package detector
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/drossan/claude-init/internal/scanner"
+)
+
+// Result contiene la información detectada del proyecto.
+type Result struct {
+	Name              string              `json:"name,omitempty" yaml:"name,omitempty"`
+	Description       string              `json:"description,omitempty" yaml:"description,omitempty"`
+	Language          string              `json:"language,omitempty" yaml:"language,omitempty"`
+	Languages         []LanguageShare     `json:"languages,omitempty" yaml:"languages,omitempty"`
+	Frameworks        []string            `json:"frameworks,omitempty" yaml:"frameworks,omitempty"`
+	Databases         []string            `json:"databases,omitempty" yaml:"databases,omitempty"`
+	TestFrameworks    []string            `json:"test_frameworks,omitempty" yaml:"test_frameworks,omitempty"`
+	CI                []string            `json:"ci,omitempty" yaml:"ci,omitempty"`
+	Architecture      string              `json:"architecture,omitempty" yaml:"architecture,omitempty"`
+	ArchitectureHints []string            `json:"architecture_hints,omitempty" yaml:"architecture_hints,omitempty"`
+	ProjectCategory   string              `json:"project_category,omitempty" yaml:"project_category,omitempty"`
+	Dependencies      map[string][]string `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`   // Ecosistema -> módulos declarados en los manifiestos
+	Skills            []string            `json:"skills,omitempty" yaml:"skills,omitempty"`               // Skills recomendadas por las librerías detectadas
+	Tooling           *Tooling            `json:"tooling,omitempty" yaml:"tooling,omitempty"`             // nil si no hay comandos ni infraestructura
+	Documentation     []string            `json:"documentation,omitempty" yaml:"documentation,omitempty"` // Directorios ("docs/") y documentos de la raíz
+	Workspace         *Workspace          `json:"workspace,omitempty" yaml:"workspace,omitempty"`         // nil si no es un monorepo
+	History           *History            `json:"history,omitempty" yaml:"history,omitempty"`             // nil si no hay historial de git
+}
+
+// LanguageShare es el número de archivos fuente de un lenguaje en el proyecto.
+type LanguageShare struct {
+	Language string `json:"language" yaml:"language"`
+	Files    int    `json:"files" yaml:"files"`
+}
+
+// Detector analiza un proyecto a partir de sus manifiestos y su estructura de archivos.
+type Detector struct {
+	projectPath string
+	scanner     *scanner.Scanner
+}
+
+// NewDetector crea un nuevo Detector para el proyecto indicado.
+func NewDetector(projectPath string) *Detector {
+	return NewDetectorWithOptions(projectPath, scanner.Options{})
+}
+
+// NewDetectorWithOptions crea un Detector cuyo recorrido del proyecto respeta los
+// globs de inclusión y exclusión indicados, además de los archivos de ignorados.
+func NewDetectorWithOptions(projectPath string, opts scanner.Options) *Detector {
+	return &Detector{
+		projectPath: projectPath,
+		scanner:     scanner.NewScanner(projectPath, opts),
+	}
+}
+
+// Detect ejecuta todas las heurísticas y retorna el resultado, incluyendo los paquetes
+// del workspace si el proyecto es un monorepo y los hábitos del historial de git.
+func (d *Detector) Detect() (*Result, error) {
+	result, err := d.detect()
+	if err != nil {
+		return nil, err
+	}
+
+	result.Workspace = d.detectWorkspace()
+	result.History = d.detectHistory()
+	return result, nil
+}
+
+// detect ejecuta las heurísticas del proyecto sin buscar paquetes de workspace.
+func (d *Detector) detect() (*Result, error) {
+	info, err := os.Stat(d.projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("project path %s is not a directory", d.projectPath)
+	}
+
+	census := d.censusLanguages()
+	manifests := d.readManifests()
+
+	result := &Result{
+		Name:        manifests.name,
+		Description: manifests.description,
+		Languages:   census.shares(),
+	}
+	if result.Name == "" {
+		result.Name = filepath.Base(filepath.Clean(d.projectPath))
+	}
+
+	result.Language = d.detectLanguage(result.Languages, manifests)
+	result.Frameworks = matchRules(frameworkRules, manifests.deps)
+	result.Databases = appendUnique(matchRules(databaseRules, manifests.deps), d.detectComposeDatabases()...)
+	result.TestFrameworks = appendUnique(matchRules(testRules, manifests.deps), d.detectTestFiles(census)...)
+	result.Dependencies = manifests.dependencies()
+	result.Skills = matchRules(skillRules, manifests.deps)
+	result.CI = d.detectCI()
+	result.Tooling = d.detectTooling(result.TestFrameworks)
+	if result.Tooling != nil {
+		result.Skills = appendUnique(result.Skills, result.Tooling.skills...)
+	}
+	result.ArchitectureHints, result.Architecture = d.detectArchitecture()
+	result.Documentation = d.detectDocumentation()
+	result.ProjectCategory = d.detectCategory(result, manifests)
+
+	return result, nil
+}
+
+// Summary formatea el resultado para incluirlo en los prompts de IA.
+func (r *Result) Summary() string {
+	var sb strings.Builder
+
+	writeField := func(label, value string) {
+		if value != "" {
+			sb.WriteString(fmt.Sprintf("- %s: %s\n", label, value))
+		}
+	}
+
+	languages := make([]string, 0, len(r.Languages))
+	for _, share := range r.Languages {
+		languages = append(languages, fmt.Sprintf("%s (%d files)", share.Language, share.Files))
+	}
+
+	writeField("Name", r.Name)
+	writeField("Description", r.Description)
+	writeField("Main language", r.Language)
+	writeField("Languages by file count", strings.Join(languages, ", "))
+	writeField("Frameworks", strings.Join(r.Frameworks, ", "))
+	writeField("Databases", strings.Join(r.Databases, ", "))
+	writeField("Testing frameworks", strings.Join(r.TestFrameworks, ", "))
+	writeField("CI", strings.Join(r.CI, ", "))
+	writeField("Architecture", r.Architecture)
+	writeField("Architecture hints", strings.Join(r.ArchitectureHints, "; "))
+	writeField("Project category", r.ProjectCategory)
+	if tooling := r.Tooling.Summary(); tooling != "" {
+		sb.WriteString(tooling + "\n")
+	}
+	if history := r.History.Summary(); history != "" {
+		sb.WriteString("- Git history:\n")
+		for _, line := range strings.Split(history, "\n") {
+			sb.WriteString("  " + line + "\n")
+		}
+	}
+
+	if r.Workspace != nil {
+		writeField("Monorepo tools", strings.Join(r.Workspace.Tools, ", "))
+		sb.WriteString("- Packages:\n")
+		for _, pkg := range r.Workspace.Packages {
+			sb.WriteString(fmt.Sprintf("  - %s\n", pkg.Summary()))
+		}
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// detectLanguage elige el lenguaje principal. El censo de archivos manda; si no hay
+// archivos fuente, se usa el ecosistema del primer manifiesto encontrado.
+func (d *Detector) detectLanguage(shares []LanguageShare, manifests *manifestInfo) string {
+	if len(shares) > 0 {
+		language := shares[0].Language
+		// Un proyecto TypeScript suele conservar archivos .js de configuración
+		if language == "JavaScript" && d.exists("tsconfig.json") && shareOf(shares, "TypeScript") > 0 {
+			return "TypeScript"
+		}
+		return language
+	}
+
+	for _, ecosystem := range manifests.ecosystems {
+		if language, ok := ecosystemLanguages[ecosystem]; ok {
+			return language
+		}
+	}
+
+	return ""
+}
+
+// detectCI detecta los sistemas de CI configurados en el repositorio.
+func (d *Detector) detectCI() []string {
+	var systems []string
+	for _, rule := range ciRules {
+		if d.exists(rule.path) {
+			systems = appendUnique(systems, rule.name)
+		}
+	}
+	return systems
+}
+
+// detectArchitecture busca patrones de directorios conocidos y retorna las pistas
+// encontradas junto con la arquitectura más probable.
+func (d *Detector) detectArchitecture() ([]string, string) {
+	dirs := d.layoutDirs()
+
+	var (
+		hints        []string
+		architecture string
+	)
+
+	for _, rule := range architectureRules {
+		matched := rule.files != nil && d.anyExists(rule.files...)
+		if !matched && rule.dirs != nil {
+			matched = true
+			for _, dir := range rule.dirs {
+				if !dirs[dir] {
+					matched = false
+					break
+				}
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		evidence := make([]string, 0, len(rule.dirs)+len(rule.files))
+		for _, dir := range rule.dirs {
+			evidence = append(evidence, dir+"/")
+		}
+		for _, file := range rule.files {
+			if d.exists(file) {
+				evidence = append(evidence, file)
+			}
+		}
+		hints = append(hints, fmt.Sprintf("%s: %s", rule.architecture, strings.Join(evidence, ", ")))
+		if architecture == "" {
+			architecture = rule.architecture
+		}
+	}
+
+	if architecture == "" && d.countServices() >= 2 {
+		architecture = "Microservicios"
+		hints = append(hints, "Microservicios: varios servicios con Dockerfile propio")
+	}
+
+	return hints, architecture
+}
+
+// detectCategory infiere la categoría del proyecto a partir de los frameworks y la estructura.
+func (d *Detector) detectCategory(result *Result, manifests *manifestInfo) string {
+	for _, rule := range categoryRules {
+		for _, framework := range result.Frameworks {
+			if contains(rule.frameworks, framework) {
+				return rule.category
+			}
+		}
+		if len(matchRules(rule.deps, manifests.deps)) > 0 {
+			return rule.category
+		}
+	}
+
+	if result.Language == "Go" && d.anyExists("cmd", "main.go") {
+		return "CLI"
+	}
+
+	return ""
+}
+
+// layoutDirs retorna los nombres de directorio presentes en los dos primeros niveles
+// del proyecto y de los directorios fuente habituales (src, internal, app, lib).
+func (d *Detector) layoutDirs() map[string]bool {
+	dirs := make(map[string]bool)
+	roots := []string{"", "src", "internal", "app", "lib", "pkg"}
+
+	for _, root := range roots {
+		entries, err := os.ReadDir(filepath.Join(d.projectPath, root))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() && !d.scanner.Ignored(path.Join(filepath.ToSlash(root), entry.Name()), true) {
+				dirs[strings.ToLower(entry.Name())] = true
+			}
+		}
+	}
+
+	return dirs
+}
+
+// countServices cuenta los subdirectorios de services/, apps/ o de la raíz que tienen Dockerfile propio.
+func (d *Detector) countServices() int {
+	count := 0
+	for _, root := range []string{"", "services", "apps"} {
+		entries, err := os.ReadDir(filepath.Join(d.projectPath, root))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() && !d.scanner.Ignored(path.Join(root, entry.Name()), true) &&
+				d.exists(filepath.Join(root, entry.Name(), "Dockerfile")) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// exists indica si existe una ruta relativa al proyecto.
+func (d *Detector) exists(rel string) bool {
+	_, err := os.Stat(filepath.Join(d.projectPath, rel))
+	return err == nil
+}
+
+// anyExists indica si existe alguna de las rutas relativas al proyecto.
+func (d *Detector) anyExists(rels ...string) bool {
+	for _, rel := range rels {
+		if d.exists(rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// shareOf retorna el número de archivos de un lenguaje en el censo.
+func shareOf(shares []LanguageShare, language string) int {
+	for _, share := range shares {
+		if share.Language == language {
+			return share.Files
+		}
+	}
+	return 0
+}
+
+// appendUnique añade los valores que no estén ya en la lista.
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		if value != "" && !contains(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// contains verifica si un slice contiene un string.
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys retorna las claves de un mapa ordenadas alfabéticamente.
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
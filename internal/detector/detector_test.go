@@ -0,0 +1,201 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFiles crea los archivos indicados (ruta relativa -> contenido) en dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		path := filepath.Join(dir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+// TestDetect_GoProject verifica la detección de un servicio Go con arquitectura hexagonal.
+func TestDetect_GoProject(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod": `module github.com/acme/orders
+
+go 1.22
+
+require (
+	github.com/gin-gonic/gin v1.9.1
+	github.com/jackc/pgx/v5 v5.5.0 // indirect
+	github.com/stretchr/testify v1.8.4
+	github.com/ginkgo-fake/notgin v1.0.0
+)
+`,
+		"cmd/server/main.go":                             "package main",
+		"internal/domain/order.go":                       "package domain",
+		"internal/domain/order_test.go":                  "package domain",
+		"internal/application/service.go":                "package application",
+		"internal/infrastructure/repo.go":                "package infrastructure",
+		"web/app.js":                                     "",
+		".github/workflows/ci.yml":                       "on: push",
+		"docker-compose.yml":                             "services:\n  cache:\n    image: redis:7\n  db:\n    image: postgres:16-alpine\n",
+		"node_modules/left-pad/index.js":                 "",
+		"node_modules/left-pad/lib/another/index.js":     "",
+		"node_modules/left-pad/lib/another/more/more.js": "",
+	})
+
+	result, err := NewDetector(dir).Detect()
+	require.NoError(t, err)
+
+	assert.Equal(t, "orders", result.Name)
+	assert.Equal(t, "Go", result.Language)
+	assert.Equal(t, []LanguageShare{{Language: "Go", Files: 5}, {Language: "JavaScript", Files: 1}}, result.Languages)
+	assert.Equal(t, []string{"Gin"}, result.Frameworks)
+	assert.Equal(t, []string{"PostgreSQL", "Redis"}, result.Databases)
+	assert.Equal(t, []string{"testify", "go test"}, result.TestFrameworks)
+	assert.Equal(t, []string{"GitHub Actions"}, result.CI)
+	assert.Equal(t, "Hexagonal", result.Architecture)
+	require.NotEmpty(t, result.ArchitectureHints)
+	assert.Contains(t, result.ArchitectureHints[0], "infrastructure/")
+	assert.Equal(t, "API REST", result.ProjectCategory)
+}
+
+// TestDetect_TypeScriptProject verifica la detección de una web app TypeScript.
+func TestDetect_TypeScriptProject(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"package.json": `{
+  "name": "storefront",
+  "description": "Tienda online",
+  "dependencies": {"next": "14.0.0", "react": "18.2.0", "react-dom": "18.2.0", "pg": "8.11.0"},
+  "devDependencies": {"vitest": "1.0.0", "@playwright/test": "1.40.0"}
+}`,
+		"tsconfig.json":         "{}",
+		"next.config.js":        "",
+		"postcss.config.js":     "",
+		"tailwind.config.js":    "",
+		"src/app/page.tsx":      "",
+		"src/app/page.test.tsx": "",
+		".gitlab-ci.yml":        "",
+	})
+
+	result, err := NewDetector(dir).Detect()
+	require.NoError(t, err)
+
+	assert.Equal(t, "storefront", result.Name)
+	assert.Equal(t, "Tienda online", result.Description)
+	assert.Equal(t, "TypeScript", result.Language)
+	assert.Equal(t, []string{"Next.js", "React"}, result.Frameworks)
+	assert.Equal(t, []string{"PostgreSQL"}, result.Databases)
+	assert.Equal(t, []string{"Vitest", "Playwright"}, result.TestFrameworks)
+	assert.Equal(t, []string{"GitLab CI"}, result.CI)
+	assert.Equal(t, "Web App", result.ProjectCategory)
+}
+
+// TestDetect_PythonProject verifica la detección desde pyproject.toml y requirements.txt.
+func TestDetect_PythonProject(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"pyproject.toml": `[project]
+name = "billing"
+description = "Facturación"
+dependencies = [
+    "fastapi[all]>=0.110",
+    "asyncpg",
+]
+
+[project.optional-dependencies]
+dev = ["pytest>=8"]
+`,
+		"requirements.txt":  "# runtime\nRedis==5.0.1\n-r base.txt\n",
+		"app/main.py":       "",
+		"tests/test_api.py": "",
+		"serverless.yml":    "",
+	})
+
+	result, err := NewDetector(dir).Detect()
+	require.NoError(t, err)
+
+	assert.Equal(t, "billing", result.Name)
+	assert.Equal(t, "Python", result.Language)
+	assert.Equal(t, []string{"FastAPI"}, result.Frameworks)
+	assert.Equal(t, []string{"PostgreSQL", "Redis"}, result.Databases)
+	assert.Equal(t, []string{"pytest"}, result.TestFrameworks)
+	assert.Equal(t, "Serverless", result.Architecture)
+	assert.Equal(t, "API REST", result.ProjectCategory)
+}
+
+// TestDetect_ManifestOnly verifica que sin archivos fuente se usa el ecosistema del manifiesto.
+func TestDetect_ManifestOnly(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"requirements.txt": "click\n",
+	})
+
+	result, err := NewDetector(dir).Detect()
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Base(dir), result.Name)
+	assert.Equal(t, "Python", result.Language)
+	assert.Empty(t, result.Frameworks)
+	assert.Equal(t, "CLI", result.ProjectCategory)
+}
+
+// TestDetect_IgnoredFiles verifica que el censo respeta .gitignore y los globs de exclusión.
+func TestDetect_IgnoredFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".gitignore":           "generated/\n",
+		"go.mod":               "module example.com/tool\n",
+		"main.go":              "package main",
+		"generated/a.py":       "",
+		"generated/b.py":       "",
+		"scripts/tool.py":      "",
+		"scripts/other.py":     "",
+		"node_modules/x/a.js":  "",
+		"node_modules/x/b.js":  "",
+		"node_modules/x/c.js":  "",
+		"internal/app/app.go":  "package app",
+		"internal/app/util.go": "package app",
+	})
+
+	result, err := NewDetectorWithOptions(dir, scanner.Options{Exclude: []string{"scripts/"}}).Detect()
+	require.NoError(t, err)
+
+	assert.Equal(t, []LanguageShare{{Language: "Go", Files: 3}}, result.Languages)
+}
+
+// TestDetect_InvalidPath verifica el error cuando el proyecto no existe.
+func TestDetect_InvalidPath(t *testing.T) {
+	_, err := NewDetector(filepath.Join(t.TempDir(), "missing")).Detect()
+	require.Error(t, err)
+}
+
+// TestDependencyRule_Matches verifica que las reglas no coinciden por substring.
+func TestDependencyRule_Matches(t *testing.T) {
+	rule := dependencyRule{EcosystemGo, "github.com/labstack/echo", "Echo"}
+
+	assert.True(t, rule.matches("github.com/labstack/echo"))
+	assert.True(t, rule.matches("github.com/labstack/echo/v4"))
+	assert.False(t, rule.matches("github.com/labstack/echo-contrib"))
+	assert.False(t, dependencyRule{EcosystemNPM, "react", "React"}.matches("preact"))
+}
+
+// TestResult_Summary verifica el formato del resumen para los prompts.
+func TestResult_Summary(t *testing.T) {
+	result := &Result{
+		Language:   "Go",
+		Languages:  []LanguageShare{{Language: "Go", Files: 12}},
+		Frameworks: []string{"Gin"},
+	}
+
+	summary := result.Summary()
+	assert.Contains(t, summary, "- Main language: Go")
+	assert.Contains(t, summary, "Go (12 files)")
+	assert.Contains(t, summary, "- Frameworks: Gin")
+	assert.NotContains(t, summary, "Databases")
+}
@@ -0,0 +1,40 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// documentationDirs son los directorios de documentación habituales.
+var documentationDirs = []string{"docs", "documentation", "guide", "guides", "wiki", "help"}
+
+// documentationFiles son los documentos habituales de la raíz del proyecto.
+var documentationFiles = []string{"README.md", "CONTRIBUTING.md", "ARCHITECTURE.md", "CHANGELOG.md", "SECURITY.md"}
+
+// DocumentationDirs retorna los directorios de documentación habituales presentes en el proyecto.
+func DocumentationDirs(projectPath string) []string {
+	found := make([]string, 0)
+	for _, dir := range documentationDirs {
+		if info, err := os.Stat(filepath.Join(projectPath, dir)); err == nil && info.IsDir() {
+			found = append(found, dir)
+		}
+	}
+	return found
+}
+
+// detectDocumentation retorna los directorios (terminados en "/") y los documentos
+// de la raíz que describen el proyecto.
+func (d *Detector) detectDocumentation() []string {
+	var docs []string
+	for _, dir := range DocumentationDirs(d.projectPath) {
+		if !d.scanner.Ignored(dir, true) {
+			docs = append(docs, dir+"/")
+		}
+	}
+	for _, file := range documentationFiles {
+		if d.exists(file) && !d.scanner.Ignored(file, false) {
+			docs = append(docs, file)
+		}
+	}
+	return docs
+}
@@ -0,0 +1,208 @@
+package detector
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"regexp"
+	"strings"
+)
+
+// normalizeCrateName normaliza un nombre de crate: crates.io trata "-" y "_" como equivalentes.
+func normalizeCrateName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "_", "-")
+}
+
+// cargoDependencySections son las tablas de dependencias de Cargo.toml.
+var cargoDependencySections = []string{"dependencies", "dev-dependencies", "build-dependencies"}
+
+// isCargoDependencySection indica si la sección declara dependencias, incluidas
+// las de workspace y las específicas de plataforma ([target.'cfg(unix)'.dependencies]).
+func isCargoDependencySection(section string) bool {
+	for _, name := range cargoDependencySections {
+		if section == name || section == "workspace."+name || strings.HasSuffix(section, "."+name) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCargoToml extrae nombre, descripción y dependencias de Cargo.toml, incluidas
+// las declaradas como tabla ([dependencies.serde]).
+func parseCargoToml(content []byte, info *manifestInfo) {
+	var name, description string
+
+	info.addEcosystem(EcosystemCargo)
+	tomlEntries(content, func(section, key, value string) {
+		switch {
+		case key == "":
+			for _, table := range cargoDependencySections {
+				if crate, ok := strings.CutPrefix(section, table+"."); ok {
+					info.addDep(EcosystemCargo, normalizeCrateName(strings.Trim(crate, `"'`)))
+				}
+			}
+		case section == "package" && key == "name":
+			name = strings.Trim(value, `"'`)
+		case section == "package" && key == "description":
+			description = strings.Trim(value, `"'`)
+		case isCargoDependencySection(section):
+			info.addDep(EcosystemCargo, normalizeCrateName(key))
+		}
+	})
+
+	info.setName(name, description)
+}
+
+// mavenArtifact es una dependencia, el parent o un plugin de un pom.xml.
+type mavenArtifact struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+}
+
+// coordinates retorna "groupId:artifactId", o "" si falta alguna parte.
+func (a mavenArtifact) coordinates() string {
+	if a.GroupID == "" || a.ArtifactID == "" {
+		return ""
+	}
+	return strings.TrimSpace(a.GroupID) + ":" + strings.TrimSpace(a.ArtifactID)
+}
+
+// parsePom extrae nombre, descripción, parent, dependencias y plugins de pom.xml.
+func parsePom(content []byte, info *manifestInfo) {
+	var pom struct {
+		ArtifactID   string          `xml:"artifactId"`
+		Name         string          `xml:"name"`
+		Description  string          `xml:"description"`
+		Parent       mavenArtifact   `xml:"parent"`
+		Dependencies []mavenArtifact `xml:"dependencies>dependency"`
+		Managed      []mavenArtifact `xml:"dependencyManagement>dependencies>dependency"`
+		Plugins      []mavenArtifact `xml:"build>plugins>plugin"`
+	}
+	if err := xml.Unmarshal(content, &pom); err != nil {
+		return
+	}
+
+	name := pom.Name
+	if name == "" || strings.Contains(name, "${") {
+		name = pom.ArtifactID
+	}
+	info.setName(name, pom.Description)
+	info.addEcosystem(EcosystemMaven)
+
+	artifacts := append([]mavenArtifact{pom.Parent}, pom.Dependencies...)
+	artifacts = append(artifacts, pom.Managed...)
+	artifacts = append(artifacts, pom.Plugins...)
+	for _, artifact := range artifacts {
+		info.addDep(EcosystemMaven, artifact.coordinates())
+	}
+}
+
+var (
+	// gradleDependency extrae "group:artifact" de las declaraciones de dependencias de Gradle
+	// (Groovy y Kotlin DSL), incluidas las plataformas (BOM).
+	gradleDependency = regexp.MustCompile(`(?m)^\s*(?:implementation|api|compileOnly|runtimeOnly|developmentOnly|annotationProcessor|kapt|ksp|testImplementation|testCompileOnly|testRuntimeOnly)\s*\(?\s*(?:(?:enforcedPlatform|platform)\s*\(\s*)?["']([^:"'\s]+):([^:"'\s]+)`)
+	// gradlePlugin extrae los IDs del bloque plugins { id("...") } o id '...'.
+	gradlePlugin = regexp.MustCompile(`(?m)^\s*id\s*\(?\s*["']([^"']+)["']`)
+)
+
+// parseGradle extrae las dependencias y los plugins de build.gradle(.kts). Los plugins
+// se registran con las coordenadas de su marker artifact ("<id>:<id>.gradle.plugin").
+func parseGradle(content []byte, info *manifestInfo) {
+	info.addEcosystem(EcosystemMaven)
+	for _, match := range gradleDependency.FindAllSubmatch(content, -1) {
+		info.addDep(EcosystemMaven, string(match[1])+":"+string(match[2]))
+	}
+	for _, match := range gradlePlugin.FindAllSubmatch(content, -1) {
+		id := string(match[1])
+		info.addDep(EcosystemMaven, id+":"+id+".gradle.plugin")
+	}
+}
+
+// parseComposerJSON extrae nombre, descripción y paquetes de composer.json. Se omiten
+// las restricciones de plataforma (php, ext-*, lib-*).
+func parseComposerJSON(content []byte, info *manifestInfo) {
+	var composer struct {
+		Name        string            `json:"name"`
+		Description string            `json:"description"`
+		Require     map[string]string `json:"require"`
+		RequireDev  map[string]string `json:"require-dev"`
+	}
+	if err := json.Unmarshal(content, &composer); err != nil {
+		return
+	}
+
+	info.setName(composer.Name, composer.Description)
+	info.addEcosystem(EcosystemPackagist)
+	for _, packages := range []map[string]string{composer.Require, composer.RequireDev} {
+		for name := range packages {
+			name = strings.ToLower(name)
+			if name == "php" || strings.HasPrefix(name, "ext-") || strings.HasPrefix(name, "lib-") {
+				continue
+			}
+			info.addDep(EcosystemPackagist, name)
+		}
+	}
+}
+
+// gemDeclaration extrae el nombre de las líneas gem "name" de un Gemfile.
+var gemDeclaration = regexp.MustCompile(`(?m)^\s*gem\s*\(?\s*["']([^"']+)["']`)
+
+// parseGemfile extrae las gemas declaradas en un Gemfile.
+func parseGemfile(content []byte, info *manifestInfo) {
+	info.addEcosystem(EcosystemRubyGems)
+	for _, match := range gemDeclaration.FindAllSubmatch(content, -1) {
+		info.addDep(EcosystemRubyGems, strings.ToLower(string(match[1])))
+	}
+}
+
+var (
+	// mixApp extrae el nombre de la aplicación (app: :my_app) de mix.exs.
+	mixApp = regexp.MustCompile(`\bapp:\s*:([a-z0-9_]+)`)
+	// mixDependency extrae el nombre de las tuplas {:dep, ...} de la función deps.
+	mixDependency = regexp.MustCompile(`\{\s*:([a-z0-9_]+)\s*,`)
+)
+
+// parseMixExs extrae la aplicación y las dependencias de mix.exs. Solo se leen las tuplas
+// de la función deps para no confundirlas con otras tuplas del archivo.
+func parseMixExs(content []byte, info *manifestInfo) {
+	text := string(content)
+	if match := mixApp.FindStringSubmatch(text); match != nil {
+		info.setName(match[1], "")
+	}
+	info.addEcosystem(EcosystemHex)
+
+	start := strings.Index(text, "defp deps")
+	if start == -1 {
+		return
+	}
+	deps := text[start+len("defp deps"):]
+	if end := strings.Index(deps, "defp "); end != -1 {
+		deps = deps[:end]
+	}
+	for _, match := range mixDependency.FindAllStringSubmatch(deps, -1) {
+		info.addDep(EcosystemHex, match[1])
+	}
+}
+
+// parseCsproj extrae el SDK y los PackageReference de un proyecto .NET. El SDK se
+// registra como una dependencia más (Microsoft.NET.Sdk.Web indica ASP.NET Core).
+func parseCsproj(content []byte, info *manifestInfo) {
+	var project struct {
+		Sdk        string `xml:"Sdk,attr"`
+		ItemGroups []struct {
+			References []struct {
+				Include string `xml:"Include,attr"`
+			} `xml:"PackageReference"`
+		} `xml:"ItemGroup"`
+	}
+	if err := xml.Unmarshal(content, &project); err != nil {
+		return
+	}
+
+	info.addEcosystem(EcosystemNuGet)
+	info.addDep(EcosystemNuGet, strings.ToLower(project.Sdk))
+	for _, group := range project.ItemGroups {
+		for _, reference := range group.References {
+			info.addDep(EcosystemNuGet, strings.ToLower(reference.Include))
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDetect_Ecosystems verifica el registro de manifiestos: cada ecosistema se lee con
+// su parser y las dependencias se mapean a lenguaje, frameworks, bases de datos y skills.
+func TestDetect_Ecosystems(t *testing.T) {
+	tests := []struct {
+		name           string
+		files          map[string]string
+		projectName    string
+		language       string
+		frameworks     []string
+		databases      []string
+		testFrameworks []string
+		skills         []string
+		category       string
+	}{
+		{
+			name: "Poetry",
+			files: map[string]string{"pyproject.toml": `[tool.poetry]
+name = "ledger"
+
+[tool.poetry.dependencies]
+python = "^3.12"
+Django = "^5.0"
+psycopg2-binary = "*"
+
+[tool.poetry.group.dev.dependencies]
+pytest = "^8"
+`},
+			projectName:    "ledger",
+			language:       "Python",
+			frameworks:     []string{"Django"},
+			databases:      []string{"PostgreSQL"},
+			testFrameworks: []string{"pytest"},
+			skills:         []string{"postgresql", "django", "pytest"},
+			category:       "API REST",
+		},
+		{
+			name: "Pipfile",
+			files: map[string]string{"Pipfile": `[packages]
+flask = "*"
+Flask_SQLAlchemy = "*"
+
+[dev-packages]
+pytest = "*"
+`},
+			language:       "Python",
+			frameworks:     []string{"Flask"},
+			testFrameworks: []string{"pytest"},
+			skills:         []string{"flask-sqlalchemy", "flask", "pytest"},
+			category:       "API REST",
+		},
+		{
+			name: "Cargo",
+			files: map[string]string{"Cargo.toml": `[package]
+name = "gateway"
+description = "Edge gateway"
+
+[dependencies]
+axum = "0.7"
+tokio = { version = "1", features = ["full"] }
+sqlx_macros = "0.7"
+
+[dependencies.sqlx]
+version = "0.7"
+
+[target.'cfg(unix)'.dependencies]
+redis = "0.25"
+`},
+			projectName: "gateway",
+			language:    "Rust",
+			frameworks:  []string{"Axum"},
+			databases:   []string{"Redis"},
+			skills:      []string{"redis", "sqlx", "tokio", "axum"},
+			category:    "API REST",
+		},
+		{
+			name: "Maven",
+			files: map[string]string{"pom.xml": `<project>
+  <parent>
+    <groupId>org.springframework.boot</groupId>
+    <artifactId>spring-boot-starter-parent</artifactId>
+  </parent>
+  <artifactId>orders</artifactId>
+  <description>Order service</description>
+  <dependencies>
+    <dependency><groupId>org.springframework.boot</groupId><artifactId>spring-boot-starter-data-jpa</artifactId></dependency>
+    <dependency><groupId>org.postgresql</groupId><artifactId>postgresql</artifactId></dependency>
+    <dependency><groupId>org.junit.jupiter</groupId><artifactId>junit-jupiter</artifactId></dependency>
+  </dependencies>
+</project>`},
+			projectName:    "orders",
+			language:       "Java",
+			frameworks:     []string{"Spring Boot"},
+			databases:      []string{"PostgreSQL"},
+			testFrameworks: []string{"JUnit 5"},
+			skills:         []string{"postgresql", "spring-data-jpa", "junit", "spring-boot"},
+			category:       "API REST",
+		},
+		{
+			name: "Gradle Kotlin DSL",
+			files: map[string]string{"build.gradle.kts": `plugins {
+    id("io.ktor.plugin") version "2.3.0"
+}
+
+dependencies {
+    implementation("io.ktor:ktor-server-core:2.3.0")
+    implementation(platform("org.testcontainers:testcontainers-bom:1.19.0"))
+    runtimeOnly("org.mariadb.jdbc:mariadb-java-client:3.3.0")
+    testImplementation("io.kotest:kotest-runner-junit5:5.8.0")
+}
+`},
+			language:       "Kotlin", // build.gradle.kts cuenta en el censo de archivos
+			frameworks:     []string{"Ktor"},
+			databases:      []string{"MariaDB"},
+			testFrameworks: []string{"Kotest", "Testcontainers"},
+			skills:         []string{"testcontainers", "ktor"},
+			category:       "API REST",
+		},
+		{
+			name: "Composer",
+			files: map[string]string{"composer.json": `{
+  "name": "acme/shop",
+  "description": "Shop backend",
+  "require": {"php": "^8.2", "ext-json": "*", "laravel/framework": "^11.0", "predis/predis": "^2.0"},
+  "require-dev": {"pestphp/pest": "^2.0"}
+}`},
+			projectName:    "acme/shop",
+			language:       "PHP",
+			frameworks:     []string{"Laravel"},
+			databases:      []string{"Redis"},
+			testFrameworks: []string{"Pest"},
+			skills:         []string{"redis", "pest", "laravel"},
+			category:       "Web App",
+		},
+		{
+			name: "Gemfile",
+			files: map[string]string{"Gemfile": `source "https://rubygems.org"
+
+gem "rails", "~> 7.1"
+gem 'pg'
+gem "sidekiq"
+
+group :test do
+  gem "rspec-rails"
+end
+`},
+			language:       "Ruby",
+			frameworks:     []string{"Ruby on Rails"},
+			databases:      []string{"PostgreSQL"},
+			testFrameworks: []string{"RSpec"},
+			skills:         []string{"postgresql", "sidekiq", "rspec", "rails"},
+			category:       "Web App",
+		},
+		{
+			name: "Mix",
+			files: map[string]string{"mix.exs": `defmodule Chat.MixProject do
+  use Mix.Project
+
+  def project do
+    [app: :chat, version: "0.1.0", deps: deps()]
+  end
+
+  def application do
+    [extra_applications: [{:logger, :permanent}]]
+  end
+
+  defp deps do
+    [
+      {:phoenix, "~> 1.7"},
+      {:ecto_sql, "~> 3.10"},
+      {:postgrex, ">= 0.0.0"}
+    ]
+  end
+end
+`},
+			projectName: "chat",
+			language:    "Elixir",
+			frameworks:  []string{"Phoenix"},
+			databases:   []string{"PostgreSQL"},
+			skills:      []string{"postgresql", "ecto", "phoenix"},
+			category:    "Web App",
+		},
+		{
+			name: "csproj",
+			files: map[string]string{"src/Billing.Api/Billing.Api.csproj": `<Project Sdk="Microsoft.NET.Sdk.Web">
+  <ItemGroup>
+    <PackageReference Include="Npgsql.EntityFrameworkCore.PostgreSQL" Version="8.0.0" />
+    <PackageReference Include="Microsoft.EntityFrameworkCore" Version="8.0.0" />
+    <PackageReference Include="xunit" Version="2.6.0" />
+  </ItemGroup>
+</Project>`},
+			language:       "C#",
+			frameworks:     []string{"ASP.NET Core"},
+			databases:      []string{"PostgreSQL"},
+			testFrameworks: []string{"xUnit"},
+			skills:         []string{"postgresql", "entity-framework-core", "xunit", "aspnet-core"},
+			category:       "API REST",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			result, err := NewDetector(dir).Detect()
+			require.NoError(t, err)
+
+			if tt.projectName != "" {
+				assert.Equal(t, tt.projectName, result.Name)
+			}
+			assert.Equal(t, tt.language, result.Language)
+			assert.Equal(t, tt.frameworks, result.Frameworks)
+			assert.Equal(t, tt.databases, result.Databases)
+			assert.Equal(t, tt.testFrameworks, result.TestFrameworks)
+			assert.Equal(t, tt.skills, result.Skills)
+			assert.Equal(t, tt.category, result.ProjectCategory)
+		})
+	}
+}
+
+// TestDependencyRule_Matches_MavenGroup verifica que un módulo terminado en ":" cubre el groupId completo.
+func TestDependencyRule_Matches_MavenGroup(t *testing.T) {
+	rule := dependencyRule{EcosystemMaven, "io.quarkus:", "Quarkus"}
+
+	assert.True(t, rule.matches("io.quarkus:quarkus-resteasy"))
+	assert.False(t, rule.matches("io.quarkusio:other"))
+	assert.False(t, dependencyRule{EcosystemMaven, "junit:junit", "JUnit"}.matches("junit:junit-dep"))
+}
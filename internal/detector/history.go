@@ -0,0 +1,417 @@
+package detector
+
+import (
+	"fmt"
+	"os/exec"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Convenciones de mensajes de commit reconocidas en el historial.
+const (
+	CommitConventional = "Conventional Commits"
+	CommitTicketPrefix = "Ticket prefix"
+	CommitBracketed    = "Bracketed prefix"
+)
+
+const (
+	// maxHistoryCommits es el número de commits recientes que se analizan.
+	maxHistoryCommits = 500
+	// minConventionShare es el porcentaje mínimo de commits para considerar un estilo la convención del equipo.
+	minConventionShare = 50
+	// maxHotPaths es el número de directorios más modificados que se reportan.
+	maxHotPaths = 5
+	// hotPathDepth es la profundidad a la que se agrupan los archivos modificados.
+	hotPathDepth = 2
+	// maxCommitTypes es el número de tipos de Conventional Commits que se reportan.
+	maxCommitTypes = 6
+)
+
+var (
+	conventionalCommit = regexp.MustCompile(`^([a-z]+)(\([^)]*\))?!?: \S`)
+	ticketPrefixCommit = regexp.MustCompile(`^\[?[A-Z][A-Z0-9]+-\d+\]?:?\s`)
+	bracketedCommit    = regexp.MustCompile(`^\[[^\]]+\]\s`)
+	ticketReference    = regexp.MustCompile(`\b([A-Z][A-Z0-9]+)-\d+\b`)
+)
+
+// defaultBranches son las ramas de larga duración que no indican convención de nombres.
+var defaultBranches = map[string]bool{"HEAD": true, "main": true, "master": true, "develop": true, "development": true, "trunk": true}
+
+// History contiene los hábitos del equipo inferidos del historial local de git.
+type History struct {
+	Commits          int           `json:"commits" yaml:"commits"`                                         // Commits analizados, sin merges
+	Contributors     int           `json:"contributors" yaml:"contributors"`                               // Autores distintos en los commits analizados
+	CommitConvention string        `json:"commit_convention,omitempty" yaml:"commit_convention,omitempty"` // Vacío si los mensajes son libres
+	ConventionShare  int           `json:"convention_share,omitempty" yaml:"convention_share,omitempty"`   // Porcentaje de commits que siguen la convención
+	CommitTypes      []string      `json:"commit_types,omitempty" yaml:"commit_types,omitempty"`           // Tipos de Conventional Commits por frecuencia
+	CommitExamples   []string      `json:"commit_examples,omitempty" yaml:"commit_examples,omitempty"`     // Mensajes recientes que siguen la convención
+	TicketPattern    string        `json:"ticket_pattern,omitempty" yaml:"ticket_pattern,omitempty"`       // Referencia de ticket habitual, p. ej. "PROJ-123"
+	BranchPrefixes   []string      `json:"branch_prefixes,omitempty" yaml:"branch_prefixes,omitempty"`     // Prefijos de rama por frecuencia ("feature/", "fix/")
+	BranchExamples   []string      `json:"branch_examples,omitempty" yaml:"branch_examples,omitempty"`     // Ramas que siguen los prefijos
+	HotPaths         []PathChanges `json:"hot_paths,omitempty" yaml:"hot_paths,omitempty"`                 // Directorios más modificados
+	TestChanges      int           `json:"test_changes" yaml:"test_changes"`                               // Cambios en archivos de test
+	CodeChanges      int           `json:"code_changes" yaml:"code_changes"`                               // Cambios en el resto de archivos fuente
+}
+
+// PathChanges es el número de cambios de archivos de un directorio en el historial.
+type PathChanges struct {
+	Path    string `json:"path" yaml:"path"`
+	Changes int    `json:"changes" yaml:"changes"`
+}
+
+// TestRatio retorna los cambios de test por cada cambio de código, o 0 si no hay cambios de código.
+func (h *History) TestRatio() float64 {
+	if h == nil || h.CodeChanges == 0 {
+		return 0
+	}
+	return float64(h.TestChanges) / float64(h.CodeChanges)
+}
+
+// Summary formatea el historial para incluirlo en los prompts de IA. Un History nil
+// produce una cadena vacía.
+func (h *History) Summary() string {
+	if h == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	convention := "free-form messages"
+	if h.CommitConvention != "" {
+		convention = fmt.Sprintf("%s (%d%% of %d commits)", h.CommitConvention, h.ConventionShare, h.Commits)
+	}
+	sb.WriteString(fmt.Sprintf("- Commit convention: %s\n", convention))
+	if len(h.CommitTypes) > 0 {
+		sb.WriteString(fmt.Sprintf("- Commit types: %s\n", strings.Join(h.CommitTypes, ", ")))
+	}
+	if len(h.CommitExamples) > 0 {
+		sb.WriteString(fmt.Sprintf("- Commit examples: %s\n", quoteAll(h.CommitExamples)))
+	}
+	if h.TicketPattern != "" {
+		sb.WriteString(fmt.Sprintf("- Ticket references: %s\n", h.TicketPattern))
+	}
+	if len(h.BranchPrefixes) > 0 {
+		sb.WriteString(fmt.Sprintf("- Branch naming: %s (e.g. %s)\n", strings.Join(h.BranchPrefixes, ", "), quoteAll(h.BranchExamples)))
+	}
+	if len(h.HotPaths) > 0 {
+		paths := make([]string, 0, len(h.HotPaths))
+		for _, hot := range h.HotPaths {
+			paths = append(paths, fmt.Sprintf("%s (%d)", hot.Path, hot.Changes))
+		}
+		sb.WriteString(fmt.Sprintf("- Most changed directories: %s\n", strings.Join(paths, ", ")))
+	}
+	if h.CodeChanges > 0 {
+		sb.WriteString(fmt.Sprintf("- Test-to-code changes: %.2f (%d test file changes, %d code file changes)\n", h.TestRatio(), h.TestChanges, h.CodeChanges))
+	}
+	sb.WriteString(fmt.Sprintf("- Contributors: %d", h.Contributors))
+
+	return sb.String()
+}
+
+// Guidelines retorna las pautas de trabajo que se derivan del historial, para los agentes
+// que escriben o revisan código.
+func (h *History) Guidelines() []string {
+	if h == nil {
+		return nil
+	}
+
+	var guidelines []string
+	switch h.CommitConvention {
+	case CommitConventional:
+		guideline := "Write commit messages as Conventional Commits (`type(scope): summary`)"
+		if len(h.CommitTypes) > 0 {
+			guideline += fmt.Sprintf(" using the team's types: %s", strings.Join(h.CommitTypes, ", "))
+		}
+		guidelines = append(guidelines, guideline)
+	case CommitTicketPrefix:
+		guidelines = append(guidelines, fmt.Sprintf("Start commit messages with the ticket reference (`%s ...`)", h.TicketPattern))
+	case CommitBracketed:
+		guidelines = append(guidelines, "Start commit messages with a bracketed prefix, like the existing history")
+	}
+	if len(h.CommitExamples) > 0 {
+		guidelines = append(guidelines, fmt.Sprintf("Match the style of recent commits: %s", quoteAll(h.CommitExamples)))
+	}
+	if h.TicketPattern != "" && h.CommitConvention != CommitTicketPrefix {
+		guidelines = append(guidelines, fmt.Sprintf("Reference the ticket (`%s`) in commits when there is one", h.TicketPattern))
+	}
+	if len(h.BranchPrefixes) > 0 {
+		guidelines = append(guidelines, fmt.Sprintf("Name branches with the prefixes %s", strings.Join(h.BranchPrefixes, ", ")))
+	}
+	if len(h.HotPaths) > 0 {
+		paths := make([]string, 0, len(h.HotPaths))
+		for _, hot := range h.HotPaths {
+			paths = append(paths, hot.Path)
+		}
+		guidelines = append(guidelines, fmt.Sprintf("Pay extra attention to the most changed directories: %s", strings.Join(paths, ", ")))
+	}
+	if h.CodeChanges > 0 {
+		if h.TestRatio() >= 0.3 {
+			guidelines = append(guidelines, "Code changes usually come with test changes: update or add tests with every change")
+		} else {
+			guidelines = append(guidelines, "Tests are rarely updated with code changes: check that changed behaviour is covered")
+		}
+	}
+	return guidelines
+}
+
+// quoteAll formatea los valores como código markdown separado por comas.
+func quoteAll(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, "`"+value+"`")
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// commitRecord es un commit del historial con sus archivos modificados.
+type commitRecord struct {
+	author  string
+	subject string
+	files   []string
+}
+
+// detectHistory lee el historial local de git del proyecto sin acceder a la red. Retorna
+// nil si el proyecto no es un repositorio git, git no está instalado o no hay commits.
+func (d *Detector) detectHistory() *History {
+	output, err := d.git("log", "--no-merges", fmt.Sprintf("--max-count=%d", maxHistoryCommits), "--relative",
+		"--format=%x1e%ae%x1f%s", "--name-only", "--", ".")
+	if err != nil {
+		return nil
+	}
+
+	commits := parseCommits(output)
+	if len(commits) == 0 {
+		return nil
+	}
+
+	history := &History{Commits: len(commits)}
+	authors := make(map[string]bool)
+	for _, commit := range commits {
+		authors[commit.author] = true
+	}
+	history.Contributors = len(authors)
+
+	history.analyzeMessages(commits)
+	history.analyzeChanges(commits)
+	if branches, err := d.git("for-each-ref", "--format=%(refname)", "refs/heads", "refs/remotes"); err == nil {
+		history.analyzeBranches(strings.Split(branches, "\n"))
+	}
+
+	return history
+}
+
+// git ejecuta un comando de git de solo lectura en el directorio del proyecto.
+func (d *Detector) git(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = d.projectPath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
+
+// parseCommits separa la salida de git log en commits.
+func parseCommits(output string) []commitRecord {
+	var commits []commitRecord
+	for _, record := range strings.Split(output, "\x1e") {
+		lines := strings.Split(strings.TrimSpace(record), "\n")
+		header := strings.SplitN(lines[0], "\x1f", 2)
+		if len(header) != 2 {
+			continue
+		}
+
+		commit := commitRecord{author: strings.ToLower(header[0]), subject: strings.TrimSpace(header[1])}
+		for _, line := range lines[1:] {
+			if line = strings.TrimSpace(line); line != "" {
+				commit.files = append(commit.files, line)
+			}
+		}
+		commits = append(commits, commit)
+	}
+	return commits
+}
+
+// analyzeMessages infiere la convención de los mensajes de commit y las referencias a tickets.
+func (h *History) analyzeMessages(commits []commitRecord) {
+	styles := make(map[string]int)
+	types := make(map[string]int)
+	tickets := make(map[string]int)
+	withTicket := 0
+
+	for _, commit := range commits {
+		style := commitStyle(commit.subject)
+		styles[style]++
+		if style == CommitConventional {
+			types[conventionalCommit.FindStringSubmatch(commit.subject)[1]]++
+		}
+
+		if match := ticketReference.FindStringSubmatch(commit.subject); match != nil {
+			tickets[match[1]]++
+			withTicket++
+		}
+	}
+
+	for _, style := range []string{CommitConventional, CommitTicketPrefix, CommitBracketed} {
+		share := styles[style] * 100 / len(commits)
+		if share >= minConventionShare && share > h.ConventionShare {
+			h.CommitConvention = style
+			h.ConventionShare = share
+		}
+	}
+	if h.CommitConvention == CommitConventional {
+		h.CommitTypes = topKeys(types, maxCommitTypes)
+	}
+
+	if withTicket*100/len(commits) >= minConventionShare/2 {
+		if keys := topKeys(tickets, 1); len(keys) > 0 {
+			h.TicketPattern = keys[0] + "-123"
+		}
+	}
+
+	if h.CommitConvention != "" {
+		for _, commit := range commits {
+			if len(h.CommitExamples) == 3 {
+				break
+			}
+			if commitStyle(commit.subject) == h.CommitConvention {
+				h.CommitExamples = append(h.CommitExamples, commit.subject)
+			}
+		}
+	}
+}
+
+// commitStyle retorna la convención que sigue un mensaje de commit, o "" si es libre.
+func commitStyle(subject string) string {
+	switch {
+	case conventionalCommit.MatchString(subject):
+		return CommitConventional
+	case ticketPrefixCommit.MatchString(subject):
+		return CommitTicketPrefix
+	case bracketedCommit.MatchString(subject):
+		return CommitBracketed
+	}
+	return ""
+}
+
+// analyzeChanges cuenta los cambios por directorio y los cambios de test frente a los de código.
+func (h *History) analyzeChanges(commits []commitRecord) {
+	dirs := make(map[string]int)
+	for _, commit := range commits {
+		for _, file := range commit.files {
+			dirs[hotPath(file)]++
+
+			if _, ok := extensionLanguages[strings.ToLower(path.Ext(file))]; !ok {
+				continue
+			}
+			if isTestPath(file) {
+				h.TestChanges++
+			} else {
+				h.CodeChanges++
+			}
+		}
+	}
+	delete(dirs, ".")
+
+	for _, dir := range topKeys(dirs, maxHotPaths) {
+		h.HotPaths = append(h.HotPaths, PathChanges{Path: dir, Changes: dirs[dir]})
+	}
+}
+
+// hotPath agrupa un archivo por su directorio hasta hotPathDepth niveles.
+func hotPath(file string) string {
+	parts := strings.Split(path.Dir(file), "/")
+	if len(parts) > hotPathDepth {
+		parts = parts[:hotPathDepth]
+	}
+	return strings.Join(parts, "/")
+}
+
+// isTestPath indica si un archivo es de test por su nombre o por estar en un directorio de tests.
+func isTestPath(file string) bool {
+	if isTestFile(path.Base(file)) {
+		return true
+	}
+	for _, part := range strings.Split(path.Dir(file), "/") {
+		switch part {
+		case "test", "tests", "__tests__", "spec":
+			return true
+		}
+	}
+	return false
+}
+
+// analyzeBranches infiere los prefijos de nombre de rama a partir de las ramas locales y remotas.
+func (h *History) analyzeBranches(refs []string) {
+	seen := make(map[string]bool)
+	prefixes := make(map[string]int)
+	examples := make(map[string]string)
+	total := 0
+
+	for _, ref := range refs {
+		name := branchName(ref)
+		if name == "" || defaultBranches[name] || seen[name] {
+			continue
+		}
+		seen[name] = true
+		total++
+
+		if i := strings.Index(name, "/"); i > 0 {
+			prefix := name[:i+1]
+			prefixes[prefix]++
+			if _, ok := examples[prefix]; !ok {
+				examples[prefix] = name
+			}
+		}
+	}
+
+	withPrefix := 0
+	for _, count := range prefixes {
+		withPrefix += count
+	}
+	if total == 0 || withPrefix*100/total < minConventionShare {
+		return
+	}
+
+	h.BranchPrefixes = topKeys(prefixes, maxHotPaths)
+	for _, prefix := range h.BranchPrefixes {
+		if len(h.BranchExamples) < 3 {
+			h.BranchExamples = append(h.BranchExamples, examples[prefix])
+		}
+	}
+}
+
+// branchName extrae el nombre de la rama de una referencia local o remota.
+func branchName(ref string) string {
+	ref = strings.TrimSpace(ref)
+	if name, ok := strings.CutPrefix(ref, "refs/heads/"); ok {
+		return name
+	}
+	if name, ok := strings.CutPrefix(ref, "refs/remotes/"); ok {
+		// Quitar el nombre del remoto (origin/feature/x -> feature/x)
+		if i := strings.Index(name, "/"); i >= 0 {
+			return name[i+1:]
+		}
+	}
+	return ""
+}
+
+// topKeys retorna las claves ordenadas por frecuencia descendente (y alfabéticamente en
+// caso de empate), limitadas a n si n > 0.
+func topKeys(counts map[string]int, n int) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if n > 0 && len(keys) > n {
+		keys = keys[:n]
+	}
+	return keys
+}
@@ -0,0 +1,108 @@
+package detector
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gitRepo crea un repositorio git con un commit por cada mensaje, modificando los archivos indicados.
+func gitRepo(t *testing.T, commits []struct {
+	message string
+	files   map[string]string
+}, branches ...string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=Dev", "-c", "user.email=dev@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	run("init", "-q", "-b", "main")
+	for _, commit := range commits {
+		writeFiles(t, dir, commit.files)
+		run("add", "-A")
+		run("commit", "-q", "-m", commit.message)
+	}
+	for _, branch := range branches {
+		run("branch", branch)
+	}
+	return dir
+}
+
+// TestDetect_History verifica la convención de commits, las ramas, los directorios más
+// modificados y la proporción de cambios de test.
+func TestDetect_History(t *testing.T) {
+	dir := gitRepo(t, []struct {
+		message string
+		files   map[string]string
+	}{
+		{"chore: init module", map[string]string{"go.mod": "module example.com/api\n"}},
+		{"feat(orders): add order service", map[string]string{"internal/orders/service.go": "package orders\n"}},
+		{"fix(orders): validate totals PAY-12", map[string]string{"internal/orders/service.go": "package orders\n\n// v2\n", "internal/orders/service_test.go": "package orders\n"}},
+		{"feat(api): expose orders", map[string]string{"cmd/api/main.go": "package main\n"}},
+		{"update readme", map[string]string{"README.md": "# api\n"}},
+	}, "feature/orders", "feature/payments", "fix/totals")
+
+	result, err := NewDetector(dir).Detect()
+	require.NoError(t, err)
+	require.NotNil(t, result.History)
+
+	history := result.History
+	assert.Equal(t, 5, history.Commits)
+	assert.Equal(t, 1, history.Contributors)
+	assert.Equal(t, CommitConventional, history.CommitConvention)
+	assert.Equal(t, 80, history.ConventionShare)
+	assert.Equal(t, []string{"feat", "chore", "fix"}, history.CommitTypes)
+	assert.Equal(t, []string{"feat(api): expose orders", "fix(orders): validate totals PAY-12", "feat(orders): add order service"}, history.CommitExamples)
+	assert.Empty(t, history.TicketPattern, "un solo commit con ticket no es un hábito")
+	assert.Equal(t, []string{"feature/", "fix/"}, history.BranchPrefixes)
+	assert.Equal(t, []string{"feature/orders", "fix/totals"}, history.BranchExamples)
+	assert.Equal(t, []PathChanges{{Path: "internal/orders", Changes: 3}, {Path: "cmd/api", Changes: 1}}, history.HotPaths)
+	assert.Equal(t, 1, history.TestChanges)
+	assert.Equal(t, 3, history.CodeChanges)
+
+	assert.Contains(t, result.Summary(), "  - Commit convention: Conventional Commits (80% of 5 commits)")
+	assert.Contains(t, history.Guidelines(), "Write commit messages as Conventional Commits (`type(scope): summary`) using the team's types: feat, chore, fix")
+	assert.Contains(t, history.Guidelines(), "Name branches with the prefixes feature/, fix/")
+}
+
+// TestDetect_History_TicketPrefix verifica la detección de mensajes que empiezan por un ticket.
+func TestDetect_History_TicketPrefix(t *testing.T) {
+	dir := gitRepo(t, []struct {
+		message string
+		files   map[string]string
+	}{
+		{"[SHOP-1] Initial import", map[string]string{"app.py": "print()\n"}},
+		{"SHOP-2: Add cart", map[string]string{"cart/cart.py": "\n"}},
+		{"SHOP-3 Add checkout", map[string]string{"cart/checkout.py": "\n"}},
+	})
+
+	result, err := NewDetector(dir).Detect()
+	require.NoError(t, err)
+	require.NotNil(t, result.History)
+
+	assert.Equal(t, CommitTicketPrefix, result.History.CommitConvention)
+	assert.Equal(t, "SHOP-123", result.History.TicketPattern)
+	assert.Empty(t, result.History.BranchPrefixes)
+	assert.Equal(t, "Start commit messages with the ticket reference (`SHOP-123 ...`)", result.History.Guidelines()[0])
+}
+
+// TestDetect_History_NoRepository verifica que sin repositorio git no hay historial.
+func TestDetect_History_NoRepository(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"main.go": "package main\n"})
+
+	result, err := NewDetector(dir).Detect()
+	require.NoError(t, err)
+	assert.Nil(t, result.History)
+}
@@ -0,0 +1,374 @@
+package detector
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Ecosistemas de dependencias soportados.
+const (
+	EcosystemGo        = "go"
+	EcosystemNPM       = "npm"
+	EcosystemPyPI      = "pypi"
+	EcosystemCargo     = "cargo"
+	EcosystemMaven     = "maven" // Coordenadas "groupId:artifactId" (pom.xml y Gradle)
+	EcosystemPackagist = "packagist"
+	EcosystemRubyGems  = "rubygems"
+	EcosystemHex       = "hex"
+	EcosystemNuGet     = "nuget" // Identificadores en minúsculas (NuGet no distingue mayúsculas)
+)
+
+// ecosystemLanguages mapea cada ecosistema al lenguaje usado cuando no hay archivos fuente.
+var ecosystemLanguages = map[string]string{
+	EcosystemGo:        "Go",
+	EcosystemNPM:       "JavaScript",
+	EcosystemPyPI:      "Python",
+	EcosystemCargo:     "Rust",
+	EcosystemMaven:     "Java",
+	EcosystemPackagist: "PHP",
+	EcosystemRubyGems:  "Ruby",
+	EcosystemHex:       "Elixir",
+	EcosystemNuGet:     "C#",
+}
+
+// manifestParser lee un tipo de manifiesto de dependencias.
+type manifestParser struct {
+	patterns []string // Nombres o globs relativos a la raíz del proyecto
+	parse    func(content []byte, info *manifestInfo)
+}
+
+// manifestParsers es el registro de manifiestos soportados. El orden decide de qué
+// manifiesto se toman el nombre y la descripción del proyecto.
+var manifestParsers = []manifestParser{
+	{[]string{"go.mod"}, parseGoMod},
+	{[]string{"package.json"}, parsePackageJSON},
+	{[]string{"pyproject.toml"}, parsePyProject},
+	{[]string{"requirements.txt", "requirements-dev.txt"}, parseRequirements},
+	{[]string{"Pipfile"}, parsePipfile},
+	{[]string{"Cargo.toml"}, parseCargoToml},
+	{[]string{"pom.xml"}, parsePom},
+	{[]string{"build.gradle", "build.gradle.kts"}, parseGradle},
+	{[]string{"composer.json"}, parseComposerJSON},
+	{[]string{"Gemfile"}, parseGemfile},
+	{[]string{"mix.exs"}, parseMixExs},
+	{[]string{"*.csproj", "src/*/*.csproj"}, parseCsproj},
+}
+
+// manifestInfo contiene los datos extraídos de los manifiestos del proyecto.
+type manifestInfo struct {
+	name        string
+	description string
+	ecosystems  []string                   // ecosistemas en el orden en que se encontraron
+	deps        map[string]map[string]bool // ecosistema -> módulos declarados
+}
+
+// addDep registra una dependencia de un ecosistema.
+func (m *manifestInfo) addDep(ecosystem, module string) {
+	module = strings.TrimSpace(module)
+	if module == "" {
+		return
+	}
+	m.addEcosystem(ecosystem)
+	m.deps[ecosystem][module] = true
+}
+
+// addEcosystem registra un ecosistema aunque el manifiesto no declare dependencias.
+func (m *manifestInfo) addEcosystem(ecosystem string) {
+	if m.deps[ecosystem] == nil {
+		m.deps[ecosystem] = make(map[string]bool)
+		m.ecosystems = append(m.ecosystems, ecosystem)
+	}
+}
+
+// dependencies retorna los módulos declarados por ecosistema, ordenados, o nil si no hay ninguno.
+func (m *manifestInfo) dependencies() map[string][]string {
+	var deps map[string][]string
+	for ecosystem, modules := range m.deps {
+		if len(modules) == 0 {
+			continue
+		}
+		if deps == nil {
+			deps = make(map[string][]string, len(m.deps))
+		}
+		deps[ecosystem] = sortedKeys(modules)
+	}
+	return deps
+}
+
+// setName asigna el nombre y la descripción si todavía no se conocen.
+func (m *manifestInfo) setName(name, description string) {
+	if m.name == "" {
+		m.name = strings.TrimSpace(name)
+	}
+	if m.description == "" {
+		m.description = strings.TrimSpace(description)
+	}
+}
+
+// readManifests lee los manifiestos de dependencias de la raíz del proyecto con los
+// parsers del registro.
+func (d *Detector) readManifests() *manifestInfo {
+	info := &manifestInfo{deps: make(map[string]map[string]bool)}
+
+	for _, parser := range manifestParsers {
+		for _, file := range manifestMatches(d.projectPath, parser) {
+			if content, err := os.ReadFile(file); err == nil {
+				parser.parse(content, info)
+			}
+		}
+	}
+
+	return info
+}
+
+// manifestMatches retorna los archivos de la raíz que coinciden con los patrones de un parser.
+func manifestMatches(projectPath string, parser manifestParser) []string {
+	var files []string
+	for _, pattern := range parser.patterns {
+		matches, err := filepath.Glob(filepath.Join(projectPath, pattern))
+		if err != nil {
+			continue
+		}
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+	return files
+}
+
+// ManifestFiles retorna las rutas relativas de los manifiestos de dependencias de la raíz
+// del proyecto que reconoce el registro de parsers.
+func ManifestFiles(projectPath string) []string {
+	var files []string
+	for _, parser := range manifestParsers {
+		for _, file := range manifestMatches(projectPath, parser) {
+			if rel, err := filepath.Rel(projectPath, file); err == nil {
+				files = append(files, filepath.ToSlash(rel))
+			}
+		}
+	}
+	return files
+}
+
+// parseGoMod extrae el módulo y los requires de go.mod (en línea y en bloque).
+func parseGoMod(content []byte, info *manifestInfo) {
+	inBlock := false
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(strings.SplitN(line, "//", 2)[0])
+
+		switch {
+		case strings.HasPrefix(line, "module "):
+			info.setName(path.Base(strings.Trim(strings.TrimPrefix(line, "module "), `" `)), "")
+		case line == "require (":
+			inBlock = true
+		case inBlock && line == ")":
+			inBlock = false
+		case inBlock:
+			if fields := strings.Fields(line); len(fields) > 0 {
+				info.addDep(EcosystemGo, strings.Trim(fields[0], `"`))
+			}
+		case strings.HasPrefix(line, "require "):
+			if fields := strings.Fields(line); len(fields) > 1 {
+				info.addDep(EcosystemGo, strings.Trim(fields[1], `"`))
+			}
+		}
+	}
+
+	info.addEcosystem(EcosystemGo)
+}
+
+// parsePackageJSON extrae nombre, descripción y dependencias de package.json.
+func parsePackageJSON(content []byte, info *manifestInfo) {
+	var pkg struct {
+		Name            string            `json:"name"`
+		Description     string            `json:"description"`
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return
+	}
+
+	info.setName(pkg.Name, pkg.Description)
+	info.addEcosystem(EcosystemNPM)
+	for name := range pkg.Dependencies {
+		info.addDep(EcosystemNPM, name)
+	}
+	for name := range pkg.DevDependencies {
+		info.addDep(EcosystemNPM, name)
+	}
+}
+
+// pythonRequirement extrae el nombre del paquete de una línea de requisitos ("fastapi[all]>=0.100").
+var pythonRequirement = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)`)
+
+// normalizePythonName normaliza un nombre de paquete de PyPI (PEP 503).
+func normalizePythonName(name string) string {
+	return strings.NewReplacer("_", "-", ".", "-").Replace(strings.ToLower(name))
+}
+
+// parseRequirements extrae los paquetes de un archivo requirements*.txt.
+func parseRequirements(content []byte, info *manifestInfo) {
+	info.addEcosystem(EcosystemPyPI)
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		if match := pythonRequirement.FindStringSubmatch(line); match != nil {
+			info.addDep(EcosystemPyPI, normalizePythonName(match[1]))
+		}
+	}
+}
+
+// parsePyProject extrae nombre, descripción y dependencias de pyproject.toml
+// (formato PEP 621 y Poetry) con un parseo por líneas suficiente para la detección.
+func parsePyProject(content []byte, info *manifestInfo) {
+	var (
+		section      string
+		inDepsArray  bool
+		name, descr  string
+		quotedString = regexp.MustCompile(`"([^"]+)"|'([^']+)'`)
+	)
+
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && !inDepsArray {
+			section = strings.Trim(line, "[] ")
+			continue
+		}
+
+		if inDepsArray {
+			for _, match := range quotedString.FindAllStringSubmatch(line, -1) {
+				addPythonRequirement(info, match[1]+match[2])
+			}
+			if strings.Contains(quotedString.ReplaceAllString(line, ""), "]") {
+				inDepsArray = false
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		switch {
+		case (section == "project" || section == "tool.poetry") && key == "name":
+			name = strings.Trim(value, `"'`)
+		case (section == "project" || section == "tool.poetry") && key == "description":
+			descr = strings.Trim(value, `"'`)
+		case section == "project" && key == "dependencies",
+			section == "project.optional-dependencies",
+			section == "dependency-groups":
+			for _, match := range quotedString.FindAllStringSubmatch(value, -1) {
+				addPythonRequirement(info, match[1]+match[2])
+			}
+			inDepsArray = strings.HasPrefix(value, "[") && !strings.Contains(quotedString.ReplaceAllString(value, ""), "]")
+		case strings.HasPrefix(section, "tool.poetry") && strings.HasSuffix(section, "dependencies"):
+			if key != "python" {
+				info.addDep(EcosystemPyPI, normalizePythonName(key))
+			}
+		}
+	}
+
+	info.setName(name, descr)
+	info.addEcosystem(EcosystemPyPI)
+}
+
+// addPythonRequirement registra un requisito PEP 508 ("django>=4.2").
+func addPythonRequirement(info *manifestInfo, requirement string) {
+	if match := pythonRequirement.FindStringSubmatch(requirement); match != nil {
+		info.addDep(EcosystemPyPI, normalizePythonName(match[1]))
+	}
+}
+
+// parsePipfile extrae los paquetes de las secciones [packages] y [dev-packages] de un Pipfile.
+func parsePipfile(content []byte, info *manifestInfo) {
+	info.addEcosystem(EcosystemPyPI)
+	tomlEntries(content, func(section, key, _ string) {
+		if key != "" && (section == "packages" || section == "dev-packages") {
+			info.addDep(EcosystemPyPI, normalizePythonName(key))
+		}
+	})
+}
+
+// tomlEntries recorre un TOML sencillo llamando a fn con cada cabecera de sección
+// (key vacía) y con cada asignación clave = valor. Es un parseo por líneas suficiente
+// para manifiestos: no interpreta arrays multilínea ni tablas anidadas en línea.
+func tomlEntries(content []byte, fn func(section, key, value string)) {
+	section := ""
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(strings.Trim(line, "[]"))
+			fn(section, "", "")
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		fn(section, strings.Trim(strings.TrimSpace(key), `"'`), strings.TrimSpace(value))
+	}
+}
+
+// composeFiles son los nombres habituales de los archivos de Docker Compose.
+var composeFiles = []string{"docker-compose.yml", "docker-compose.yaml", "compose.yml", "compose.yaml"}
+
+// detectComposeDatabases detecta bases de datos por las imágenes de Docker Compose.
+func (d *Detector) detectComposeDatabases() []string {
+	var databases []string
+
+	for _, file := range composeFiles {
+		content, err := os.ReadFile(filepath.Join(d.projectPath, file))
+		if err != nil {
+			continue
+		}
+
+		var compose struct {
+			Services map[string]struct {
+				Image string `yaml:"image"`
+			} `yaml:"services"`
+		}
+		if err := yaml.Unmarshal(content, &compose); err != nil {
+			continue
+		}
+
+		for _, name := range sortedServiceNames(compose.Services) {
+			image := compose.Services[name].Image
+			image = path.Base(strings.SplitN(image, ":", 2)[0])
+			if database, ok := imageDatabases[image]; ok {
+				databases = appendUnique(databases, database)
+			}
+		}
+	}
+
+	return databases
+}
+
+// sortedServiceNames retorna los nombres de servicio ordenados para un resultado determinista.
+func sortedServiceNames[T any](services map[string]T) []string {
+	names := make(map[string]bool, len(services))
+	for name := range services {
+		names[name] = true
+	}
+	return sortedKeys(names)
+}
@@ -0,0 +1,259 @@
+package detector
+
+import "strings"
+
+// dependencyRule asocia un módulo de un ecosistema a un valor detectado.
+//
+// El módulo coincide de forma exacta o como prefijo de ruta ("github.com/labstack/echo"
+// coincide con "github.com/labstack/echo/v4"), nunca como substring. En Maven, un módulo
+// terminado en ":" coincide con cualquier artefacto del groupId ("io.quarkus:").
+type dependencyRule struct {
+	ecosystem string
+	module    string
+	value     string
+}
+
+// matches indica si la dependencia coincide con la regla.
+func (r dependencyRule) matches(module string) bool {
+	if strings.HasSuffix(r.module, ":") {
+		return strings.HasPrefix(module, r.module)
+	}
+	return module == r.module || strings.HasPrefix(module, r.module+"/")
+}
+
+// matchRules retorna los valores de las reglas que coinciden con alguna dependencia,
+// en el orden de las reglas y sin duplicados.
+func matchRules(rules []dependencyRule, deps map[string]map[string]bool) []string {
+	var values []string
+	for _, rule := range rules {
+		if contains(values, rule.value) {
+			continue
+		}
+		for module := range deps[rule.ecosystem] {
+			if rule.matches(module) {
+				values = append(values, rule.value)
+				break
+			}
+		}
+	}
+	return values
+}
+
+// frameworkRules detecta frameworks (valores del catálogo del survey cuando existen).
+var frameworkRules = []dependencyRule{
+	{EcosystemGo, "github.com/gin-gonic/gin", "Gin"},
+	{EcosystemGo, "github.com/labstack/echo", "Echo"},
+	{EcosystemGo, "github.com/gofiber/fiber", "Fiber"},
+	{EcosystemGo, "github.com/go-chi/chi", "Chi"},
+	{EcosystemGo, "github.com/spf13/cobra", "Cobra"},
+	{EcosystemNPM, "@nestjs/core", "NestJS"},
+	{EcosystemNPM, "next", "Next.js"},
+	{EcosystemNPM, "nuxt", "Nuxt"},
+	{EcosystemNPM, "@angular/core", "Angular"},
+	{EcosystemNPM, "react", "React"},
+	{EcosystemNPM, "vue", "Vue"},
+	{EcosystemNPM, "svelte", "Svelte"},
+	{EcosystemNPM, "express", "Express"},
+	{EcosystemNPM, "fastify", "Fastify"},
+	{EcosystemPyPI, "django", "Django"},
+	{EcosystemPyPI, "fastapi", "FastAPI"},
+	{EcosystemPyPI, "flask", "Flask"},
+	{EcosystemCargo, "actix-web", "Actix-web"},
+	{EcosystemCargo, "axum", "Axum"},
+	{EcosystemCargo, "rocket", "Rocket"},
+	{EcosystemMaven, "org.springframework.boot:", "Spring Boot"},
+	{EcosystemMaven, "io.quarkus:", "Quarkus"},
+	{EcosystemMaven, "io.micronaut:", "Micronaut"},
+	{EcosystemMaven, "io.ktor:", "Ktor"},
+	{EcosystemPackagist, "laravel/framework", "Laravel"},
+	{EcosystemPackagist, "symfony/framework-bundle", "Symfony"},
+	{EcosystemRubyGems, "rails", "Ruby on Rails"},
+	{EcosystemRubyGems, "sinatra", "Sinatra"},
+	{EcosystemHex, "phoenix", "Phoenix"},
+	{EcosystemNuGet, "microsoft.net.sdk.web", "ASP.NET Core"},
+}
+
+// databaseRules detecta bases de datos por sus drivers.
+var databaseRules = []dependencyRule{
+	{EcosystemGo, "github.com/lib/pq", "PostgreSQL"},
+	{EcosystemGo, "github.com/jackc/pgx", "PostgreSQL"},
+	{EcosystemGo, "gorm.io/driver/postgres", "PostgreSQL"},
+	{EcosystemGo, "github.com/go-sql-driver/mysql", "MySQL"},
+	{EcosystemGo, "gorm.io/driver/mysql", "MySQL"},
+	{EcosystemGo, "github.com/mattn/go-sqlite3", "SQLite"},
+	{EcosystemGo, "modernc.org/sqlite", "SQLite"},
+	{EcosystemGo, "gorm.io/driver/sqlite", "SQLite"},
+	{EcosystemGo, "go.mongodb.org/mongo-driver", "MongoDB"},
+	{EcosystemGo, "github.com/redis/go-redis", "Redis"},
+	{EcosystemGo, "github.com/go-redis/redis", "Redis"},
+	{EcosystemNPM, "pg", "PostgreSQL"},
+	{EcosystemNPM, "postgres", "PostgreSQL"},
+	{EcosystemNPM, "mysql", "MySQL"},
+	{EcosystemNPM, "mysql2", "MySQL"},
+	{EcosystemNPM, "sqlite3", "SQLite"},
+	{EcosystemNPM, "better-sqlite3", "SQLite"},
+	{EcosystemNPM, "mongoose", "MongoDB"},
+	{EcosystemNPM, "mongodb", "MongoDB"},
+	{EcosystemNPM, "redis", "Redis"},
+	{EcosystemNPM, "ioredis", "Redis"},
+	{EcosystemPyPI, "psycopg2", "PostgreSQL"},
+	{EcosystemPyPI, "psycopg2-binary", "PostgreSQL"},
+	{EcosystemPyPI, "psycopg", "PostgreSQL"},
+	{EcosystemPyPI, "asyncpg", "PostgreSQL"},
+	{EcosystemPyPI, "pymysql", "MySQL"},
+	{EcosystemPyPI, "mysqlclient", "MySQL"},
+	{EcosystemPyPI, "pymongo", "MongoDB"},
+	{EcosystemPyPI, "motor", "MongoDB"},
+	{EcosystemPyPI, "redis", "Redis"},
+	{EcosystemCargo, "tokio-postgres", "PostgreSQL"},
+	{EcosystemCargo, "postgres", "PostgreSQL"},
+	{EcosystemCargo, "mysql", "MySQL"},
+	{EcosystemCargo, "mysql-async", "MySQL"},
+	{EcosystemCargo, "rusqlite", "SQLite"},
+	{EcosystemCargo, "mongodb", "MongoDB"},
+	{EcosystemCargo, "redis", "Redis"},
+	{EcosystemMaven, "org.postgresql:postgresql", "PostgreSQL"},
+	{EcosystemMaven, "com.mysql:mysql-connector-j", "MySQL"},
+	{EcosystemMaven, "mysql:mysql-connector-java", "MySQL"},
+	{EcosystemMaven, "org.mariadb.jdbc:mariadb-java-client", "MariaDB"},
+	{EcosystemMaven, "org.xerial:sqlite-jdbc", "SQLite"},
+	{EcosystemMaven, "com.microsoft.sqlserver:mssql-jdbc", "SQL Server"},
+	{EcosystemMaven, "org.mongodb:", "MongoDB"},
+	{EcosystemMaven, "org.springframework.boot:spring-boot-starter-data-mongodb", "MongoDB"},
+	{EcosystemMaven, "org.springframework.boot:spring-boot-starter-data-redis", "Redis"},
+	{EcosystemMaven, "redis.clients:jedis", "Redis"},
+	{EcosystemMaven, "io.lettuce:lettuce-core", "Redis"},
+	{EcosystemPackagist, "predis/predis", "Redis"},
+	{EcosystemPackagist, "mongodb/mongodb", "MongoDB"},
+	{EcosystemRubyGems, "pg", "PostgreSQL"},
+	{EcosystemRubyGems, "mysql2", "MySQL"},
+	{EcosystemRubyGems, "sqlite3", "SQLite"},
+	{EcosystemRubyGems, "mongoid", "MongoDB"},
+	{EcosystemRubyGems, "redis", "Redis"},
+	{EcosystemHex, "postgrex", "PostgreSQL"},
+	{EcosystemHex, "myxql", "MySQL"},
+	{EcosystemHex, "ecto_sqlite3", "SQLite"},
+	{EcosystemHex, "mongodb_driver", "MongoDB"},
+	{EcosystemHex, "redix", "Redis"},
+	{EcosystemNuGet, "npgsql", "PostgreSQL"},
+	{EcosystemNuGet, "npgsql.entityframeworkcore.postgresql", "PostgreSQL"},
+	{EcosystemNuGet, "mysqlconnector", "MySQL"},
+	{EcosystemNuGet, "mysql.data", "MySQL"},
+	{EcosystemNuGet, "pomelo.entityframeworkcore.mysql", "MySQL"},
+	{EcosystemNuGet, "microsoft.data.sqlclient", "SQL Server"},
+	{EcosystemNuGet, "microsoft.entityframeworkcore.sqlserver", "SQL Server"},
+	{EcosystemNuGet, "microsoft.data.sqlite", "SQLite"},
+	{EcosystemNuGet, "microsoft.entityframeworkcore.sqlite", "SQLite"},
+	{EcosystemNuGet, "mongodb.driver", "MongoDB"},
+	{EcosystemNuGet, "stackexchange.redis", "Redis"},
+}
+
+// testRules detecta frameworks de testing declarados como dependencias.
+var testRules = []dependencyRule{
+	{EcosystemGo, "github.com/stretchr/testify", "testify"},
+	{EcosystemGo, "github.com/onsi/ginkgo", "Ginkgo"},
+	{EcosystemNPM, "jest", "Jest"},
+	{EcosystemNPM, "vitest", "Vitest"},
+	{EcosystemNPM, "mocha", "Mocha"},
+	{EcosystemNPM, "cypress", "Cypress"},
+	{EcosystemNPM, "@playwright/test", "Playwright"},
+	{EcosystemNPM, "@testing-library/react", "Testing Library"},
+	{EcosystemNPM, "@testing-library/vue", "Testing Library"},
+	{EcosystemPyPI, "pytest", "pytest"},
+	{EcosystemMaven, "org.junit.jupiter:", "JUnit 5"},
+	{EcosystemMaven, "junit:junit", "JUnit"},
+	{EcosystemMaven, "org.mockito:", "Mockito"},
+	{EcosystemMaven, "io.kotest:", "Kotest"},
+	{EcosystemMaven, "org.testcontainers:", "Testcontainers"},
+	{EcosystemPackagist, "phpunit/phpunit", "PHPUnit"},
+	{EcosystemPackagist, "pestphp/pest", "Pest"},
+	{EcosystemRubyGems, "rspec", "RSpec"},
+	{EcosystemRubyGems, "rspec-rails", "RSpec"},
+	{EcosystemRubyGems, "minitest", "Minitest"},
+	{EcosystemNuGet, "xunit", "xUnit"},
+	{EcosystemNuGet, "nunit", "NUnit"},
+	{EcosystemNuGet, "mstest.testframework", "MSTest"},
+}
+
+// imageDatabases mapea imágenes de Docker a bases de datos.
+var imageDatabases = map[string]string{
+	"postgres":      "PostgreSQL",
+	"postgis":       "PostgreSQL",
+	"postgresql":    "PostgreSQL",
+	"mysql":         "MySQL",
+	"mariadb":       "MariaDB",
+	"mongo":         "MongoDB",
+	"mongodb":       "MongoDB",
+	"redis":         "Redis",
+	"elasticsearch": "Elasticsearch",
+	"opensearch":    "Elasticsearch",
+	"cassandra":     "Cassandra",
+	"mssql":         "SQL Server",
+}
+
+// ciRules detecta sistemas de CI por sus archivos de configuración.
+var ciRules = []struct {
+	path string
+	name string
+}{
+	{".github/workflows", "GitHub Actions"},
+	{".gitlab-ci.yml", "GitLab CI"},
+	{"Jenkinsfile", "Jenkins"},
+	{".circleci", "CircleCI"},
+	{"azure-pipelines.yml", "Azure Pipelines"},
+	{"bitbucket-pipelines.yml", "Bitbucket Pipelines"},
+	{".travis.yml", "Travis CI"},
+	{".drone.yml", "Drone"},
+}
+
+// architectureRules detectan arquitecturas por directorios (todos deben existir) o archivos (basta uno).
+// Las reglas más específicas van primero.
+var architectureRules = []struct {
+	architecture string
+	dirs         []string
+	files        []string
+}{
+	{architecture: "Serverless", files: []string{"serverless.yml", "serverless.yaml", "template.yaml", "samconfig.toml"}},
+	{architecture: "Hexagonal", dirs: []string{"domain", "ports", "adapters"}},
+	{architecture: "Hexagonal", dirs: []string{"domain", "application", "infrastructure"}},
+	{architecture: "Clean Architecture", dirs: []string{"entities", "usecases"}},
+	{architecture: "Clean Architecture", dirs: []string{"domain", "usecase"}},
+	{architecture: "DDD", dirs: []string{"domain", "aggregates"}},
+	{architecture: "MVC", dirs: []string{"controllers", "models", "views"}},
+	{architecture: "Layered", dirs: []string{"handlers", "services", "repositories"}},
+	{architecture: "Layered", dirs: []string{"controllers", "services", "repositories"}},
+}
+
+// categoryRules infieren la categoría del proyecto. Las reglas se evalúan en orden.
+var categoryRules = []struct {
+	category   string
+	frameworks []string
+	deps       []dependencyRule
+}{
+	{category: "Web App", frameworks: []string{"Next.js", "Nuxt", "Angular", "React", "Vue", "Svelte", "Ruby on Rails", "Laravel", "Phoenix"}},
+	{
+		category: "GraphQL API",
+		deps: []dependencyRule{
+			{EcosystemGo, "github.com/99designs/gqlgen", "graphql"},
+			{EcosystemNPM, "@apollo/server", "graphql"},
+			{EcosystemNPM, "graphql", "graphql"},
+			{EcosystemPyPI, "strawberry-graphql", "graphql"},
+			{EcosystemPyPI, "graphene", "graphql"},
+		},
+	},
+	{category: "API REST", frameworks: []string{
+		"Gin", "Echo", "Fiber", "Chi", "NestJS", "Express", "Fastify", "Django", "FastAPI", "Flask",
+		"Actix-web", "Axum", "Rocket", "Spring Boot", "Quarkus", "Micronaut", "Ktor", "Symfony", "Sinatra", "ASP.NET Core",
+	}},
+	{category: "CLI", frameworks: []string{"Cobra"}, deps: []dependencyRule{
+		{EcosystemGo, "github.com/urfave/cli", "cli"},
+		{EcosystemNPM, "commander", "cli"},
+		{EcosystemNPM, "yargs", "cli"},
+		{EcosystemPyPI, "click", "cli"},
+		{EcosystemPyPI, "typer", "cli"},
+		{EcosystemCargo, "clap", "cli"},
+		{EcosystemMaven, "info.picocli:picocli", "cli"},
+		{EcosystemRubyGems, "thor", "cli"},
+		{EcosystemNuGet, "system.commandline", "cli"},
+	}},
+}
@@ -0,0 +1,160 @@
+package detector
+
+// skillRules mapea librerías a las skills que se recomiendan generar (nombres en kebab-case).
+// Cubren bases de datos, ORMs, validación, testing y frameworks de todos los ecosistemas.
+var skillRules = []dependencyRule{
+	// Go
+	{EcosystemGo, "github.com/lib/pq", "postgresql"},
+	{EcosystemGo, "github.com/jackc/pgx", "postgresql"},
+	{EcosystemGo, "github.com/go-sql-driver/mysql", "mysql"},
+	{EcosystemGo, "github.com/mattn/go-sqlite3", "sqlite"},
+	{EcosystemGo, "modernc.org/sqlite", "sqlite"},
+	{EcosystemGo, "go.mongodb.org/mongo-driver", "mongodb"},
+	{EcosystemGo, "github.com/redis/go-redis", "redis"},
+	{EcosystemGo, "github.com/go-redis/redis", "redis"},
+	{EcosystemGo, "gorm.io/gorm", "gorm"},
+	{EcosystemGo, "github.com/jmoiron/sqlx", "sqlx"},
+	{EcosystemGo, "entgo.io/ent", "ent"},
+	{EcosystemGo, "github.com/gin-gonic/gin", "gin"},
+	{EcosystemGo, "github.com/labstack/echo", "echo"},
+	{EcosystemGo, "github.com/gofiber/fiber", "fiber"},
+	{EcosystemGo, "github.com/go-chi/chi", "chi"},
+
+	// npm
+	{EcosystemNPM, "pg", "postgresql"},
+	{EcosystemNPM, "postgres", "postgresql"},
+	{EcosystemNPM, "mysql", "mysql"},
+	{EcosystemNPM, "mysql2", "mysql"},
+	{EcosystemNPM, "mongoose", "mongodb"},
+	{EcosystemNPM, "mongodb", "mongodb"},
+	{EcosystemNPM, "redis", "redis"},
+	{EcosystemNPM, "ioredis", "redis"},
+	{EcosystemNPM, "sqlite3", "sqlite"},
+	{EcosystemNPM, "better-sqlite3", "sqlite"},
+	{EcosystemNPM, "typeorm", "typeorm"},
+	{EcosystemNPM, "prisma", "prisma"},
+	{EcosystemNPM, "@prisma/client", "prisma"},
+	{EcosystemNPM, "sequelize", "sequelize"},
+	{EcosystemNPM, "@mikro-orm/core", "mikro-orm"},
+	{EcosystemNPM, "drizzle-orm", "drizzle"},
+	{EcosystemNPM, "zod", "zod"},
+	{EcosystemNPM, "joi", "joi"},
+	{EcosystemNPM, "yup", "yup"},
+	{EcosystemNPM, "class-validator", "class-validator"},
+	{EcosystemNPM, "jest", "jest"},
+	{EcosystemNPM, "vitest", "vitest"},
+	{EcosystemNPM, "mocha", "mocha"},
+	{EcosystemNPM, "cypress", "cypress"},
+	{EcosystemNPM, "@playwright/test", "playwright"},
+	{EcosystemNPM, "@testing-library/react", "testing-library"},
+	{EcosystemNPM, "@testing-library/vue", "testing-library"},
+	{EcosystemNPM, "react", "react"},
+	{EcosystemNPM, "vue", "vue"},
+	{EcosystemNPM, "@angular/core", "angular"},
+	{EcosystemNPM, "next", "nextjs"},
+	{EcosystemNPM, "nuxt", "nuxtjs"},
+	{EcosystemNPM, "svelte", "svelte"},
+	{EcosystemNPM, "express", "express"},
+	{EcosystemNPM, "fastify", "fastify"},
+	{EcosystemNPM, "@nestjs/core", "nestjs"},
+
+	// PyPI
+	{EcosystemPyPI, "psycopg2", "postgresql"},
+	{EcosystemPyPI, "psycopg2-binary", "postgresql"},
+	{EcosystemPyPI, "psycopg", "postgresql"},
+	{EcosystemPyPI, "asyncpg", "postgresql"},
+	{EcosystemPyPI, "pymysql", "mysql"},
+	{EcosystemPyPI, "mysqlclient", "mysql"},
+	{EcosystemPyPI, "pymongo", "mongodb"},
+	{EcosystemPyPI, "motor", "mongodb"},
+	{EcosystemPyPI, "redis", "redis"},
+	{EcosystemPyPI, "sqlalchemy", "sqlalchemy"},
+	{EcosystemPyPI, "flask-sqlalchemy", "flask-sqlalchemy"},
+	{EcosystemPyPI, "tortoise-orm", "tortoise-orm"},
+	{EcosystemPyPI, "django", "django"},
+	{EcosystemPyPI, "flask", "flask"},
+	{EcosystemPyPI, "fastapi", "fastapi"},
+	{EcosystemPyPI, "pydantic", "pydantic"},
+	{EcosystemPyPI, "marshmallow", "marshmallow"},
+	{EcosystemPyPI, "pytest", "pytest"},
+
+	// Cargo
+	{EcosystemCargo, "tokio-postgres", "postgresql"},
+	{EcosystemCargo, "rusqlite", "sqlite"},
+	{EcosystemCargo, "mongodb", "mongodb"},
+	{EcosystemCargo, "redis", "redis"},
+	{EcosystemCargo, "sqlx", "sqlx"},
+	{EcosystemCargo, "diesel", "diesel"},
+	{EcosystemCargo, "sea-orm", "sea-orm"},
+	{EcosystemCargo, "tokio", "tokio"},
+	{EcosystemCargo, "serde", "serde"},
+	{EcosystemCargo, "actix-web", "actix-web"},
+	{EcosystemCargo, "axum", "axum"},
+	{EcosystemCargo, "rocket", "rocket"},
+
+	// Maven / Gradle
+	{EcosystemMaven, "org.postgresql:postgresql", "postgresql"},
+	{EcosystemMaven, "com.mysql:mysql-connector-j", "mysql"},
+	{EcosystemMaven, "mysql:mysql-connector-java", "mysql"},
+	{EcosystemMaven, "org.mongodb:", "mongodb"},
+	{EcosystemMaven, "org.springframework.boot:spring-boot-starter-data-mongodb", "mongodb"},
+	{EcosystemMaven, "org.springframework.boot:spring-boot-starter-data-redis", "redis"},
+	{EcosystemMaven, "org.springframework.boot:spring-boot-starter-data-jpa", "spring-data-jpa"},
+	{EcosystemMaven, "org.hibernate.orm:", "hibernate"},
+	{EcosystemMaven, "org.flywaydb:", "flyway"},
+	{EcosystemMaven, "org.liquibase:", "liquibase"},
+	{EcosystemMaven, "org.projectlombok:lombok", "lombok"},
+	{EcosystemMaven, "org.junit.jupiter:", "junit"},
+	{EcosystemMaven, "junit:junit", "junit"},
+	{EcosystemMaven, "org.mockito:", "mockito"},
+	{EcosystemMaven, "org.testcontainers:", "testcontainers"},
+	{EcosystemMaven, "org.springframework.boot:", "spring-boot"},
+	{EcosystemMaven, "io.quarkus:", "quarkus"},
+	{EcosystemMaven, "io.micronaut:", "micronaut"},
+	{EcosystemMaven, "io.ktor:", "ktor"},
+
+	// Packagist
+	{EcosystemPackagist, "predis/predis", "redis"},
+	{EcosystemPackagist, "doctrine/orm", "doctrine"},
+	{EcosystemPackagist, "phpunit/phpunit", "phpunit"},
+	{EcosystemPackagist, "pestphp/pest", "pest"},
+	{EcosystemPackagist, "laravel/framework", "laravel"},
+	{EcosystemPackagist, "symfony/framework-bundle", "symfony"},
+
+	// RubyGems
+	{EcosystemRubyGems, "pg", "postgresql"},
+	{EcosystemRubyGems, "mysql2", "mysql"},
+	{EcosystemRubyGems, "sqlite3", "sqlite"},
+	{EcosystemRubyGems, "redis", "redis"},
+	{EcosystemRubyGems, "mongoid", "mongodb"},
+	{EcosystemRubyGems, "sidekiq", "sidekiq"},
+	{EcosystemRubyGems, "rspec", "rspec"},
+	{EcosystemRubyGems, "rspec-rails", "rspec"},
+	{EcosystemRubyGems, "rails", "rails"},
+	{EcosystemRubyGems, "sinatra", "sinatra"},
+
+	// Hex
+	{EcosystemHex, "postgrex", "postgresql"},
+	{EcosystemHex, "myxql", "mysql"},
+	{EcosystemHex, "redix", "redis"},
+	{EcosystemHex, "ecto", "ecto"},
+	{EcosystemHex, "ecto_sql", "ecto"},
+	{EcosystemHex, "oban", "oban"},
+	{EcosystemHex, "phoenix", "phoenix"},
+	{EcosystemHex, "phoenix_live_view", "phoenix-liveview"},
+
+	// NuGet
+	{EcosystemNuGet, "npgsql", "postgresql"},
+	{EcosystemNuGet, "npgsql.entityframeworkcore.postgresql", "postgresql"},
+	{EcosystemNuGet, "microsoft.entityframeworkcore.sqlserver", "sql-server"},
+	{EcosystemNuGet, "microsoft.data.sqlclient", "sql-server"},
+	{EcosystemNuGet, "mongodb.driver", "mongodb"},
+	{EcosystemNuGet, "stackexchange.redis", "redis"},
+	{EcosystemNuGet, "microsoft.entityframeworkcore", "entity-framework-core"},
+	{EcosystemNuGet, "dapper", "dapper"},
+	{EcosystemNuGet, "fluentvalidation", "fluentvalidation"},
+	{EcosystemNuGet, "mediatr", "mediatr"},
+	{EcosystemNuGet, "xunit", "xunit"},
+	{EcosystemNuGet, "nunit", "nunit"},
+	{EcosystemNuGet, "microsoft.net.sdk.web", "aspnet-core"},
+}
@@ -0,0 +1,505 @@
+package detector
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Tipos de comando detectados en Makefiles, scripts y pasos de CI.
+const (
+	CommandBuild  = "build"
+	CommandTest   = "test"
+	CommandLint   = "lint"
+	CommandFormat = "format"
+	CommandRun    = "run"
+)
+
+// commandKinds es el orden en el que se presentan los comandos.
+var commandKinds = []string{CommandBuild, CommandTest, CommandLint, CommandFormat, CommandRun}
+
+// maxToolingDepth limita la profundidad a la que se buscan Dockerfiles, charts y módulos de Terraform.
+const maxToolingDepth = 4
+
+// Command es un comando real del proyecto y el archivo del que se obtuvo.
+type Command struct {
+	Kind    string `json:"kind" yaml:"kind"`
+	Command string `json:"command" yaml:"command"`
+	Source  string `json:"source" yaml:"source"` // Makefile, package.json, .github/workflows/ci.yml, go.mod...
+}
+
+// Tooling resume la infraestructura de build y despliegue del proyecto: targets de Make,
+// contenedores, herramientas de infraestructura y los comandos de build, test y lint.
+type Tooling struct {
+	MakeTargets    []string  `json:"make_targets,omitempty" yaml:"make_targets,omitempty"`
+	Containers     []string  `json:"containers,omitempty" yaml:"containers,omitempty"`
+	Infrastructure []string  `json:"infrastructure,omitempty" yaml:"infrastructure,omitempty"`
+	Commands       []Command `json:"commands,omitempty" yaml:"commands,omitempty"`
+	skills         []string
+}
+
+// CommandsFor retorna los comandos del tipo indicado, sin duplicados.
+func (t *Tooling) CommandsFor(kind string) []string {
+	if t == nil {
+		return nil
+	}
+	var commands []string
+	for _, command := range t.Commands {
+		if command.Kind == kind {
+			commands = appendUnique(commands, command.Command)
+		}
+	}
+	return commands
+}
+
+// Empty indica si no se detectó ninguna herramienta ni comando.
+func (t *Tooling) Empty() bool {
+	return t == nil || (len(t.MakeTargets) == 0 && len(t.Containers) == 0 &&
+		len(t.Infrastructure) == 0 && len(t.Commands) == 0)
+}
+
+// Summary formatea los comandos y la infraestructura como lista markdown para los prompts.
+func (t *Tooling) Summary() string {
+	if t.Empty() {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, kind := range commandKinds {
+		var commands []string
+		for _, command := range t.Commands {
+			if command.Kind == kind {
+				commands = append(commands, fmt.Sprintf("`%s` (%s)", command.Command, command.Source))
+			}
+		}
+		if len(commands) > 0 {
+			sb.WriteString(fmt.Sprintf("- %s commands: %s\n", strings.ToUpper(kind[:1])+kind[1:], strings.Join(commands, ", ")))
+		}
+	}
+	if len(t.MakeTargets) > 0 {
+		sb.WriteString(fmt.Sprintf("- Make targets: %s\n", strings.Join(t.MakeTargets, ", ")))
+	}
+	if len(t.Containers) > 0 {
+		sb.WriteString(fmt.Sprintf("- Containers: %s\n", strings.Join(t.Containers, "; ")))
+	}
+	if len(t.Infrastructure) > 0 {
+		sb.WriteString(fmt.Sprintf("- Infrastructure: %s\n", strings.Join(t.Infrastructure, "; ")))
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// nameKinds clasifica targets de Make y scripts de package.json por su primer segmento
+// (test:unit, build-docker, lint_fix...).
+var nameKinds = map[string]string{
+	"build":       CommandBuild,
+	"compile":     CommandBuild,
+	"dist":        CommandBuild,
+	"test":        CommandTest,
+	"tests":       CommandTest,
+	"unit":        CommandTest,
+	"integration": CommandTest,
+	"e2e":         CommandTest,
+	"coverage":    CommandTest,
+	"cover":       CommandTest,
+	"lint":        CommandLint,
+	"vet":         CommandLint,
+	"check":       CommandLint,
+	"typecheck":   CommandLint,
+	"staticcheck": CommandLint,
+	"fmt":         CommandFormat,
+	"format":      CommandFormat,
+	"prettier":    CommandFormat,
+	"run":         CommandRun,
+	"dev":         CommandRun,
+	"serve":       CommandRun,
+	"start":       CommandRun,
+}
+
+// classifyName retorna el tipo de comando de un target o script, o "" si no es relevante.
+func classifyName(name string) string {
+	first := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == ':' || r == '-' || r == '_' || r == '.'
+	})
+	if len(first) == 0 {
+		return ""
+	}
+	return nameKinds[first[0]]
+}
+
+// commandPatterns clasifica las líneas de los pasos de CI por la herramienta que invocan.
+// El orden importa: "npm run test" es un test aunque el paso también compile.
+var commandPatterns = []struct {
+	kind    string
+	pattern *regexp.Regexp
+}{
+	{CommandTest, regexp.MustCompile(`^(go test|pytest|python -m pytest|cargo test|cargo nextest|(\./)?mvnw? .*\b(test|verify)\b|\./gradlew .*\btest\b|gradle .*\btest\b|npx (jest|vitest)|jest|vitest|(bundle exec )?rspec|mix test|dotnet test|(vendor/bin/)?(phpunit|pest)|make test\b|(npm|pnpm|yarn)( run)? test)`)},
+	{CommandLint, regexp.MustCompile(`^(golangci-lint|go vet|staticcheck|npx eslint|eslint|ruff|flake8|pylint|mypy|black --check|cargo clippy|cargo fmt --check|(bundle exec )?rubocop|mix (credo|format --check-formatted)|dotnet format --verify-no-changes|make lint\b|(npm|pnpm|yarn)( run)? (lint|typecheck))`)},
+	{CommandBuild, regexp.MustCompile(`^(go build|cargo build|(\./)?mvnw? .*\b(package|install)\b|\./gradlew .*\b(build|assemble)\b|gradle .*\b(build|assemble)\b|mix compile|dotnet build|docker build|make build\b|(npm|pnpm|yarn)( run)? build)`)},
+}
+
+// classifyCommand retorna el tipo de una línea de CI, o "" si no es de build, test ni lint.
+func classifyCommand(line string) string {
+	for _, candidate := range commandPatterns {
+		if candidate.pattern.MatchString(line) {
+			return candidate.kind
+		}
+	}
+	return ""
+}
+
+// detectTooling detecta Makefile, scripts, pasos de CI, contenedores e infraestructura,
+// y deduce los comandos reales de build, test y lint del proyecto.
+func (d *Detector) detectTooling(testFrameworks []string) *Tooling {
+	tooling := &Tooling{}
+	seen := make(map[string]bool)
+	add := func(kind, command, source string) {
+		if kind == "" || seen[command] {
+			return
+		}
+		seen[command] = true
+		tooling.Commands = append(tooling.Commands, Command{Kind: kind, Command: command, Source: source})
+	}
+
+	if makefile, targets := d.makeTargets(); makefile != "" {
+		tooling.MakeTargets = targets
+		for _, target := range targets {
+			add(classifyName(target), "make "+target, makefile)
+		}
+	}
+
+	if scripts := d.packageScripts(); len(scripts) > 0 {
+		runner := d.scriptRunner()
+		for _, script := range sortedKeys(scripts) {
+			add(classifyName(script), runner+" "+script, "package.json")
+		}
+	}
+
+	for _, step := range d.ciSteps() {
+		add(classifyCommand(step.command), step.command, step.source)
+	}
+
+	// Los comandos estándar del ecosistema solo cubren los tipos que no aparecen en
+	// Makefile, scripts o CI
+	for _, command := range d.ecosystemCommands(testFrameworks) {
+		if len(tooling.CommandsFor(command.Kind)) == 0 {
+			add(command.Kind, command.Command, command.Source)
+		}
+	}
+	sort.SliceStable(tooling.Commands, func(i, j int) bool {
+		return kindIndex(tooling.Commands[i].Kind) < kindIndex(tooling.Commands[j].Kind)
+	})
+
+	d.detectInfrastructure(tooling)
+
+	if tooling.Empty() {
+		return nil
+	}
+	return tooling
+}
+
+// kindIndex retorna la posición de un tipo de comando en commandKinds.
+func kindIndex(kind string) int {
+	for i, candidate := range commandKinds {
+		if candidate == kind {
+			return i
+		}
+	}
+	return len(commandKinds)
+}
+
+// makeTarget extrae los targets declarados de un Makefile, descartando asignaciones
+// (VAR := x), reglas de patrón y targets especiales (.PHONY).
+var makeTarget = regexp.MustCompile(`(?m)^([A-Za-z0-9][A-Za-z0-9_./-]*)[ \t]*:(?:[^=:]|$)`)
+
+// makeTargets retorna el Makefile encontrado y sus targets en orden de aparición.
+func (d *Detector) makeTargets() (string, []string) {
+	for _, name := range []string{"Makefile", "makefile", "GNUmakefile"} {
+		content, err := os.ReadFile(filepath.Join(d.projectPath, name))
+		if err != nil {
+			continue
+		}
+
+		var targets []string
+		for _, match := range makeTarget.FindAllStringSubmatch(string(content), -1) {
+			targets = appendUnique(targets, match[1])
+		}
+		return name, targets
+	}
+	return "", nil
+}
+
+// scriptRunner retorna el comando con el que se ejecutan los scripts de package.json
+// según el gestor de paquetes del proyecto.
+func (d *Detector) scriptRunner() string {
+	switch {
+	case d.anyExists("pnpm-workspace.yaml", "pnpm-lock.yaml"):
+		return "pnpm run"
+	case d.exists("yarn.lock"):
+		return "yarn"
+	default:
+		return "npm run"
+	}
+}
+
+// ciStep es una línea de comando de un pipeline de CI.
+type ciStep struct {
+	source  string
+	command string
+}
+
+// gitlabReservedKeys son las claves de .gitlab-ci.yml que no definen jobs.
+var gitlabReservedKeys = map[string]bool{
+	"stages": true, "variables": true, "default": true, "include": true, "workflow": true,
+	"image": true, "services": true, "before_script": true, "after_script": true, "cache": true,
+}
+
+// ciSteps lee los pasos run de GitHub Actions y los script de GitLab CI.
+func (d *Detector) ciSteps() []ciStep {
+	var steps []ciStep
+
+	workflows, _ := filepath.Glob(filepath.Join(d.projectPath, ".github", "workflows", "*.y*ml"))
+	sort.Strings(workflows)
+	for _, file := range workflows {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+
+		var workflow struct {
+			Jobs map[string]struct {
+				Steps []struct {
+					Run string `yaml:"run"`
+				} `yaml:"steps"`
+			} `yaml:"jobs"`
+		}
+		if err := yaml.Unmarshal(content, &workflow); err != nil {
+			continue
+		}
+
+		source := ".github/workflows/" + filepath.Base(file)
+		for _, job := range sortedServiceNames(workflow.Jobs) {
+			for _, step := range workflow.Jobs[job].Steps {
+				for _, line := range commandLines(step.Run) {
+					steps = append(steps, ciStep{source: source, command: line})
+				}
+			}
+		}
+	}
+
+	if content, err := os.ReadFile(filepath.Join(d.projectPath, ".gitlab-ci.yml")); err == nil {
+		var pipeline map[string]yaml.Node
+		if err := yaml.Unmarshal(content, &pipeline); err == nil {
+			for _, name := range sortedServiceNames(pipeline) {
+				if strings.HasPrefix(name, ".") || gitlabReservedKeys[name] {
+					continue
+				}
+				node := pipeline[name]
+				var job struct {
+					Script yaml.Node `yaml:"script"`
+				}
+				if err := node.Decode(&job); err != nil {
+					continue
+				}
+				for _, script := range scriptLines(&job.Script) {
+					for _, line := range commandLines(script) {
+						steps = append(steps, ciStep{source: ".gitlab-ci.yml", command: line})
+					}
+				}
+			}
+		}
+	}
+
+	return steps
+}
+
+// scriptLines decodifica un script de GitLab CI, que puede ser una cadena o una lista.
+func scriptLines(node *yaml.Node) []string {
+	var lines []string
+	if err := node.Decode(&lines); err == nil {
+		return lines
+	}
+	var line string
+	if err := node.Decode(&line); err == nil && line != "" {
+		return []string{line}
+	}
+	return nil
+}
+
+// commandLines divide un bloque de shell en comandos, uniendo las continuaciones con "\"
+// y descartando comentarios.
+func commandLines(script string) []string {
+	var (
+		lines   []string
+		current string
+	)
+	for _, line := range strings.Split(script, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasSuffix(line, "\\") {
+			current += strings.TrimSpace(strings.TrimSuffix(line, "\\")) + " "
+			continue
+		}
+		line = strings.TrimSpace(current + line)
+		current = ""
+		if line != "" && !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// ecosystemCommands retorna los comandos estándar de los ecosistemas presentes en la raíz.
+func (d *Detector) ecosystemCommands(testFrameworks []string) []Command {
+	var commands []Command
+	add := func(source string, pairs ...string) {
+		for i := 0; i+1 < len(pairs); i += 2 {
+			commands = append(commands, Command{Kind: pairs[i], Command: pairs[i+1], Source: source})
+		}
+	}
+
+	if d.exists("go.mod") {
+		add("go.mod", CommandBuild, "go build ./...", CommandTest, "go test ./...", CommandLint, "go vet ./...")
+	}
+	if d.exists("Cargo.toml") {
+		add("Cargo.toml", CommandBuild, "cargo build", CommandTest, "cargo test", CommandLint, "cargo clippy")
+	}
+	if d.exists("pom.xml") {
+		runner := "mvn"
+		if d.exists("mvnw") {
+			runner = "./mvnw"
+		}
+		add("pom.xml", CommandBuild, runner+" package", CommandTest, runner+" test")
+	}
+	for _, file := range []string{"build.gradle.kts", "build.gradle"} {
+		if d.exists(file) {
+			runner := "gradle"
+			if d.exists("gradlew") {
+				runner = "./gradlew"
+			}
+			add(file, CommandBuild, runner+" build", CommandTest, runner+" test")
+			break
+		}
+	}
+	if d.exists("mix.exs") {
+		add("mix.exs", CommandBuild, "mix compile", CommandTest, "mix test", CommandFormat, "mix format")
+	}
+	if contains(testFrameworks, "pytest") {
+		add("pyproject.toml", CommandTest, "pytest")
+	}
+	if contains(testFrameworks, "RSpec") {
+		add("Gemfile", CommandTest, "bundle exec rspec")
+	}
+	if matches, _ := filepath.Glob(filepath.Join(d.projectPath, "*.sln")); len(matches) > 0 {
+		add(filepath.Base(matches[0]), CommandBuild, "dotnet build", CommandTest, "dotnet test")
+	}
+
+	return commands
+}
+
+// detectInfrastructure detecta Dockerfiles, Docker Compose, charts de Helm, Kustomize,
+// manifiestos de Kubernetes, Terraform y Pulumi.
+func (d *Detector) detectInfrastructure(tooling *Tooling) {
+	var (
+		dockerfiles []string
+		charts      []string
+		kustomize   []string
+		terraform   = make(map[string]bool)
+		pulumi      []string
+	)
+
+	_ = d.scanner.Walk(func(rel string, entry fs.DirEntry) error {
+		if entry.IsDir() {
+			if entry.Name() == "testdata" || strings.Count(rel, "/") >= maxToolingDepth {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		name := entry.Name()
+		dir := path.Dir(rel)
+		switch {
+		case name == "Dockerfile" || strings.HasPrefix(name, "Dockerfile.") || strings.HasSuffix(name, ".Dockerfile"):
+			dockerfiles = append(dockerfiles, rel)
+		case name == "Chart.yaml":
+			charts = append(charts, d.chartName(rel, dir))
+		case name == "kustomization.yaml" || name == "kustomization.yml":
+			kustomize = append(kustomize, dir)
+		case strings.HasSuffix(name, ".tf"):
+			terraform[dir] = true
+		case name == "Pulumi.yaml":
+			pulumi = append(pulumi, dir)
+		}
+		return nil
+	})
+
+	if len(dockerfiles) > 0 {
+		tooling.Containers = append(tooling.Containers, "Dockerfile: "+strings.Join(dockerfiles, ", "))
+		tooling.skills = appendUnique(tooling.skills, "docker")
+	}
+	for _, file := range composeFiles {
+		if services := d.composeServices(file); services != nil {
+			tooling.Containers = append(tooling.Containers, fmt.Sprintf("Docker Compose (%s): %s", file, strings.Join(services, ", ")))
+			tooling.skills = appendUnique(tooling.skills, "docker")
+		}
+	}
+
+	if len(charts) > 0 {
+		tooling.Infrastructure = append(tooling.Infrastructure, "Helm charts: "+strings.Join(charts, ", "))
+		tooling.skills = appendUnique(tooling.skills, "kubernetes", "helm")
+	}
+	if len(kustomize) > 0 {
+		tooling.Infrastructure = append(tooling.Infrastructure, "Kustomize: "+strings.Join(kustomize, ", "))
+		tooling.skills = appendUnique(tooling.skills, "kubernetes")
+	}
+	for _, dir := range []string{"k8s", "kubernetes"} {
+		if d.exists(dir) && !d.scanner.Ignored(dir, true) {
+			tooling.Infrastructure = append(tooling.Infrastructure, "Kubernetes manifests: "+dir+"/")
+			tooling.skills = appendUnique(tooling.skills, "kubernetes")
+		}
+	}
+	if len(terraform) > 0 {
+		tooling.Infrastructure = append(tooling.Infrastructure, "Terraform: "+strings.Join(sortedKeys(terraform), ", "))
+		tooling.skills = appendUnique(tooling.skills, "terraform")
+	}
+	if len(pulumi) > 0 {
+		tooling.Infrastructure = append(tooling.Infrastructure, "Pulumi: "+strings.Join(pulumi, ", "))
+		tooling.skills = appendUnique(tooling.skills, "pulumi")
+	}
+}
+
+// chartName retorna el directorio de un chart de Helm junto con su nombre, si lo declara.
+func (d *Detector) chartName(rel, dir string) string {
+	content, err := os.ReadFile(filepath.Join(d.projectPath, filepath.FromSlash(rel)))
+	if err != nil {
+		return dir
+	}
+	var chart struct {
+		Name string `yaml:"name"`
+	}
+	if yaml.Unmarshal(content, &chart) != nil || chart.Name == "" {
+		return dir
+	}
+	return fmt.Sprintf("%s (%s)", dir, chart.Name)
+}
+
+// composeServices retorna los servicios de un archivo de Docker Compose, o nil si no existe.
+func (d *Detector) composeServices(file string) []string {
+	content, err := os.ReadFile(filepath.Join(d.projectPath, file))
+	if err != nil {
+		return nil
+	}
+	var compose struct {
+		Services map[string]any `yaml:"services"`
+	}
+	if yaml.Unmarshal(content, &compose) != nil {
+		return nil
+	}
+	return append([]string{}, sortedServiceNames(compose.Services)...)
+}
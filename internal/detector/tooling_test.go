@@ -0,0 +1,117 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDetect_Tooling verifica que los comandos reales salen del Makefile, los scripts y
+// el CI, y que se detectan contenedores e infraestructura.
+func TestDetect_Tooling(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod": "module example.com/api\n",
+		"Makefile": `BINARY := api
+.PHONY: build test
+
+build:
+	go build -o bin/$(BINARY) ./cmd/api
+
+test: build
+	go test -race ./...
+
+%.pb.go: %.proto
+	protoc $<
+
+release-notes:
+	./scripts/notes.sh
+`,
+		"package.json":   `{"scripts": {"lint": "eslint .", "dev": "vite", "postinstall": "husky"}}`,
+		"pnpm-lock.yaml": "",
+		".github/workflows/ci.yml": `jobs:
+  checks:
+    steps:
+      - uses: actions/checkout@v4
+      - run: |
+          # lint
+          golangci-lint run \
+            --timeout 5m
+          go test ./...
+      - run: echo done
+`,
+		".gitlab-ci.yml": `stages: [test]
+.template:
+  script: [make secret]
+unit:
+  script: pytest -q
+`,
+		"Dockerfile":               "FROM golang:1.25\n",
+		"docker-compose.yml":       "services:\n  api:\n    build: .\n  db:\n    image: postgres:16\n",
+		"deploy/chart/Chart.yaml":  "name: api\nversion: 0.1.0\n",
+		"infra/main.tf":            "provider \"aws\" {}\n",
+		"infra/modules/vpc/vpc.tf": "",
+	})
+
+	result, err := NewDetector(dir).Detect()
+	require.NoError(t, err)
+	require.NotNil(t, result.Tooling)
+
+	tooling := result.Tooling
+	assert.Equal(t, []string{"build", "test", "release-notes"}, tooling.MakeTargets)
+	assert.Equal(t, []string{"make build"}, tooling.CommandsFor(CommandBuild))
+	assert.Equal(t, []string{"make test", "go test ./...", "pytest -q"}, tooling.CommandsFor(CommandTest))
+	assert.Equal(t, []string{"pnpm run lint", "golangci-lint run --timeout 5m"}, tooling.CommandsFor(CommandLint))
+	assert.Equal(t, []string{"pnpm run dev"}, tooling.CommandsFor(CommandRun))
+	assert.Contains(t, tooling.Commands, Command{Kind: CommandLint, Command: "golangci-lint run --timeout 5m", Source: ".github/workflows/ci.yml"})
+
+	assert.Equal(t, []string{"Dockerfile: Dockerfile", "Docker Compose (docker-compose.yml): api, db"}, tooling.Containers)
+	assert.Equal(t, []string{"Helm charts: deploy/chart (api)", "Terraform: infra, infra/modules/vpc"}, tooling.Infrastructure)
+	assert.Subset(t, result.Skills, []string{"docker", "kubernetes", "helm", "terraform"})
+
+	summary := result.Summary()
+	assert.Contains(t, summary, "- Build commands: `make build` (Makefile)")
+	assert.Contains(t, summary, "- Infrastructure: Helm charts: deploy/chart (api)")
+}
+
+// TestDetect_Tooling_EcosystemDefaults verifica que sin Makefile ni CI se usan los
+// comandos estándar del ecosistema.
+func TestDetect_Tooling_EcosystemDefaults(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"Cargo.toml":  "[package]\nname = \"cli\"\n",
+		"src/main.rs": "fn main() {}\n",
+	})
+
+	result, err := NewDetector(dir).Detect()
+	require.NoError(t, err)
+	require.NotNil(t, result.Tooling)
+
+	assert.Equal(t, []Command{
+		{Kind: CommandBuild, Command: "cargo build", Source: "Cargo.toml"},
+		{Kind: CommandTest, Command: "cargo test", Source: "Cargo.toml"},
+		{Kind: CommandLint, Command: "cargo clippy", Source: "Cargo.toml"},
+	}, result.Tooling.Commands)
+	assert.Empty(t, result.Tooling.Containers)
+}
+
+// TestClassifyCommand verifica la clasificación de las líneas de los pasos de CI.
+func TestClassifyCommand(t *testing.T) {
+	tests := map[string]string{
+		"go test -race ./...":          CommandTest,
+		"./mvnw -B verify":             CommandTest,
+		"yarn test --coverage":         CommandTest,
+		"bundle exec rubocop":          CommandLint,
+		"npm run typecheck":            CommandLint,
+		"docker build -t api .":        CommandBuild,
+		"./gradlew assemble":           CommandBuild,
+		"echo done":                    "",
+		"npm ci":                       "",
+		"actions/setup-go@v5 go build": "",
+	}
+
+	for line, kind := range tests {
+		assert.Equal(t, kind, classifyCommand(line), line)
+	}
+}
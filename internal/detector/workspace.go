@@ -0,0 +1,404 @@
+package detector
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxModuleDepth limita la profundidad a la que se buscan go.mod anidados.
+const maxModuleDepth = 4
+
+// Herramientas de monorepo detectadas.
+const (
+	ToolNPMWorkspaces  = "npm workspaces"
+	ToolYarnWorkspaces = "yarn workspaces"
+	ToolPNPMWorkspaces = "pnpm workspaces"
+	ToolNx             = "Nx"
+	ToolTurborepo      = "Turborepo"
+	ToolGoWork         = "go.work"
+	ToolGoModules      = "Go multi-module"
+	ToolCargoWorkspace = "Cargo workspace"
+	ToolMavenModules   = "Maven multi-module"
+)
+
+// Workspace describe un monorepo y sus paquetes.
+type Workspace struct {
+	Tools    []string  `json:"tools" yaml:"tools"`
+	Packages []Package `json:"packages" yaml:"packages"`
+}
+
+// Package es un paquete o módulo de un monorepo con su propio stack.
+type Package struct {
+	Name           string   `json:"name" yaml:"name"`
+	Path           string   `json:"path" yaml:"path"` // Relativo a la raíz del proyecto, con "/"
+	Language       string   `json:"language,omitempty" yaml:"language,omitempty"`
+	Frameworks     []string `json:"frameworks,omitempty" yaml:"frameworks,omitempty"`
+	Databases      []string `json:"databases,omitempty" yaml:"databases,omitempty"`
+	TestFrameworks []string `json:"test_frameworks,omitempty" yaml:"test_frameworks,omitempty"`
+	Commands       []string `json:"commands,omitempty" yaml:"commands,omitempty"`
+	Skills         []string `json:"skills,omitempty" yaml:"skills,omitempty"`
+}
+
+// Summary formatea el paquete en una línea para los prompts y la salida por consola.
+func (p Package) Summary() string {
+	parts := []string{}
+	if p.Language != "" {
+		parts = append(parts, p.Language)
+	}
+	parts = append(parts, p.Frameworks...)
+	parts = append(parts, p.Databases...)
+
+	if len(parts) == 0 {
+		return p.Path
+	}
+	return p.Path + " (" + strings.Join(parts, ", ") + ")"
+}
+
+// PackagePaths retorna las rutas de todos los paquetes del workspace.
+func (w *Workspace) PackagePaths() []string {
+	paths := make([]string, 0, len(w.Packages))
+	for _, pkg := range w.Packages {
+		paths = append(paths, pkg.Path)
+	}
+	return paths
+}
+
+// Package retorna el paquete con la ruta indicada.
+func (w *Workspace) Package(path string) (Package, bool) {
+	path = filepath.ToSlash(filepath.Clean(path))
+	for _, pkg := range w.Packages {
+		if pkg.Path == path {
+			return pkg, true
+		}
+	}
+	return Package{}, false
+}
+
+// detectWorkspace detecta si el proyecto es un monorepo y analiza cada paquete.
+// Retorna nil si el proyecto no tiene varios paquetes.
+func (d *Detector) detectWorkspace() *Workspace {
+	var (
+		tools []string
+		paths = make(map[string]bool)
+	)
+
+	add := func(tool string, found []string) {
+		if len(found) == 0 {
+			return
+		}
+		tools = appendUnique(tools, tool)
+		for _, path := range found {
+			if path != "." && path != "" {
+				paths[path] = true
+			}
+		}
+	}
+
+	npmTool := ToolNPMWorkspaces
+	if d.exists("yarn.lock") {
+		npmTool = ToolYarnWorkspaces
+	}
+	add(npmTool, d.expandPatterns(d.packageJSONWorkspaces()))
+	add(ToolPNPMWorkspaces, d.expandPatterns(d.pnpmWorkspaces()))
+	add(ToolGoWork, d.goWorkModules())
+	add(ToolGoModules, d.nestedGoModules())
+	add(ToolCargoWorkspace, d.expandPatterns(d.cargoMembers()))
+	add(ToolMavenModules, d.mavenModules())
+
+	if d.exists("nx.json") {
+		add(ToolNx, d.expandPatterns([]string{"apps/*", "libs/*", "packages/*"}))
+	}
+	if d.exists("turbo.json") && len(paths) > 0 {
+		tools = appendUnique(tools, ToolTurborepo)
+	}
+
+	if len(paths) == 0 {
+		return nil
+	}
+
+	workspace := &Workspace{Tools: tools}
+	for _, path := range sortedKeys(paths) {
+		workspace.Packages = append(workspace.Packages, d.detectPackage(path))
+	}
+	return workspace
+}
+
+// detectPackage analiza un paquete del workspace con las mismas heurísticas que la raíz.
+func (d *Detector) detectPackage(path string) Package {
+	pkgDetector := NewDetector(filepath.Join(d.projectPath, filepath.FromSlash(path)))
+	pkg := Package{Name: filepath.Base(path), Path: path}
+
+	result, err := pkgDetector.detect()
+	if err != nil {
+		return pkg
+	}
+
+	if result.Name != "" {
+		pkg.Name = result.Name
+	}
+	pkg.Language = result.Language
+	pkg.Frameworks = result.Frameworks
+	pkg.Databases = result.Databases
+	pkg.TestFrameworks = result.TestFrameworks
+	pkg.Skills = result.Skills
+	pkg.Commands = d.packageCommands(path, pkgDetector)
+
+	return pkg
+}
+
+// packageCommands deduce los comandos de build, test y lint de un paquete.
+func (d *Detector) packageCommands(path string, pkg *Detector) []string {
+	var commands []string
+
+	if scripts := pkg.packageScripts(); len(scripts) > 0 {
+		runner := d.scriptRunner()
+		for _, script := range []string{"dev", "build", "test", "lint", "typecheck"} {
+			if scripts[script] {
+				commands = append(commands, runner+" "+script)
+			}
+		}
+	}
+
+	if pkg.exists("go.mod") {
+		commands = append(commands, "go build ./...", "go test ./...")
+	}
+	if pkg.exists("Cargo.toml") {
+		commands = append(commands, "cargo build", "cargo test")
+	}
+	if pkg.exists("pom.xml") {
+		commands = append(commands, "mvn -pl "+path+" -am package", "mvn -pl "+path+" test")
+	}
+	if pkg.anyExists("pyproject.toml", "requirements.txt") && pkg.anyExists("tests", "pytest.ini", "conftest.py") {
+		commands = append(commands, "pytest")
+	}
+
+	return commands
+}
+
+// packageScripts retorna los nombres de los scripts de package.json.
+func (d *Detector) packageScripts() map[string]bool {
+	content, err := os.ReadFile(filepath.Join(d.projectPath, "package.json"))
+	if err != nil {
+		return nil
+	}
+
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil
+	}
+
+	scripts := make(map[string]bool, len(pkg.Scripts))
+	for name := range pkg.Scripts {
+		scripts[name] = true
+	}
+	return scripts
+}
+
+// packageJSONWorkspaces lee el campo workspaces de package.json (lista u objeto {packages: [...]}).
+func (d *Detector) packageJSONWorkspaces() []string {
+	content, err := os.ReadFile(filepath.Join(d.projectPath, "package.json"))
+	if err != nil {
+		return nil
+	}
+
+	var pkg struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil || len(pkg.Workspaces) == 0 {
+		return nil
+	}
+
+	var patterns []string
+	if err := json.Unmarshal(pkg.Workspaces, &patterns); err == nil {
+		return patterns
+	}
+
+	var object struct {
+		Packages []string `json:"packages"`
+	}
+	if err := json.Unmarshal(pkg.Workspaces, &object); err == nil {
+		return object.Packages
+	}
+	return nil
+}
+
+// pnpmWorkspaces lee los patrones de pnpm-workspace.yaml.
+func (d *Detector) pnpmWorkspaces() []string {
+	content, err := os.ReadFile(filepath.Join(d.projectPath, "pnpm-workspace.yaml"))
+	if err != nil {
+		return nil
+	}
+
+	var workspace struct {
+		Packages []string `yaml:"packages"`
+	}
+	if err := yaml.Unmarshal(content, &workspace); err != nil {
+		return nil
+	}
+	return workspace.Packages
+}
+
+// goWorkModules lee las directivas use de go.work (en línea y en bloque).
+func (d *Detector) goWorkModules() []string {
+	content, err := os.ReadFile(filepath.Join(d.projectPath, "go.work"))
+	if err != nil {
+		return nil
+	}
+
+	var (
+		modules []string
+		inBlock bool
+	)
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(strings.SplitN(line, "//", 2)[0])
+
+		switch {
+		case line == "use (":
+			inBlock = true
+		case inBlock && line == ")":
+			inBlock = false
+		case inBlock && line != "":
+			modules = append(modules, cleanRelPath(line))
+		case strings.HasPrefix(line, "use "):
+			modules = append(modules, cleanRelPath(strings.TrimPrefix(line, "use ")))
+		}
+	}
+	return d.existingDirs(modules)
+}
+
+// nestedGoModules busca archivos go.mod por debajo de la raíz.
+func (d *Detector) nestedGoModules() []string {
+	var modules []string
+
+	_ = d.scanner.Walk(func(rel string, entry fs.DirEntry) error {
+		if entry.IsDir() {
+			// testdata contiene módulos de ejemplo que no forman parte del proyecto
+			if entry.Name() == "testdata" || strings.Count(rel, "/") >= maxModuleDepth {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if entry.Name() == "go.mod" && rel != "go.mod" {
+			modules = append(modules, path.Dir(rel))
+		}
+		return nil
+	})
+
+	return modules
+}
+
+// cargoMembersPattern extrae la lista members de la sección [workspace] de Cargo.toml.
+var cargoMembersPattern = regexp.MustCompile(`(?s)\[workspace\].*?members\s*=\s*\[(.*?)\]`)
+
+// cargoMembers lee los miembros del workspace de Cargo.toml.
+func (d *Detector) cargoMembers() []string {
+	content, err := os.ReadFile(filepath.Join(d.projectPath, "Cargo.toml"))
+	if err != nil {
+		return nil
+	}
+
+	match := cargoMembersPattern.FindSubmatch(content)
+	if match == nil {
+		return nil
+	}
+
+	var members []string
+	for _, quoted := range regexp.MustCompile(`"([^"]+)"`).FindAllSubmatch(match[1], -1) {
+		members = append(members, string(quoted[1]))
+	}
+	return members
+}
+
+// mavenModules lee los módulos declarados en el pom.xml raíz.
+func (d *Detector) mavenModules() []string {
+	content, err := os.ReadFile(filepath.Join(d.projectPath, "pom.xml"))
+	if err != nil {
+		return nil
+	}
+
+	var pom struct {
+		Modules []string `xml:"modules>module"`
+	}
+	if err := xml.Unmarshal(content, &pom); err != nil {
+		return nil
+	}
+
+	modules := make([]string, 0, len(pom.Modules))
+	for _, module := range pom.Modules {
+		modules = append(modules, cleanRelPath(module))
+	}
+	return d.existingDirs(modules)
+}
+
+// expandPatterns expande patrones de workspace ("packages/*", "apps/**") a directorios existentes.
+// Los patrones que empiezan por "!" excluyen directorios.
+func (d *Detector) expandPatterns(patterns []string) []string {
+	included := make(map[string]bool)
+	var excluded []string
+
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if strings.HasPrefix(pattern, "!") {
+			excluded = append(excluded, cleanRelPath(strings.TrimPrefix(pattern, "!")))
+			continue
+		}
+
+		// "**" se trata como un nivel: los paquetes anidados a más profundidad son poco habituales
+		pattern = strings.ReplaceAll(cleanRelPath(pattern), "**", "*")
+		matches, err := filepath.Glob(filepath.Join(d.projectPath, filepath.FromSlash(pattern)))
+		if err != nil {
+			continue
+		}
+		for _, match := range matches {
+			rel, err := filepath.Rel(d.projectPath, match)
+			if err != nil {
+				continue
+			}
+			rel = filepath.ToSlash(rel)
+			if info, err := os.Stat(match); err == nil && info.IsDir() && !d.scanner.Ignored(rel, true) {
+				included[rel] = true
+			}
+		}
+	}
+
+	for path := range included {
+		for _, pattern := range excluded {
+			if matched, _ := filepath.Match(pattern, path); matched || path == pattern {
+				delete(included, path)
+			}
+		}
+	}
+
+	return sortedKeys(included)
+}
+
+// existingDirs filtra las rutas relativas que existen como directorio.
+func (d *Detector) existingDirs(paths []string) []string {
+	var dirs []string
+	for _, path := range paths {
+		if info, err := os.Stat(filepath.Join(d.projectPath, filepath.FromSlash(path))); err == nil && info.IsDir() {
+			dirs = append(dirs, path)
+		}
+	}
+	return dirs
+}
+
+// cleanRelPath normaliza una ruta relativa de un manifiesto ("./api/" -> "api").
+func cleanRelPath(path string) string {
+	path = strings.Trim(strings.TrimSpace(path), `"'`)
+	return strings.TrimPrefix(filepath.ToSlash(filepath.Clean(path)), "./")
+}
@@ -0,0 +1,126 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDetect_NPMWorkspaces verifica los workspaces de package.json con exclusiones.
+func TestDetect_NPMWorkspaces(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"package.json":                 `{"name": "root", "workspaces": {"packages": ["apps/*", "packages/**", "!packages/legacy"]}}`,
+		"turbo.json":                   "{}",
+		"yarn.lock":                    "",
+		"apps/web/package.json":        `{"name": "@acme/web", "dependencies": {"next": "14"}, "scripts": {"dev": "next dev", "build": "next build", "lint": "next lint"}}`,
+		"apps/api/package.json":        `{"name": "@acme/api", "dependencies": {"express": "4", "pg": "8"}, "scripts": {"test": "jest"}}`,
+		"packages/ui/package.json":     `{"name": "@acme/ui"}`,
+		"packages/legacy/package.json": `{"name": "legacy"}`,
+	})
+
+	result, err := NewDetector(dir).Detect()
+	require.NoError(t, err)
+	require.NotNil(t, result.Workspace)
+
+	assert.Equal(t, []string{ToolYarnWorkspaces, ToolTurborepo}, result.Workspace.Tools)
+	assert.Equal(t, []string{"apps/api", "apps/web", "packages/ui"}, result.Workspace.PackagePaths())
+
+	api, ok := result.Workspace.Package("./apps/api")
+	require.True(t, ok)
+	assert.Equal(t, "@acme/api", api.Name)
+	assert.Equal(t, []string{"Express"}, api.Frameworks)
+	assert.Equal(t, []string{"PostgreSQL"}, api.Databases)
+	assert.Equal(t, []string{"yarn test"}, api.Commands)
+
+	web, ok := result.Workspace.Package("apps/web")
+	require.True(t, ok)
+	assert.Equal(t, []string{"yarn dev", "yarn build", "yarn lint"}, web.Commands)
+	assert.Equal(t, "apps/web (JavaScript, Next.js)", web.Summary())
+}
+
+// TestDetect_PNPMWorkspace verifica pnpm-workspace.yaml y Nx.
+func TestDetect_PNPMWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"pnpm-workspace.yaml":        "packages:\n  - 'services/*'\n",
+		"nx.json":                    "{}",
+		"services/auth/package.json": `{"name": "auth", "scripts": {"build": "tsc"}}`,
+		"libs/shared/project.json":   "{}",
+	})
+
+	result, err := NewDetector(dir).Detect()
+	require.NoError(t, err)
+	require.NotNil(t, result.Workspace)
+
+	assert.Equal(t, []string{ToolPNPMWorkspaces, ToolNx}, result.Workspace.Tools)
+	assert.Equal(t, []string{"libs/shared", "services/auth"}, result.Workspace.PackagePaths())
+
+	auth, _ := result.Workspace.Package("services/auth")
+	assert.Equal(t, []string{"pnpm run build"}, auth.Commands)
+}
+
+// TestDetect_GoWorkspace verifica go.work y los go.mod anidados.
+func TestDetect_GoWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.work":                 "go 1.22\n\nuse (\n\t./api\n\t./worker // jobs\n)\nuse ./missing\n",
+		"api/go.mod":              "module example.com/api\n\nrequire github.com/labstack/echo/v4 v4.11.0\n",
+		"api/main.go":             "package main",
+		"worker/go.mod":           "module example.com/worker\n",
+		"tools/lint/go.mod":       "module example.com/tools/lint\n",
+		"api/testdata/mod/go.mod": "module example.com/fixture\n",
+		"node_modules/pkg/go.mod": "module example.com/ignored\n",
+	})
+
+	result, err := NewDetector(dir).Detect()
+	require.NoError(t, err)
+	require.NotNil(t, result.Workspace)
+
+	assert.Equal(t, []string{ToolGoWork, ToolGoModules}, result.Workspace.Tools)
+	assert.Equal(t, []string{"api", "tools/lint", "worker"}, result.Workspace.PackagePaths())
+
+	api, _ := result.Workspace.Package("api")
+	assert.Equal(t, "api", api.Name)
+	assert.Equal(t, "Go", api.Language)
+	assert.Equal(t, []string{"Echo"}, api.Frameworks)
+	assert.Equal(t, []string{"go build ./...", "go test ./..."}, api.Commands)
+}
+
+// TestDetect_CargoAndMavenWorkspaces verifica los workspaces de Cargo y los módulos de Maven.
+func TestDetect_CargoAndMavenWorkspaces(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"Cargo.toml":             "[workspace]\nmembers = [\n  \"crates/*\",\n]\n",
+		"crates/core/Cargo.toml": "[package]\nname = \"core\"\n",
+		"pom.xml":                "<project><modules><module>billing</module><module>./gone</module></modules></project>",
+		"billing/pom.xml":        "<project></project>",
+	})
+
+	result, err := NewDetector(dir).Detect()
+	require.NoError(t, err)
+	require.NotNil(t, result.Workspace)
+
+	assert.Equal(t, []string{ToolCargoWorkspace, ToolMavenModules}, result.Workspace.Tools)
+	assert.Equal(t, []string{"billing", "crates/core"}, result.Workspace.PackagePaths())
+
+	billing, _ := result.Workspace.Package("billing")
+	assert.Equal(t, []string{"mvn -pl billing -am package", "mvn -pl billing test"}, billing.Commands)
+	core, _ := result.Workspace.Package("crates/core")
+	assert.Equal(t, []string{"cargo build", "cargo test"}, core.Commands)
+}
+
+// TestDetect_SinglePackage_NoWorkspace verifica que un proyecto simple no se trata como monorepo.
+func TestDetect_SinglePackage_NoWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"package.json": `{"name": "single"}`,
+		"src/index.js": "",
+	})
+
+	result, err := NewDetector(dir).Detect()
+	require.NoError(t, err)
+	assert.Nil(t, result.Workspace)
+	assert.NotContains(t, result.Summary(), "Packages")
+}
@@ -0,0 +1,283 @@
+// Package hooks propone los hooks de Claude Code de un proyecto a partir de sus herramientas:
+// formatear cada archivo editado, pasar el lint antes de un commit y recordar los tests
+// antes de terminar. Solo se proponen los hooks cuyas herramientas existen en el proyecto, y
+// cada uno se añade a .claude/settings.json solo si el usuario lo elige.
+package hooks
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gSurvey "github.com/AlecAivazis/survey/v2"
+	"github.com/drossan/claude-init/internal/detector"
+)
+
+// Nombres de los hooks, para elegirlos con --hooks.
+const (
+	HookFormat = "format" // Formatea los archivos editados
+	HookLint   = "lint"   // Bloquea los commits si falla el lint
+	HookTest   = "test"   // Recuerda ejecutar los tests antes de terminar
+)
+
+// Valores especiales de --hooks.
+const (
+	SelectAll  = "all"
+	SelectNone = "none"
+)
+
+// Eventos de Claude Code en los que se ejecutan los hooks.
+const (
+	EventPreToolUse  = "PreToolUse"
+	EventPostToolUse = "PostToolUse"
+	EventStop        = "Stop"
+)
+
+// editTools es el matcher de las herramientas que modifican archivos.
+const editTools = "Edit|MultiEdit|Write"
+
+// Hook es un hook de Claude Code: un comando que se ejecuta en un evento, para las
+// herramientas que cumplen matcher.
+type Hook struct {
+	Name        string
+	Event       string
+	Matcher     string // Vacío para los eventos sin herramienta, como Stop
+	Command     string
+	Description string
+}
+
+// formatter es un formateador y los archivos a los que se aplica.
+type formatter struct {
+	name     string
+	patterns string // Patrones de case de shell
+	command  string // Recibe el archivo en "$f"
+}
+
+// sourceExtensions son las extensiones de código de cada ecosistema, para saber si hay
+// cambios que probar.
+var sourceExtensions = map[string]string{
+	detector.EcosystemGo:    "go",
+	detector.EcosystemNPM:   "js|jsx|ts|tsx|mjs|cjs",
+	detector.EcosystemPyPI:  "py",
+	detector.EcosystemCargo: "rs",
+}
+
+// prettierConfigs son los archivos de configuración de Prettier.
+var prettierConfigs = []string{
+	".prettierrc", ".prettierrc.json", ".prettierrc.yaml", ".prettierrc.yml", ".prettierrc.js",
+	".prettierrc.cjs", ".prettierrc.mjs", "prettier.config.js", "prettier.config.cjs", "prettier.config.mjs",
+}
+
+// requireJQ termina el hook sin hacer nada si jq no está instalado: sin él no se puede leer
+// la entrada del hook, y los hooks de lint y test bloquearían o dejarían pasar todo.
+const requireJQ = "command -v jq >/dev/null || exit 0; "
+
+// Available retorna los hooks que se pueden generar para el proyecto, según los
+// formateadores y los comandos de lint y test detectados. result puede ser nil.
+func Available(projectPath string, result *detector.Result) []Hook {
+	var hooks []Hook
+	if hook, ok := formatHook(projectPath, result); ok {
+		hooks = append(hooks, hook)
+	}
+
+	var tooling *detector.Tooling
+	if result != nil {
+		tooling = result.Tooling
+	}
+	if lint := tooling.CommandsFor(detector.CommandLint); len(lint) > 0 {
+		hooks = append(hooks, Hook{
+			Name:    HookLint,
+			Event:   EventPreToolUse,
+			Matcher: "Bash",
+			Command: fmt.Sprintf(requireJQ+`jq -r '.tool_input.command // empty' | grep -q 'git commit' || exit 0; (%s) >&2 || { echo 'Lint failed: fix the issues before committing.' >&2; exit 2; }`,
+				lint[0]),
+			Description: fmt.Sprintf("run %s before every git commit and block the commit if it fails", lint[0]),
+		})
+	}
+	if test := tooling.CommandsFor(detector.CommandTest); len(test) > 0 {
+		changed := `test -n "$(git status --porcelain)" || exit 0`
+		if extensions := changedExtensions(result); extensions != "" {
+			changed = fmt.Sprintf(`git status --porcelain | grep -qE '\.(%s)$' || exit 0`, extensions)
+		}
+		hooks = append(hooks, Hook{
+			Name:  HookTest,
+			Event: EventStop,
+			Command: fmt.Sprintf(requireJQ+`jq -e '.stop_hook_active == true' >/dev/null && exit 0; %s; echo 'Source files changed: run %s and fix any failure before finishing.' >&2; exit 2`,
+				changed, test[0]),
+			Description: fmt.Sprintf("before finishing with uncommitted source changes, remind Claude to run %s (once per stop)", test[0]),
+		})
+	}
+	return hooks
+}
+
+// formatHook retorna el hook que formatea los archivos editados con los formateadores del
+// proyecto, si hay alguno.
+func formatHook(projectPath string, result *detector.Result) (Hook, bool) {
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(projectPath, name))
+		return err == nil
+	}
+	hasDependency := func(ecosystem, module string) bool {
+		if result == nil {
+			return false
+		}
+		for _, dep := range result.Dependencies[ecosystem] {
+			if strings.EqualFold(dep, module) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var formatters []formatter
+	if exists("go.mod") {
+		formatters = append(formatters, formatter{"gofmt", "*.go", `gofmt -w "$f"`})
+	}
+	if exists("Cargo.toml") {
+		formatters = append(formatters, formatter{"rustfmt", "*.rs", `rustfmt "$f"`})
+	}
+	if hasDependency(detector.EcosystemPyPI, "black") || fileContains(filepath.Join(projectPath, "pyproject.toml"), "[tool.black]") {
+		formatters = append(formatters, formatter{"black", "*.py", `black -q "$f"`})
+	}
+	prettier := hasDependency(detector.EcosystemNPM, "prettier")
+	for _, config := range prettierConfigs {
+		prettier = prettier || exists(config)
+	}
+	if prettier {
+		formatters = append(formatters, formatter{"prettier", "*.js|*.jsx|*.ts|*.tsx|*.mjs|*.cjs|*.css|*.scss|*.json", `npx --no-install prettier --write "$f"`})
+	}
+	if len(formatters) == 0 {
+		return Hook{}, false
+	}
+
+	var cases, names []string
+	for _, f := range formatters {
+		cases = append(cases, fmt.Sprintf("%s) %s ;;", f.patterns, f.command))
+		names = append(names, f.name)
+	}
+	return Hook{
+		Name:        HookFormat,
+		Event:       EventPostToolUse,
+		Matcher:     editTools,
+		Command:     fmt.Sprintf(`f=$(jq -r '.tool_input.file_path // empty'); case "$f" in %s esac`, strings.Join(cases, " ")),
+		Description: "format every file Claude edits with " + strings.Join(names, ", "),
+	}, true
+}
+
+// changedExtensions retorna las extensiones de código de los ecosistemas del proyecto,
+// separadas por |, o "" si no se conoce ninguna.
+func changedExtensions(result *detector.Result) string {
+	if result == nil {
+		return ""
+	}
+	var extensions []string
+	for _, ecosystem := range []string{detector.EcosystemGo, detector.EcosystemNPM, detector.EcosystemPyPI, detector.EcosystemCargo} {
+		if _, ok := result.Dependencies[ecosystem]; ok {
+			extensions = append(extensions, sourceExtensions[ecosystem])
+		}
+	}
+	return strings.Join(extensions, "|")
+}
+
+// fileContains indica si el archivo existe y contiene text.
+func fileContains(path, text string) bool {
+	content, err := os.ReadFile(path)
+	return err == nil && strings.Contains(string(content), text)
+}
+
+// Select retorna los hooks de available elegidos por nombre. "all" elige todos y "none"
+// ninguno. Es un error pedir un hook desconocido o que el proyecto no permite generar.
+func Select(available []Hook, names []string) ([]Hook, error) {
+	var selected []Hook
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "", SelectNone:
+			continue
+		case SelectAll:
+			return available, nil
+		case HookFormat, HookLint, HookTest:
+		default:
+			return nil, fmt.Errorf("unknown hook %q (use %s, %s, %s, %s or %s)", name, HookFormat, HookLint, HookTest, SelectAll, SelectNone)
+		}
+
+		found := false
+		for _, hook := range available {
+			if hook.Name == name {
+				selected = appendHook(selected, hook)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("hook %q is not available: no matching tool was detected in the project", name)
+		}
+	}
+	return selected, nil
+}
+
+// appendHook añade hook a la lista si aún no está.
+func appendHook(list []Hook, hook Hook) []Hook {
+	for _, existing := range list {
+		if existing.Name == hook.Name {
+			return list
+		}
+	}
+	return append(list, hook)
+}
+
+// WritePreview escribe en out qué hace un hook y el comando que se añadiría.
+func WritePreview(out io.Writer, hook Hook) {
+	on := hook.Event
+	if hook.Matcher != "" {
+		on += " on " + hook.Matcher
+	}
+	fmt.Fprintf(out, "Hook %q (%s): %s\n  command: %s\n", hook.Name, on, hook.Description, hook.Command)
+}
+
+// Confirmer pregunta si se añade un hook.
+type Confirmer func(hook Hook) (bool, error)
+
+// Choose elige los hooks a generar entre los disponibles. Si se indican nombres (--hooks),
+// se eligen con Select; si no, y hay confirm, se muestra la vista previa de cada hook y se
+// pregunta por él. Sin nombres ni confirm no se elige ninguno. La vista previa de los
+// hooks elegidos por nombre también se escribe en out.
+func Choose(out io.Writer, available []Hook, names []string, confirm Confirmer) ([]Hook, error) {
+	if len(names) > 0 {
+		selected, err := Select(available, names)
+		if err != nil {
+			return nil, err
+		}
+		for _, hook := range selected {
+			WritePreview(out, hook)
+		}
+		return selected, nil
+	}
+	if confirm == nil {
+		return nil, nil
+	}
+
+	var selected []Hook
+	for _, hook := range available {
+		WritePreview(out, hook)
+		ok, err := confirm(hook)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			selected = append(selected, hook)
+		}
+	}
+	return selected, nil
+}
+
+// SurveyConfirm pregunta en la terminal si se añade el hook. Por defecto, no.
+func SurveyConfirm(hook Hook) (bool, error) {
+	add := false
+	prompt := &gSurvey.Confirm{Message: fmt.Sprintf("Add the %s hook to .claude/settings.json?", hook.Name), Default: false}
+	if err := gSurvey.AskOne(prompt, &add); err != nil {
+		return false, err
+	}
+	return add, nil
+}
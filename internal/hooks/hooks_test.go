@@ -0,0 +1,132 @@
+package hooks
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/detector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAvailable verifica que solo se proponen los hooks de las herramientas del proyecto.
+func TestAvailable(t *testing.T) {
+	dir := t.TempDir()
+	assert.Empty(t, Available(dir, nil))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/acme\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".prettierrc"), []byte("{}"), 0644))
+	result := &detector.Result{
+		Dependencies: map[string][]string{detector.EcosystemGo: {"github.com/spf13/cobra"}},
+		Tooling: &detector.Tooling{Commands: []detector.Command{
+			{Kind: detector.CommandLint, Command: "golangci-lint run", Source: "Makefile"},
+			{Kind: detector.CommandTest, Command: "go test ./...", Source: "go.mod"},
+		}},
+	}
+
+	hooks := Available(dir, result)
+	require.Len(t, hooks, 3)
+
+	format := hooks[0]
+	assert.Equal(t, HookFormat, format.Name)
+	assert.Equal(t, EventPostToolUse, format.Event)
+	assert.Equal(t, "Edit|MultiEdit|Write", format.Matcher)
+	assert.Contains(t, format.Command, `*.go) gofmt -w "$f" ;;`)
+	assert.Contains(t, format.Command, `npx --no-install prettier --write "$f"`)
+	assert.NotContains(t, format.Command, "black")
+	assert.Equal(t, "format every file Claude edits with gofmt, prettier", format.Description)
+
+	lint := hooks[1]
+	assert.Equal(t, EventPreToolUse, lint.Event)
+	assert.Equal(t, "Bash", lint.Matcher)
+	assert.Contains(t, lint.Command, "(golangci-lint run) >&2 ||")
+	assert.Contains(t, lint.Command, "exit 2")
+
+	test := hooks[2]
+	assert.Equal(t, EventStop, test.Event)
+	assert.Empty(t, test.Matcher)
+	assert.Contains(t, test.Command, ".stop_hook_active")
+	assert.Contains(t, test.Command, `grep -qE '\.(go)$'`)
+	assert.Contains(t, test.Command, "run go test ./...")
+}
+
+// TestAvailable_WithoutJQ verifica que, sin jq instalado, los hooks de lint y test terminan
+// sin hacer nada: el de test no puede detectar que ya ha avisado y no dejaría terminar a
+// Claude nunca.
+func TestAvailable_WithoutJQ(t *testing.T) {
+	bin := t.TempDir()
+	for _, tool := range []string{"sh", "git", "grep"} {
+		path, err := exec.LookPath(tool)
+		if err != nil {
+			t.Skipf("%s not available", tool)
+		}
+		require.NoError(t, os.Symlink(path, filepath.Join(bin, tool)))
+	}
+
+	dir := t.TempDir()
+	gitInit := exec.Command("git", "init", "-q", dir)
+	require.NoError(t, gitInit.Run())
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644))
+
+	result := &detector.Result{Tooling: &detector.Tooling{Commands: []detector.Command{
+		{Kind: detector.CommandLint, Command: "false", Source: "Makefile"},
+		{Kind: detector.CommandTest, Command: "false", Source: "Makefile"},
+	}}}
+	hooks := Available(dir, result)
+	require.Len(t, hooks, 2)
+	for _, hook := range hooks {
+		cmd := exec.Command(filepath.Join(bin, "sh"), "-c", hook.Command)
+		cmd.Dir = dir
+		cmd.Env = []string{"PATH=" + bin, "HOME=" + dir}
+		cmd.Stdin = strings.NewReader(`{"tool_input": {"command": "git commit -m wip"}, "stop_hook_active": true}`)
+		assert.NoError(t, cmd.Run(), hook.Name)
+	}
+}
+
+// TestChoose verifica la elección por nombre y la confirmación de cada hook.
+func TestChoose(t *testing.T) {
+	available := []Hook{
+		{Name: HookFormat, Event: EventPostToolUse, Matcher: "Edit|MultiEdit|Write", Command: "fmt", Description: "format"},
+		{Name: HookTest, Event: EventStop, Command: "test", Description: "test"},
+	}
+
+	var out bytes.Buffer
+	selected, err := Choose(&out, available, []string{"test", "TEST"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []Hook{available[1]}, selected)
+	assert.Equal(t, "Hook \"test\" (Stop): test\n  command: test\n", out.String())
+
+	selected, err = Choose(&out, available, []string{"all"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, available, selected)
+
+	selected, err = Choose(&out, available, []string{"none"}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, selected)
+
+	_, err = Choose(&out, available, []string{"lint"}, nil)
+	assert.ErrorContains(t, err, "not available")
+	_, err = Choose(&out, available, []string{"deploy"}, nil)
+	assert.ErrorContains(t, err, "unknown hook")
+
+	// Sin nombres ni confirmación no se elige ninguno
+	selected, err = Choose(&out, available, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, selected)
+
+	// Con confirmación se muestra cada hook y se añaden los aceptados
+	out.Reset()
+	var asked []string
+	selected, err = Choose(&out, available, nil, func(hook Hook) (bool, error) {
+		asked = append(asked, hook.Name)
+		return hook.Name == HookFormat, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{HookFormat, HookTest}, asked)
+	assert.Equal(t, []Hook{available[0]}, selected)
+	assert.Contains(t, out.String(), `Hook "format" (PostToolUse on Edit|MultiEdit|Write): format`)
+}
@@ -0,0 +1,125 @@
+// Package jsonobject lee y reescribe objetos JSON conservando el orden de sus claves y el
+// contenido original de sus valores. Se usa para fusionar archivos de configuración del
+// usuario (settings.json, .mcp.json) sin reordenar ni reformatear lo que ya contienen.
+package jsonobject
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Object es un objeto JSON que conserva el orden de sus claves.
+type Object struct {
+	keys   []string
+	values map[string]json.RawMessage
+}
+
+// New crea un objeto vacío.
+func New() *Object {
+	return &Object{values: map[string]json.RawMessage{}}
+}
+
+// Parse decodifica un objeto JSON manteniendo el orden de sus claves. Es un error que data
+// no sea un objeto o que tenga contenido tras él.
+func Parse(data []byte) (*Object, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, fmt.Errorf("expected an object")
+	}
+
+	obj := New()
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := token.(string)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		if _, ok := obj.values[key]; !ok {
+			obj.keys = append(obj.keys, key)
+		}
+		obj.values[key] = value
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected content after the object")
+	}
+	return obj, nil
+}
+
+// Get retorna el valor de key, sin decodificar.
+func (o *Object) Get(key string) (json.RawMessage, bool) {
+	value, ok := o.values[key]
+	return value, ok
+}
+
+// Set establece el valor de key. Las claves nuevas se añaden al final.
+func (o *Object) Set(key string, value any) error {
+	raw, err := Marshal(value)
+	if err != nil {
+		return err
+	}
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = raw
+	return nil
+}
+
+// MarshalJSON codifica el objeto con sus claves en orden.
+func (o *Object) MarshalJSON() ([]byte, error) {
+	var out bytes.Buffer
+	out.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			out.WriteByte(',')
+		}
+		name, err := Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		out.Write(name)
+		out.WriteByte(':')
+		out.Write(o.values[key])
+	}
+	out.WriteByte('}')
+	return out.Bytes(), nil
+}
+
+// Format retorna el objeto indentado con dos espacios y terminado en salto de línea, como
+// se escribe en un archivo.
+func (o *Object) Format() ([]byte, error) {
+	compact, err := Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, compact, "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+// Marshal codifica value sin escapar los caracteres HTML, que aparecen en comandos y URLs
+// (&&, <, >).
+func Marshal(value any) (json.RawMessage, error) {
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(out.Bytes(), "\n"), nil
+}
@@ -0,0 +1,41 @@
+package jsonobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestObject verifica que las claves conservan su orden y sus valores, que las nuevas se
+// añaden al final y que solo se aceptan objetos.
+func TestObject(t *testing.T) {
+	obj, err := Parse([]byte(`{"zeta": {"b": 1, "a": 2}, "alpha": 1.50, "cmd": "a && b"}`))
+	require.NoError(t, err)
+
+	value, ok := obj.Get("zeta")
+	require.True(t, ok)
+	assert.Equal(t, `{"b": 1, "a": 2}`, string(value))
+
+	require.NoError(t, obj.Set("cmd", "make build && make test"))
+	require.NoError(t, obj.Set("new", []string{"<x>"}))
+	content, err := obj.Format()
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "zeta": {
+    "b": 1,
+    "a": 2
+  },
+  "alpha": 1.50,
+  "cmd": "make build && make test",
+  "new": [
+    "<x>"
+  ]
+}
+`, string(content))
+
+	for _, invalid := range []string{`[]`, `null`, `{"a": 1} {}`, `{not json`} {
+		_, err := Parse([]byte(invalid))
+		assert.Error(t, err, invalid)
+	}
+}
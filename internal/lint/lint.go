@@ -0,0 +1,382 @@
+// Package lint valida el frontmatter de los agents, skills y commands de Claude Code: que
+// exista cuando es obligatorio, que sea YAML válido y que sus campos tengan los valores que
+// Claude Code acepta (nombres, descripciones, herramientas y modelos).
+package lint
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Tipos de archivo que se validan.
+const (
+	KindAgent   = "agent"
+	KindSkill   = "skill"
+	KindCommand = "command"
+)
+
+// Severidades de un problema. Solo los errores hacen que Claude Code rechace el archivo.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Límites de los campos de una skill.
+const (
+	maxSkillNameLength        = 64
+	maxSkillDescriptionLength = 1024
+)
+
+// namePattern es el formato de los nombres de agents y skills: minúsculas, dígitos y guiones.
+var namePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// knownTools son las herramientas integradas de Claude Code. Las de servidores MCP
+// (mcp__<servidor>__<herramienta>) se aceptan siempre.
+var knownTools = map[string]bool{
+	"Bash": true, "BashOutput": true, "Edit": true, "ExitPlanMode": true, "Glob": true,
+	"Grep": true, "KillShell": true, "LS": true, "MultiEdit": true, "NotebookEdit": true,
+	"NotebookRead": true, "Read": true, "SlashCommand": true, "Skill": true, "Task": true,
+	"TodoWrite": true, "WebFetch": true, "WebSearch": true, "Write": true,
+}
+
+// modelAliases son los alias de modelo que aceptan agents y commands, además de los IDs
+// completos (claude-*).
+var modelAliases = map[string]bool{"sonnet": true, "opus": true, "haiku": true, "inherit": true}
+
+// agentColors son los colores con nombre de los agents; también se aceptan colores hex.
+var agentColors = map[string]bool{
+	"red": true, "blue": true, "green": true, "yellow": true, "purple": true,
+	"orange": true, "pink": true, "cyan": true, "gray": true,
+}
+
+var hexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Issue es un problema encontrado en un archivo.
+type Issue struct {
+	Path     string `json:"path"`
+	Severity string `json:"severity"`
+	Field    string `json:"field,omitempty"` // Campo del frontmatter; vacío si afecta al archivo
+	Message  string `json:"message"`
+}
+
+// String formatea el problema en una línea.
+func (i Issue) String() string {
+	if i.Field != "" {
+		return fmt.Sprintf("%s: %s: %s: %s", i.Path, i.Severity, i.Field, i.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", i.Path, i.Severity, i.Message)
+}
+
+// Report es el resultado de validar un directorio .claude.
+type Report struct {
+	Files    int     `json:"files"`
+	Errors   int     `json:"errors"`
+	Warnings int     `json:"warnings"`
+	Issues   []Issue `json:"issues"`
+}
+
+// HasErrors indica si alguno de los problemas es un error.
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Content valida el contenido de un archivo del tipo indicado. path solo se usa para
+// identificar el archivo en los problemas.
+func Content(kind, path, content string) []Issue {
+	c := &checker{path: path}
+
+	frontmatter, found, err := splitFrontmatter(content)
+	switch {
+	case err != nil:
+		c.error("", err.Error())
+		return c.issues
+	case !found && kind == KindCommand:
+		c.warning("description", "no frontmatter: Claude Code will use the first line as the description")
+		return c.issues
+	case !found:
+		c.error("", "missing YAML frontmatter (the file must start with ---)")
+		return c.issues
+	}
+
+	fields := map[string]any{}
+	if err := yaml.Unmarshal([]byte(frontmatter), &fields); err != nil {
+		c.error("", fmt.Sprintf("invalid YAML frontmatter: %v", yamlMessage(err)))
+		return c.issues
+	}
+
+	switch kind {
+	case KindAgent:
+		c.checkName(fields, 0)
+		c.checkDescription(fields, 0, true)
+		c.checkTools(fields, "tools")
+		c.checkModel(fields)
+		c.checkColor(fields)
+	case KindSkill:
+		c.checkName(fields, maxSkillNameLength)
+		c.checkSkillDirectory(fields)
+		c.checkDescription(fields, maxSkillDescriptionLength, true)
+		c.checkTools(fields, "allowed-tools")
+	case KindCommand:
+		c.checkDescription(fields, 0, false)
+		c.checkTools(fields, "allowed-tools")
+		c.checkModel(fields)
+	}
+	return c.issues
+}
+
+// SkillFile es el archivo principal de una skill dentro de su directorio (skills/<name>/).
+const SkillFile = "SKILL.md"
+
+// Dir valida los agents, skills y commands de un directorio .claude. Los README.md de
+// índice no se validan. De las skills se valida el SKILL.md de cada directorio, no sus
+// archivos de apoyo; las skills planas (skills/<name>.md) se validan y se avisa de que
+// Claude Code no las carga.
+func Dir(claudeDir string) (*Report, error) {
+	info, err := os.Stat(claudeDir)
+	if err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", claudeDir)
+	}
+
+	report := &Report{Issues: []Issue{}}
+	kinds := []struct{ dir, kind string }{
+		{"agents", KindAgent},
+		{"skills", KindSkill},
+		{"commands", KindCommand},
+	}
+	for _, k := range kinds {
+		root := filepath.Join(claudeDir, k.dir)
+		if _, err := os.Stat(root); os.IsNotExist(err) {
+			continue
+		}
+		err := filepath.WalkDir(root, func(p string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() || filepath.Ext(p) != ".md" || entry.Name() == "README.md" {
+				return nil
+			}
+			flat := filepath.Dir(p) == root
+			if k.kind == KindSkill && !flat && (entry.Name() != SkillFile || filepath.Dir(filepath.Dir(p)) != root) {
+				// Archivos de apoyo de una skill
+				return nil
+			}
+			content, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(claudeDir, p)
+			if err != nil {
+				rel = p
+			}
+			rel = filepath.ToSlash(rel)
+			report.Files++
+			report.add(Content(k.kind, rel, string(content)))
+			if k.kind == KindSkill && flat {
+				report.add([]Issue{{Path: rel, Severity: SeverityWarning, Message: fmt.Sprintf("flat skill file: Claude Code loads skills from skills/<name>/%s (run claude-init update to migrate it)", SkillFile)}})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", root, err)
+		}
+	}
+
+	sort.SliceStable(report.Issues, func(i, j int) bool { return report.Issues[i].Path < report.Issues[j].Path })
+	return report, nil
+}
+
+// add añade los problemas de un archivo al informe.
+func (r *Report) add(issues []Issue) {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			r.Errors++
+		} else {
+			r.Warnings++
+		}
+	}
+	r.Issues = append(r.Issues, issues...)
+}
+
+// splitFrontmatter retorna el frontmatter de content, sin los delimitadores ---. found es
+// false si el archivo no empieza por ---; es un error que no se cierre.
+func splitFrontmatter(content string) (frontmatter string, found bool, err error) {
+	content = strings.TrimPrefix(content, "\ufeff")
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return "", false, nil
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			return strings.Join(lines[1:i], "\n"), true, nil
+		}
+	}
+	return "", true, fmt.Errorf("YAML frontmatter is not closed with ---")
+}
+
+// yamlMessage quita el prefijo "yaml: " de los errores de yaml.v3.
+func yamlMessage(err error) string {
+	return strings.TrimPrefix(err.Error(), "yaml: ")
+}
+
+// checker acumula los problemas de un archivo.
+type checker struct {
+	path   string
+	issues []Issue
+}
+
+func (c *checker) error(field, message string) {
+	c.issues = append(c.issues, Issue{Path: c.path, Severity: SeverityError, Field: field, Message: message})
+}
+
+func (c *checker) warning(field, message string) {
+	c.issues = append(c.issues, Issue{Path: c.path, Severity: SeverityWarning, Field: field, Message: message})
+}
+
+// stringField retorna el valor de un campo de texto. ok es false si falta; es un error que
+// no sea texto.
+func (c *checker) stringField(fields map[string]any, key string) (value string, ok bool) {
+	raw, present := fields[key]
+	if !present || raw == nil {
+		return "", false
+	}
+	value, isString := raw.(string)
+	if !isString {
+		c.error(key, fmt.Sprintf("must be a string, got %v", raw))
+		return "", false
+	}
+	return value, true
+}
+
+// checkName valida el nombre obligatorio de un agent o skill. maxLength 0 no limita.
+func (c *checker) checkName(fields map[string]any, maxLength int) {
+	if fields["name"] == nil {
+		c.error("name", "missing required field")
+		return
+	}
+	name, ok := c.stringField(fields, "name")
+	if !ok {
+		return
+	}
+	if !namePattern.MatchString(name) {
+		c.error("name", fmt.Sprintf("%q must contain only lowercase letters, digits and hyphens", name))
+	}
+	if maxLength > 0 && len(name) > maxLength {
+		c.error("name", fmt.Sprintf("must be at most %d characters, got %d", maxLength, len(name)))
+	}
+}
+
+// checkSkillDirectory valida que el nombre de una skill con formato de directorio
+// (skills/<name>/SKILL.md) coincida con el de su directorio.
+func (c *checker) checkSkillDirectory(fields map[string]any) {
+	if path.Base(c.path) != SkillFile {
+		return
+	}
+	name, _ := fields["name"].(string)
+	if dir := path.Base(path.Dir(c.path)); name != "" && name != dir {
+		c.error("name", fmt.Sprintf("%q must match the skill directory %q", name, dir))
+	}
+}
+
+// checkDescription valida la descripción. Si no es obligatoria, que falte es un aviso.
+// maxLength 0 no limita.
+func (c *checker) checkDescription(fields map[string]any, maxLength int, required bool) {
+	raw := fields["description"]
+	description, isString := raw.(string)
+	if raw != nil && !isString {
+		c.error("description", fmt.Sprintf("must be a string, got %v", raw))
+		return
+	}
+	if strings.TrimSpace(description) == "" {
+		if required {
+			c.error("description", "missing required field")
+		} else {
+			c.warning("description", "missing: Claude Code will use the first line as the description")
+		}
+		return
+	}
+	if maxLength > 0 && len(description) > maxLength {
+		c.error("description", fmt.Sprintf("must be at most %d characters, got %d", maxLength, len(description)))
+	}
+}
+
+// checkTools valida una lista de herramientas: texto separado por comas o lista YAML. Cada
+// herramienta puede llevar un patrón de permisos, como Bash(git add:*).
+func (c *checker) checkTools(fields map[string]any, key string) {
+	raw, present := fields[key]
+	if !present || raw == nil {
+		return
+	}
+
+	var tools []string
+	switch value := raw.(type) {
+	case string:
+		for _, tool := range strings.Split(value, ",") {
+			tools = append(tools, strings.TrimSpace(tool))
+		}
+	case []any:
+		for _, item := range value {
+			tool, ok := item.(string)
+			if !ok {
+				c.error(key, fmt.Sprintf("must list tool names, got %v", item))
+				return
+			}
+			tools = append(tools, strings.TrimSpace(tool))
+		}
+	default:
+		c.error(key, "must be a comma-separated string or a list of tool names")
+		return
+	}
+
+	for _, tool := range tools {
+		if tool == "" {
+			continue
+		}
+		name := tool
+		if idx := strings.Index(name, "("); idx >= 0 {
+			if !strings.HasSuffix(name, ")") {
+				c.error(key, fmt.Sprintf("unbalanced permission pattern in %q", tool))
+				continue
+			}
+			name = name[:idx]
+		}
+		if !knownTools[name] && !strings.HasPrefix(name, "mcp__") {
+			c.error(key, fmt.Sprintf("unknown tool %q", name))
+		}
+	}
+}
+
+// checkModel valida el modelo: un alias o un ID completo de Claude.
+func (c *checker) checkModel(fields map[string]any) {
+	model, ok := c.stringField(fields, "model")
+	if !ok {
+		return
+	}
+	if !modelAliases[model] && !strings.HasPrefix(model, "claude-") {
+		c.error("model", fmt.Sprintf("unknown model %q (use sonnet, opus, haiku, inherit or a claude-* model ID)", model))
+	}
+}
+
+// checkColor valida el color de un agent. Un color desconocido solo es un aviso: Claude
+// Code lo ignora.
+func (c *checker) checkColor(fields map[string]any) {
+	color, ok := c.stringField(fields, "color")
+	if !ok {
+		return
+	}
+	if !agentColors[color] && !hexColorPattern.MatchString(color) {
+		c.warning("color", fmt.Sprintf("unknown color %q", color))
+	}
+}
@@ -0,0 +1,119 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestContent verifica los problemas detectados en cada tipo de archivo.
+func TestContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     string
+		content  string
+		expected []string // "severidad campo" de cada problema
+	}{
+		{
+			name:    "agent válido",
+			kind:    KindAgent,
+			content: "---\nname: go-developer\ndescription: Writes Go code\ntools: Read, Edit, Bash(go test:*), mcp__github__search\nmodel: sonnet\ncolor: \"#3B82F6\"\nversion: 1.0.0\n---\n\n# Agent\n",
+		},
+		{
+			name:     "agent sin frontmatter",
+			kind:     KindAgent,
+			content:  "# Agent\n",
+			expected: []string{"error "},
+		},
+		{
+			name:     "frontmatter sin cerrar",
+			kind:     KindSkill,
+			content:  "---\nname: go\n# Skill\n",
+			expected: []string{"error "},
+		},
+		{
+			name:     "YAML inválido",
+			kind:     KindAgent,
+			content:  "---\nname: dev\ndescription: [unclosed\n---\n",
+			expected: []string{"error "},
+		},
+		{
+			name:     "agent con campos incorrectos",
+			kind:     KindAgent,
+			content:  "---\nname: Go Developer\ntools: [Read, FileSystem]\nmodel: gpt-4\ncolor: teal\n---\n",
+			expected: []string{"error name", "error description", "error tools", "error model", "warning color"},
+		},
+		{
+			name:     "skill demasiado larga",
+			kind:     KindSkill,
+			content:  "---\nname: " + strings.Repeat("a", 65) + "\ndescription: " + strings.Repeat("d", 1025) + "\n---\n",
+			expected: []string{"error name", "error description"},
+		},
+		{
+			name:     "command sin frontmatter",
+			kind:     KindCommand,
+			content:  "# Review\n",
+			expected: []string{"warning description"},
+		},
+		{
+			name:     "command con herramientas desconocidas",
+			kind:     KindCommand,
+			content:  "---\ndescription: Review the code\nallowed-tools: Bash(git diff:*), Terminal\n---\n",
+			expected: []string{"error allowed-tools"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, issue := range Content(tt.kind, "file.md", tt.content) {
+				assert.Equal(t, "file.md", issue.Path)
+				got = append(got, issue.Severity+" "+issue.Field)
+			}
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+// TestDir verifica que se validan los agents, skills y commands de un directorio .claude,
+// sin los README.md de índice.
+func TestDir(t *testing.T) {
+	claudeDir := t.TempDir()
+	files := map[string]string{
+		"agents/dev.md":          "---\nname: dev\ndescription: Developer\n---\n",
+		"agents/README.md":       "# Agents\n",
+		"skills/go.md":           "# Go\n",
+		"commands/review.md":     "---\ndescription: Review\n---\n",
+		"commands/deploy.md":     "# Deploy\n",
+		"settings.local.json":    "{}",
+		"plans/some-plan.md":     "# Plan\n",
+		"skills/api/SKILL.md":    "---\nname: api\ndescription: API design\n---\n",
+		"skills/api/examples.md": "# Examples\n",
+		"skills/db/SKILL.md":     "---\nname: sql\ndescription: SQL queries\n---\n",
+	}
+	for rel, content := range files {
+		path := filepath.Join(claudeDir, filepath.FromSlash(rel))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	report, err := Dir(claudeDir)
+	require.NoError(t, err)
+	assert.Equal(t, 6, report.Files)
+	assert.Equal(t, 2, report.Errors)
+	assert.Equal(t, 2, report.Warnings)
+	require.Len(t, report.Issues, 4)
+	assert.Equal(t, "commands/deploy.md", report.Issues[0].Path)
+	assert.Equal(t, "skills/db/SKILL.md", report.Issues[1].Path)
+	assert.Contains(t, report.Issues[1].Message, `must match the skill directory "db"`)
+	assert.Equal(t, "skills/go.md", report.Issues[2].Path)
+	assert.Contains(t, report.Issues[3].Message, "flat skill file")
+	assert.True(t, HasErrors(report.Issues))
+
+	_, err = Dir(filepath.Join(claudeDir, "missing"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,362 @@
+// Package mcp propone los servidores MCP de un proyecto a partir de un catálogo incrustado:
+// un servidor de base de datos para las bases de datos del proyecto, uno de sistema de
+// archivos para su documentación o el de GitHub si el remoto es GitHub. Los servidores
+// elegidos se escriben en .mcp.json sin secretos: las credenciales quedan como referencias
+// ${VAR} a variables de entorno.
+package mcp
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"net/url"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+
+	gSurvey "github.com/AlecAivazis/survey/v2"
+	"github.com/drossan/claude-init/internal/jsonobject"
+	"gopkg.in/yaml.v3"
+)
+
+// File es el archivo de configuración de servidores MCP del proyecto, en su raíz.
+const File = ".mcp.json"
+
+// Valores especiales de --mcp.
+const (
+	SelectAll  = "all"
+	SelectNone = "none"
+)
+
+// docsPlaceholder es el argumento del catálogo que se sustituye por los directorios de
+// documentación del proyecto.
+const docsPlaceholder = "{docs}"
+
+//go:embed embeds/catalog.yaml
+var catalogContent []byte
+
+// catalog contiene los servidores del catálogo incrustado.
+var catalog = mustLoadCatalog()
+
+// variablePattern reconoce las referencias ${VAR} y ${VAR:-default} a variables de entorno.
+var variablePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-[^}]*)?\}`)
+
+// Trigger indica cuándo se propone un servidor del catálogo.
+type Trigger struct {
+	Databases []string `yaml:"databases,omitempty"` // Alguna de las bases de datos del proyecto
+	Docs      bool     `yaml:"docs,omitempty"`      // El proyecto tiene directorios de documentación
+	Remote    string   `yaml:"remote,omitempty"`    // Host del remoto origin de git
+}
+
+// Server es un servidor MCP del catálogo: cuándo se propone y su entrada de .mcp.json.
+// Los servidores locales tienen Command; los remotos, Type y URL.
+type Server struct {
+	Name        string            `yaml:"name"`
+	Description string            `yaml:"description"`
+	When        Trigger           `yaml:"when"`
+	Type        string            `yaml:"type,omitempty"`
+	Command     string            `yaml:"command,omitempty"`
+	Args        []string          `yaml:"args,omitempty"`
+	Env         map[string]string `yaml:"env,omitempty"`
+	URL         string            `yaml:"url,omitempty"`
+	Headers     map[string]string `yaml:"headers,omitempty"`
+}
+
+// Project es lo que se conoce del proyecto para elegir los servidores del catálogo.
+type Project struct {
+	Databases  []string // Bases de datos (PostgreSQL, MongoDB...)
+	DocDirs    []string // Directorios de documentación, relativos al proyecto
+	RemoteHost string   // Host del remoto origin de git (github.com...), vacío si no hay
+}
+
+// mustLoadCatalog parsea el catálogo incrustado.
+func mustLoadCatalog() []Server {
+	var servers []Server
+	if err := yaml.Unmarshal(catalogContent, &servers); err != nil {
+		panic(fmt.Sprintf("invalid embedded MCP catalog: %v", err))
+	}
+	return servers
+}
+
+// Catalog retorna los nombres de los servidores del catálogo.
+func Catalog() []string {
+	names := make([]string, 0, len(catalog))
+	for _, server := range catalog {
+		names = append(names, server.Name)
+	}
+	return names
+}
+
+// Available retorna los servidores del catálogo que aplican al proyecto, con los
+// directorios de documentación ya sustituidos en sus argumentos.
+func Available(project Project) []Server {
+	var servers []Server
+	for _, server := range catalog {
+		if !server.When.matches(project) {
+			continue
+		}
+		var args []string
+		for _, arg := range server.Args {
+			if arg == docsPlaceholder {
+				args = append(args, project.DocDirs...)
+			} else {
+				args = append(args, arg)
+			}
+		}
+		server.Args = args
+		servers = append(servers, server)
+	}
+	return servers
+}
+
+// matches indica si el disparador se cumple para el proyecto.
+func (t Trigger) matches(project Project) bool {
+	for _, want := range t.Databases {
+		for _, database := range project.Databases {
+			if strings.EqualFold(want, strings.TrimSpace(database)) {
+				return true
+			}
+		}
+	}
+	if t.Docs && len(project.DocDirs) > 0 {
+		return true
+	}
+	return t.Remote != "" && strings.EqualFold(t.Remote, project.RemoteHost)
+}
+
+// RemoteHost retorna el host del remoto origin del repositorio git del proyecto, o "" si
+// no es un repositorio o no tiene origin.
+func RemoteHost(projectPath string) string {
+	cmd := exec.Command("git", "remote", "get-url", "origin")
+	cmd.Dir = projectPath
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return hostOf(strings.TrimSpace(string(output)))
+}
+
+// hostOf retorna el host de una URL de git, tanto en forma de URL (https://, ssh://) como
+// en la forma scp de ssh (git@github.com:owner/repo.git).
+func hostOf(remote string) string {
+	if strings.Contains(remote, "://") {
+		parsed, err := url.Parse(remote)
+		if err != nil {
+			return ""
+		}
+		return strings.ToLower(parsed.Hostname())
+	}
+	host, _, ok := strings.Cut(remote, ":")
+	if !ok {
+		return ""
+	}
+	if _, after, found := strings.Cut(host, "@"); found {
+		host = after
+	}
+	return strings.ToLower(host)
+}
+
+// Entry retorna la entrada del servidor en la sección mcpServers de .mcp.json.
+func (s Server) Entry() map[string]any {
+	entry := map[string]any{}
+	if s.Command != "" {
+		entry["type"] = "stdio"
+		entry["command"] = s.Command
+		entry["args"] = s.Args
+		if len(s.Env) > 0 {
+			entry["env"] = s.Env
+		}
+		return entry
+	}
+	entry["type"] = s.Type
+	entry["url"] = s.URL
+	if len(s.Headers) > 0 {
+		entry["headers"] = s.Headers
+	}
+	return entry
+}
+
+// Variables retorna, ordenadas y sin duplicados, las variables de entorno que usan los
+// servidores. Hay que definirlas antes de arrancar Claude Code.
+func Variables(servers []Server) []string {
+	seen := map[string]bool{}
+	var names []string
+	add := func(value string) {
+		for _, match := range variablePattern.FindAllStringSubmatch(value, -1) {
+			if !seen[match[1]] {
+				seen[match[1]] = true
+				names = append(names, match[1])
+			}
+		}
+	}
+	for _, server := range servers {
+		add(server.URL)
+		for _, arg := range server.Args {
+			add(arg)
+		}
+		for _, value := range server.Env {
+			add(value)
+		}
+		for _, value := range server.Headers {
+			add(value)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Merge añade los servidores a la sección mcpServers del contenido de un .mcp.json
+// existente (vacío si no existe) y retorna el nuevo contenido. Los servidores que ya
+// están, con el mismo nombre, no se cambian. Las claves conservan su orden y, si no falta
+// ningún servidor, se retorna existing sin cambios.
+func Merge(existing []byte, servers []Server) ([]byte, error) {
+	created := len(bytes.TrimSpace(existing)) == 0
+	doc := jsonobject.New()
+	if !created {
+		var err error
+		if doc, err = jsonobject.Parse(existing); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", File, err)
+		}
+	}
+
+	entries := jsonobject.New()
+	if raw, ok := doc.Get("mcpServers"); ok {
+		var err error
+		if entries, err = jsonobject.Parse(raw); err != nil {
+			return nil, fmt.Errorf("invalid %s: mcpServers must be an object", File)
+		}
+	}
+	changed := false
+	for _, server := range servers {
+		if _, ok := entries.Get(server.Name); ok {
+			continue
+		}
+		if err := entries.Set(server.Name, server.Entry()); err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", File, err)
+		}
+		changed = true
+	}
+	if !created && !changed {
+		return existing, nil
+	}
+	if err := doc.Set("mcpServers", entries); err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", File, err)
+	}
+
+	content, err := doc.Format()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", File, err)
+	}
+	return content, nil
+}
+
+// Select retorna los servidores de available elegidos por nombre. "all" elige todos y
+// "none" ninguno. Es un error pedir un servidor que no está en el catálogo o que no aplica
+// al proyecto.
+func Select(available []Server, names []string) ([]Server, error) {
+	var selected []Server
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "", SelectNone:
+			continue
+		case SelectAll:
+			return available, nil
+		}
+		if !inCatalog(name) {
+			return nil, fmt.Errorf("unknown MCP server %q (use %s, %s or %s)", name, strings.Join(Catalog(), ", "), SelectAll, SelectNone)
+		}
+
+		found := false
+		for _, server := range available {
+			if server.Name == name {
+				selected = appendServer(selected, server)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("MCP server %q is not available: it does not apply to the detected database, docs or git remote", name)
+		}
+	}
+	return selected, nil
+}
+
+// inCatalog indica si el catálogo tiene un servidor con ese nombre.
+func inCatalog(name string) bool {
+	for _, server := range catalog {
+		if server.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// appendServer añade server a la lista si aún no está.
+func appendServer(list []Server, server Server) []Server {
+	for _, existing := range list {
+		if existing.Name == server.Name {
+			return list
+		}
+	}
+	return append(list, server)
+}
+
+// WritePreview escribe en out qué hace un servidor y la entrada que se añadiría.
+func WritePreview(out io.Writer, server Server) {
+	target := server.URL
+	if server.Command != "" {
+		target = strings.TrimSpace(server.Command + " " + strings.Join(server.Args, " "))
+	}
+	fmt.Fprintf(out, "MCP server %q: %s\n  %s: %s\n", server.Name, server.Description, server.Entry()["type"], target)
+	if variables := Variables([]Server{server}); len(variables) > 0 {
+		fmt.Fprintf(out, "  requires: %s\n", strings.Join(variables, ", "))
+	}
+}
+
+// Confirmer pregunta si se añade un servidor.
+type Confirmer func(server Server) (bool, error)
+
+// Choose elige los servidores a escribir entre los disponibles. Si se indican nombres
+// (--mcp), se eligen con Select; si no, y hay confirm, se muestra la vista previa de cada
+// servidor y se pregunta por él. Sin nombres ni confirm no se elige ninguno. La vista previa
+// de los servidores elegidos por nombre también se escribe en out.
+func Choose(out io.Writer, available []Server, names []string, confirm Confirmer) ([]Server, error) {
+	if len(names) > 0 {
+		selected, err := Select(available, names)
+		if err != nil {
+			return nil, err
+		}
+		for _, server := range selected {
+			WritePreview(out, server)
+		}
+		return selected, nil
+	}
+	if confirm == nil {
+		return nil, nil
+	}
+
+	var selected []Server
+	for _, server := range available {
+		WritePreview(out, server)
+		ok, err := confirm(server)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			selected = append(selected, server)
+		}
+	}
+	return selected, nil
+}
+
+// SurveyConfirm pregunta en la terminal si se añade el servidor. Por defecto, no.
+func SurveyConfirm(server Server) (bool, error) {
+	add := false
+	prompt := &gSurvey.Confirm{Message: fmt.Sprintf("Add the %s MCP server to %s?", server.Name, File), Default: false}
+	if err := gSurvey.AskOne(prompt, &add); err != nil {
+		return false, err
+	}
+	return add, nil
+}
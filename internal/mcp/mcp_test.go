@@ -0,0 +1,157 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAvailable verifica que solo se proponen los servidores que aplican al proyecto.
+func TestAvailable(t *testing.T) {
+	assert.Empty(t, Available(Project{}))
+
+	servers := Available(Project{
+		Databases:  []string{"postgresql", "MySQL"},
+		DocDirs:    []string{"docs", "wiki"},
+		RemoteHost: "github.com",
+	})
+	var names []string
+	for _, server := range servers {
+		names = append(names, server.Name)
+	}
+	assert.Equal(t, []string{"postgres", "docs", "github"}, names)
+
+	assert.Equal(t, []string{"-y", "@modelcontextprotocol/server-postgres", "${DATABASE_URL}"}, servers[0].Args)
+	assert.Equal(t, []string{"-y", "@modelcontextprotocol/server-filesystem", "docs", "wiki"}, servers[1].Args)
+	assert.Equal(t, map[string]any{
+		"type":    "http",
+		"url":     "https://api.githubcopilot.com/mcp/",
+		"headers": map[string]string{"Authorization": "Bearer ${GITHUB_PERSONAL_ACCESS_TOKEN}"},
+	}, servers[2].Entry())
+
+	// El catálogo no cambia al sustituir los directorios de documentación
+	again := Available(Project{DocDirs: []string{"guides"}})
+	require.Len(t, again, 1)
+	assert.Equal(t, []string{"-y", "@modelcontextprotocol/server-filesystem", "guides"}, again[0].Args)
+}
+
+// TestHostOf verifica el host de las URLs de git habituales.
+func TestHostOf(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/acme/api.git":        "github.com",
+		"git@github.com:acme/api.git":            "github.com",
+		"ssh://git@GitLab.com:2222/acme/api.git": "gitlab.com",
+		"/srv/git/api.git":                       "",
+		"":                                       "",
+	}
+	for remote, want := range tests {
+		assert.Equal(t, want, hostOf(remote), remote)
+	}
+}
+
+// TestVariables verifica que se listan las variables de entorno que usan los servidores.
+func TestVariables(t *testing.T) {
+	servers := Available(Project{Databases: []string{"MongoDB", "PostgreSQL", "Redis"}, RemoteHost: "github.com"})
+	assert.Equal(t, []string{"DATABASE_URL", "GITHUB_PERSONAL_ACCESS_TOKEN", "MDB_MCP_CONNECTION_STRING", "REDIS_URL"}, Variables(servers))
+	assert.Empty(t, Variables(Available(Project{DocDirs: []string{"docs"}})))
+}
+
+// TestMerge verifica que se añaden los servidores que faltan sin cambiar los existentes.
+func TestMerge(t *testing.T) {
+	servers := Available(Project{Databases: []string{"PostgreSQL"}, DocDirs: []string{"docs"}})
+
+	content, err := Merge(nil, servers)
+	require.NoError(t, err)
+	var doc struct {
+		Servers map[string]map[string]any `json:"mcpServers"`
+	}
+	require.NoError(t, json.Unmarshal(content, &doc))
+	assert.Equal(t, "stdio", doc.Servers["postgres"]["type"])
+	assert.Equal(t, "npx", doc.Servers["postgres"]["command"])
+
+	existing := []byte(`{"mcpServers": {"postgres": {"command": "pg-mcp"}, "sentry": {"type": "http", "url": "https://mcp.sentry.dev/mcp"}}}`)
+	content, err = Merge(existing, servers)
+	require.NoError(t, err)
+	doc.Servers = nil
+	require.NoError(t, json.Unmarshal(content, &doc))
+	assert.Equal(t, map[string]any{"command": "pg-mcp"}, doc.Servers["postgres"])
+	assert.Contains(t, doc.Servers, "sentry")
+	assert.Contains(t, doc.Servers, "docs")
+
+	again, err := Merge(content, servers)
+	require.NoError(t, err)
+	assert.Equal(t, string(content), string(again))
+
+	_, err = Merge([]byte(`{"mcpServers": []}`), servers)
+	assert.ErrorContains(t, err, "mcpServers must be an object")
+}
+
+// TestMerge_PreservesLayout verifica que un .mcp.json que ya tiene los servidores se retorna
+// tal cual y que, al añadir uno, las claves del usuario conservan su orden.
+func TestMerge_PreservesLayout(t *testing.T) {
+	servers := []Server{{Name: "docs", Command: "npx", Args: []string{"-y", "server", "docs"}}}
+
+	existing := []byte(`{"mcpServers": {"zeta": {"url": "https://z"}, "docs": {"command": "mine"}}, "alpha": 1.50}`)
+	content, err := Merge(existing, servers)
+	require.NoError(t, err)
+	assert.Equal(t, string(existing), string(content))
+
+	existing = []byte(`{"zeta": true, "mcpServers": {"sentry": {"url": "https://mcp.sentry.dev/mcp", "type": "http"}}, "alpha": 1.50}`)
+	content, err = Merge(existing, servers)
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "zeta": true,
+  "mcpServers": {
+    "sentry": {
+      "url": "https://mcp.sentry.dev/mcp",
+      "type": "http"
+    },
+    "docs": {
+      "args": [
+        "-y",
+        "server",
+        "docs"
+      ],
+      "command": "npx",
+      "type": "stdio"
+    }
+  },
+  "alpha": 1.50
+}
+`, string(content))
+}
+
+// TestChoose verifica la elección por nombre y la confirmación de cada servidor.
+func TestChoose(t *testing.T) {
+	available := Available(Project{Databases: []string{"SQLite"}, RemoteHost: "gitlab.com"})
+	require.Len(t, available, 2)
+
+	var out bytes.Buffer
+	selected, err := Choose(&out, available, []string{"GitLab"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, available[1:], selected)
+	assert.Contains(t, out.String(), `MCP server "gitlab"`)
+	assert.Contains(t, out.String(), "requires: GITLAB_PERSONAL_ACCESS_TOKEN")
+
+	selected, err = Choose(&out, available, []string{"all"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, available, selected)
+
+	_, err = Choose(&out, available, []string{"github"}, nil)
+	assert.ErrorContains(t, err, "not available")
+	_, err = Choose(&out, available, []string{"jira"}, nil)
+	assert.ErrorContains(t, err, "unknown MCP server")
+
+	selected, err = Choose(&out, available, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, selected)
+
+	selected, err = Choose(&out, available, nil, func(server Server) (bool, error) {
+		return server.Name == "sqlite", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, available[:1], selected)
+}
@@ -0,0 +1,131 @@
+// Package merge implementa la fusión a tres bandas, por líneas, de un archivo generado
+// que el usuario ha editado con su nueva versión generada.
+package merge
+
+import (
+	"strings"
+)
+
+// Marcadores de conflicto, con el formato de git.
+const (
+	MarkerOurs   = "<<<<<<< yours"
+	MarkerSep    = "======="
+	MarkerTheirs = ">>>>>>> claude-init"
+)
+
+// Result es el resultado de una fusión.
+type Result struct {
+	Content   string
+	Conflicts int // Número de bloques en conflicto, marcados en Content
+}
+
+// ThreeWay fusiona los cambios de ours (la versión editada por el usuario) y theirs (la
+// nueva versión generada) respecto a base (la versión generada anteriormente). Los bloques
+// que ambos han cambiado de forma distinta quedan marcados como conflicto.
+func ThreeWay(base, ours, theirs string) Result {
+	baseLines := splitLines(base)
+	oursLines := splitLines(ours)
+	theirsLines := splitLines(theirs)
+
+	oursMatch := Matches(baseLines, oursLines)
+	theirsMatch := Matches(baseLines, theirsLines)
+
+	var out []string
+	var result Result
+	resolve := func(b, o, t []string) {
+		switch {
+		case equal(o, t), equal(t, b):
+			out = append(out, o...)
+		case equal(o, b):
+			out = append(out, t...)
+		default:
+			result.Conflicts++
+			out = append(out, MarkerOurs)
+			out = append(out, o...)
+			out = append(out, MarkerSep)
+			out = append(out, t...)
+			out = append(out, MarkerTheirs)
+		}
+	}
+
+	// Recorre las líneas de base que se conservan en ambas versiones; entre ellas quedan
+	// los bloques que al menos una de las dos ha cambiado.
+	i, o, t := 0, 0, 0
+	for {
+		j := i
+		for j < len(baseLines) && (oursMatch[j] < 0 || theirsMatch[j] < 0) {
+			j++
+		}
+		if j == len(baseLines) {
+			resolve(baseLines[i:], oursLines[o:], theirsLines[t:])
+			break
+		}
+		resolve(baseLines[i:j], oursLines[o:oursMatch[j]], theirsLines[t:theirsMatch[j]])
+		out = append(out, baseLines[j])
+		i, o, t = j+1, oursMatch[j]+1, theirsMatch[j]+1
+	}
+
+	result.Content = strings.Join(out, "\n")
+	return result
+}
+
+// splitLines divide el contenido en líneas. Un salto de línea final produce una última
+// línea vacía, de modo que unir las líneas con "\n" reconstruye el contenido.
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	return strings.Split(content, "\n")
+}
+
+// Matches calcula la subsecuencia común más larga de a y b, y retorna para cada línea
+// de a el índice de su línea en b, o -1 si no forma parte de ella.
+func Matches(a, b []string) []int {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			case lengths[i+1][j] >= lengths[i][j+1]:
+				lengths[i][j] = lengths[i+1][j]
+			default:
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	result := make([]int, len(a))
+	for i := range result {
+		result[i] = -1
+	}
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			result[i] = j
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return result
+}
+
+// equal indica si dos bloques de líneas son iguales.
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
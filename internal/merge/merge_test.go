@@ -0,0 +1,59 @@
+package merge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThreeWay(t *testing.T) {
+	base := "# Agent\n\n## Role\nReviews code.\n\n## Stack\nGo 1.22\n"
+
+	tests := []struct {
+		name      string
+		ours      string
+		theirs    string
+		expected  string
+		conflicts int
+	}{
+		{
+			name:     "sin cambios del usuario toma la nueva versión",
+			ours:     base,
+			theirs:   "# Agent\n\n## Role\nReviews code.\n\n## Stack\nGo 1.25\n",
+			expected: "# Agent\n\n## Role\nReviews code.\n\n## Stack\nGo 1.25\n",
+		},
+		{
+			name:     "sin cambios generados conserva la edición",
+			ours:     "# Agent\n\n## Role\nReviews code carefully.\n\n## Stack\nGo 1.22\n",
+			theirs:   base,
+			expected: "# Agent\n\n## Role\nReviews code carefully.\n\n## Stack\nGo 1.22\n",
+		},
+		{
+			name:     "cambios en bloques distintos se combinan",
+			ours:     "# Agent\n\n## Role\nReviews code carefully.\n\n## Stack\nGo 1.22\n\n## Notes\nAsk before merging.\n",
+			theirs:   "# Agent\n\n## Role\nReviews code.\n\n## Stack\nGo 1.25\nPostgreSQL\n",
+			expected: "# Agent\n\n## Role\nReviews code carefully.\n\n## Stack\nGo 1.25\nPostgreSQL\n\n## Notes\nAsk before merging.\n",
+		},
+		{
+			name:      "cambios distintos en el mismo bloque son un conflicto",
+			ours:      "# Agent\n\n## Role\nReviews code.\n\n## Stack\nGo 1.23\n",
+			theirs:    "# Agent\n\n## Role\nReviews code.\n\n## Stack\nGo 1.25\n",
+			expected:  "# Agent\n\n## Role\nReviews code.\n\n## Stack\n<<<<<<< yours\nGo 1.23\n=======\nGo 1.25\n>>>>>>> claude-init\n",
+			conflicts: 1,
+		},
+		{
+			name:     "el mismo cambio en ambas versiones no es un conflicto",
+			ours:     "# Agent\n\n## Role\nReviews code.\n\n## Stack\nGo 1.25\n",
+			theirs:   "# Agent\n\n## Role\nReviews code.\n\n## Stack\nGo 1.25\n",
+			expected: "# Agent\n\n## Role\nReviews code.\n\n## Stack\nGo 1.25\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ThreeWay(base, tt.ours, tt.theirs)
+			assert.Equal(t, tt.expected, result.Content)
+			assert.Equal(t, tt.conflicts, result.Conflicts)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package preview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/drossan/claude-init/internal/merge"
+)
+
+// contextLines es el número de líneas sin cambios que rodean cada bloque del diff.
+const contextLines = 3
+
+// Colores ANSI del diff.
+const (
+	colorReset = "\033[0m"
+	colorBold  = "\033[1m"
+	colorRed   = "\033[31m"
+	colorGreen = "\033[32m"
+	colorCyan  = "\033[36m"
+)
+
+// op es una línea del diff: ' ' sin cambios, '-' eliminada o '+' añadida.
+type op struct {
+	kind byte
+	text string
+}
+
+// unified retorna el diff unificado de un archivo entre su contenido anterior y el nuevo.
+// added y removed indican que el archivo no existía antes o no existirá después.
+func unified(path, oldContent, newContent string, added, removed bool) string {
+	oldName, newName := "a/"+path, "b/"+path
+	if added {
+		oldName = "/dev/null"
+	}
+	if removed {
+		newName = "/dev/null"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+
+	ops := edits(lines(oldContent), lines(newContent))
+	for start := 0; start < len(ops); {
+		// Busca el siguiente cambio y extiende el bloque mientras los cambios estén a menos
+		// de dos contextos de distancia.
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		last := first
+		for i := first; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				if i-last > 2*contextLines {
+					break
+				}
+				last = i
+			}
+		}
+		from := max(first-contextLines, start)
+		to := min(last+contextLines+1, len(ops))
+		writeHunk(&b, ops, from, to)
+		start = to
+	}
+	return b.String()
+}
+
+// writeHunk escribe el bloque ops[from:to] con su cabecera @@.
+func writeHunk(b *strings.Builder, ops []op, from, to int) {
+	oldStart, newStart := 1, 1
+	for _, o := range ops[:from] {
+		if o.kind != '+' {
+			oldStart++
+		}
+		if o.kind != '-' {
+			newStart++
+		}
+	}
+	oldCount, newCount := 0, 0
+	for _, o := range ops[from:to] {
+		if o.kind != '+' {
+			oldCount++
+		}
+		if o.kind != '-' {
+			newCount++
+		}
+	}
+	// Como en diff, un lado vacío se ubica en la línea anterior al bloque
+	if oldCount == 0 {
+		oldStart--
+	}
+	if newCount == 0 {
+		newStart--
+	}
+
+	fmt.Fprintf(b, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+	for _, o := range ops[from:to] {
+		fmt.Fprintf(b, "%c%s\n", o.kind, o.text)
+	}
+}
+
+// edits calcula las líneas eliminadas, añadidas y sin cambios para pasar de a a b.
+func edits(a, b []string) []op {
+	match := merge.Matches(a, b)
+	var ops []op
+	j := 0
+	for i, line := range a {
+		if match[i] < 0 {
+			ops = append(ops, op{'-', line})
+			continue
+		}
+		for ; j < match[i]; j++ {
+			ops = append(ops, op{'+', b[j]})
+		}
+		ops = append(ops, op{' ', line})
+		j++
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, op{'+', b[j]})
+	}
+	return ops
+}
+
+// lines divide el contenido en líneas, sin la línea vacía que deja el salto final.
+func lines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
+
+// colorize añade colores ANSI a un diff unificado.
+func colorize(diff string) string {
+	var b strings.Builder
+	for _, line := range lines(diff) {
+		switch {
+		case strings.HasPrefix(line, "--- "), strings.HasPrefix(line, "+++ "):
+			b.WriteString(colorBold + line + colorReset)
+		case strings.HasPrefix(line, "@@"):
+			b.WriteString(colorCyan + line + colorReset)
+		case strings.HasPrefix(line, "-"):
+			b.WriteString(colorRed + line + colorReset)
+		case strings.HasPrefix(line, "+"):
+			b.WriteString(colorGreen + line + colorReset)
+		default:
+			b.WriteString(line)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
@@ -0,0 +1,296 @@
+// Package preview compara la configuración generada en un staging con la del proyecto y la
+// muestra antes de escribir nada: un diff unificado de los archivos que cambian, un árbol
+// con los tamaños para un proyecto nuevo o JSON para otras herramientas.
+package preview
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Formatos de salida de la vista previa.
+const (
+	FormatUnified = "unified" // Diff unificado, o árbol de archivos si no existe nada aún
+	FormatJSON    = "json"
+)
+
+// Estados de un archivo en la vista previa.
+const (
+	StatusAdded    = "added"
+	StatusModified = "modified"
+	StatusRemoved  = "removed"
+)
+
+// Change es un archivo que la generación crearía, modificaría o eliminaría.
+type Change struct {
+	Path    string `json:"path"` // Relativo al proyecto, con "/"
+	Status  string `json:"status"`
+	OldSize int64  `json:"old_size"`
+	NewSize int64  `json:"new_size"`
+	Diff    string `json:"diff,omitempty"` // Diff unificado, sin colores
+}
+
+// Summary cuenta los archivos de cada estado.
+type Summary struct {
+	Added    int `json:"added"`
+	Modified int `json:"modified"`
+	Removed  int `json:"removed"`
+}
+
+// Preview es el resultado de comparar el proyecto con su staging.
+type Preview struct {
+	Files   []Change `json:"files"`
+	Summary Summary  `json:"summary"`
+}
+
+// ValidateFormat verifica que el formato de salida es uno de los soportados.
+func ValidateFormat(format string) error {
+	switch format {
+	case FormatUnified, FormatJSON:
+		return nil
+	}
+	return fmt.Errorf("invalid diff format %q (use %s or %s)", format, FormatUnified, FormatJSON)
+}
+
+// Compare compara las rutas indicadas (archivos o directorios, relativas al proyecto) entre
+// el proyecto y el staging. Los archivos cuya ruta empieza por alguno de los prefijos de
+// ignore (registros internos de claude-init) no se comparan.
+func Compare(projectPath, stagedPath string, paths, ignore []string) (*Preview, error) {
+	before, err := collect(projectPath, paths, ignore)
+	if err != nil {
+		return nil, err
+	}
+	after, err := collect(stagedPath, paths, ignore)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &Preview{Files: []Change{}}
+	for rel, newPath := range after {
+		newContent, err := os.ReadFile(newPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+		change := Change{Path: rel, Status: StatusAdded, NewSize: int64(len(newContent))}
+
+		var oldContent []byte
+		if oldPath, ok := before[rel]; ok {
+			if oldContent, err = os.ReadFile(oldPath); err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+			}
+			if string(oldContent) == string(newContent) {
+				continue
+			}
+			change.Status = StatusModified
+			change.OldSize = int64(len(oldContent))
+		}
+		change.Diff = unified(rel, string(oldContent), string(newContent), change.Status == StatusAdded, false)
+		preview.Files = append(preview.Files, change)
+	}
+	for rel, oldPath := range before {
+		if _, ok := after[rel]; ok {
+			continue
+		}
+		oldContent, err := os.ReadFile(oldPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+		preview.Files = append(preview.Files, Change{
+			Path:    rel,
+			Status:  StatusRemoved,
+			OldSize: int64(len(oldContent)),
+			Diff:    unified(rel, string(oldContent), "", false, true),
+		})
+	}
+
+	sort.Slice(preview.Files, func(i, j int) bool { return preview.Files[i].Path < preview.Files[j].Path })
+	for _, change := range preview.Files {
+		switch change.Status {
+		case StatusAdded:
+			preview.Summary.Added++
+		case StatusModified:
+			preview.Summary.Modified++
+		case StatusRemoved:
+			preview.Summary.Removed++
+		}
+	}
+	return preview, nil
+}
+
+// CompareFile compara un único archivo (ruta relativa, con "/") entre el proyecto y el
+// staging. Retorna nil si no cambia.
+func CompareFile(projectPath, stagedPath, rel string) (*Change, error) {
+	read := func(root string) ([]byte, bool, error) {
+		content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(rel)))
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+		return content, true, nil
+	}
+	oldContent, existed, err := read(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	newContent, exists, err := read(stagedPath)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &Change{Path: rel, OldSize: int64(len(oldContent)), NewSize: int64(len(newContent))}
+	switch {
+	case existed && exists && string(oldContent) == string(newContent), !existed && !exists:
+		return nil, nil
+	case !existed:
+		change.Status = StatusAdded
+	case !exists:
+		change.Status = StatusRemoved
+	default:
+		change.Status = StatusModified
+	}
+	change.Diff = unified(rel, string(oldContent), string(newContent), !existed, !exists)
+	return change, nil
+}
+
+// WriteChange escribe un archivo de la vista previa: su diff unificado, coloreado si color es
+// true, o su contenido si es nuevo.
+func WriteChange(out io.Writer, change *Change, stagedPath string, color bool) error {
+	if change.Status == StatusAdded {
+		content, err := os.ReadFile(filepath.Join(stagedPath, filepath.FromSlash(change.Path)))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", change.Path, err)
+		}
+		header := fmt.Sprintf("=== %s (new, %s) ===", change.Path, formatSize(change.NewSize))
+		if color {
+			header = colorBold + header + colorReset
+		}
+		fmt.Fprintf(out, "%s\n%s\n", header, strings.TrimSuffix(string(content), "\n"))
+		return nil
+	}
+
+	diff := change.Diff
+	if color {
+		diff = colorize(diff)
+	}
+	_, err := fmt.Fprint(out, diff)
+	return err
+}
+
+// collect retorna los archivos regulares bajo las rutas indicadas de root, por su ruta
+// relativa con "/".
+func collect(root string, paths, ignore []string) (map[string]string, error) {
+	files := make(map[string]string)
+	for _, rel := range paths {
+		start := filepath.Join(root, filepath.FromSlash(rel))
+		if _, err := os.Stat(start); os.IsNotExist(err) {
+			continue
+		}
+		err := filepath.WalkDir(start, func(p string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !entry.Type().IsRegular() {
+				return nil
+			}
+			relPath, err := filepath.Rel(root, p)
+			if err != nil {
+				return err
+			}
+			relPath = filepath.ToSlash(relPath)
+			for _, prefix := range ignore {
+				if relPath == prefix || strings.HasPrefix(relPath, prefix+"/") {
+					return nil
+				}
+			}
+			files[relPath] = p
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+	}
+	return files, nil
+}
+
+// Write escribe la vista previa en el formato indicado. Con FormatUnified muestra un diff
+// unificado, coloreado si color es true, salvo que la generación solo cree archivos (un
+// proyecto nuevo): entonces muestra el árbol de archivos con sus tamaños.
+func Write(out io.Writer, preview *Preview, format string, color bool) error {
+	if err := ValidateFormat(format); err != nil {
+		return err
+	}
+	if format == FormatJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(preview)
+	}
+
+	if len(preview.Files) == 0 {
+		fmt.Fprintln(out, "No changes: the generated configuration matches the current one.")
+		return nil
+	}
+
+	if preview.Summary.Modified == 0 && preview.Summary.Removed == 0 {
+		writeTree(out, preview.Files)
+	} else {
+		for _, change := range preview.Files {
+			diff := change.Diff
+			if color {
+				diff = colorize(diff)
+			}
+			fmt.Fprint(out, diff)
+		}
+	}
+
+	fmt.Fprintf(out, "\n%d file(s): %d added, %d modified, %d removed. Nothing was written.\n",
+		len(preview.Files), preview.Summary.Added, preview.Summary.Modified, preview.Summary.Removed)
+	return nil
+}
+
+// writeTree escribe los archivos como un árbol de directorios con el tamaño de cada uno.
+func writeTree(out io.Writer, files []Change) {
+	printed := make(map[string]bool)
+	for _, change := range files {
+		parts := strings.Split(change.Path, "/")
+		for depth := range parts[:len(parts)-1] {
+			dir := path.Join(parts[:depth+1]...)
+			if printed[dir] {
+				continue
+			}
+			printed[dir] = true
+			fmt.Fprintf(out, "%s%s/\n", strings.Repeat("  ", depth), parts[depth])
+		}
+		fmt.Fprintf(out, "%s%s (%s)\n", strings.Repeat("  ", len(parts)-1), parts[len(parts)-1], formatSize(change.NewSize))
+	}
+}
+
+// formatSize formatea un tamaño en bytes de forma legible.
+func formatSize(size int64) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+	return fmt.Sprintf("%.1f KB", float64(size)/1024)
+}
+
+// ColorEnabled indica si se debe colorear la salida escrita en out: solo en una terminal y
+// si no se ha definido NO_COLOR.
+func ColorEnabled(out io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	file, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
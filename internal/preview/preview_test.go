@@ -0,0 +1,122 @@
+package preview
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFiles crea los archivos indicados (ruta relativa -> contenido) dentro de dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+// TestUnified verifica las cabeceras y los bloques del diff unificado.
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name     string
+		old      string
+		new      string
+		added    bool
+		removed  bool
+		expected string
+	}{
+		{
+			name:     "archivo nuevo",
+			new:      "a\nb\n",
+			added:    true,
+			expected: "--- /dev/null\n+++ b/f.md\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name:     "archivo eliminado",
+			old:      "a\n",
+			removed:  true,
+			expected: "--- a/f.md\n+++ /dev/null\n@@ -1,1 +0,0 @@\n-a\n",
+		},
+		{
+			name:     "cambio con contexto",
+			old:      "1\n2\n3\n4\n5\n6\n7\n8\n",
+			new:      "1\n2\n3\n4\nfive\n6\n7\n8\n",
+			expected: "--- a/f.md\n+++ b/f.md\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name: "cambios lejanos en bloques separados",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			new:  "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n",
+			expected: "--- a/f.md\n+++ b/f.md\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n" +
+				"@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+ten\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, unified("f.md", tt.old, tt.new, tt.added, tt.removed))
+		})
+	}
+}
+
+// TestCompareAndWrite verifica la comparación del proyecto con su staging y los formatos de salida.
+func TestCompareAndWrite(t *testing.T) {
+	project := t.TempDir()
+	staged := t.TempDir()
+	paths := []string{".claude", "CLAUDE.md"}
+	ignore := []string{".claude/.claude-init-base"}
+
+	// Proyecto nuevo: árbol de archivos con tamaños
+	writeFiles(t, staged, map[string]string{
+		"CLAUDE.md":                   "# Project\n",
+		".claude/agents/architect.md": "# Architect\n",
+		".claude/.claude-init-base/x": "internal",
+		".claude/commands/test.md":    "# Test\n",
+	})
+	p, err := Compare(project, staged, paths, ignore)
+	require.NoError(t, err)
+	assert.Equal(t, Summary{Added: 3}, p.Summary)
+
+	var out bytes.Buffer
+	require.NoError(t, Write(&out, p, FormatUnified, false))
+	assert.Equal(t, ".claude/\n  agents/\n    architect.md (12 B)\n  commands/\n    test.md (7 B)\nCLAUDE.md (10 B)\n\n"+
+		"3 file(s): 3 added, 0 modified, 0 removed. Nothing was written.\n", out.String())
+
+	// Proyecto existente: diff unificado, con colores si se piden
+	writeFiles(t, project, map[string]string{
+		"CLAUDE.md":                   "# Old\n",
+		".claude/agents/architect.md": "# Architect\n",
+		".claude/agents/legacy.md":    "# Legacy\n",
+	})
+	p, err = Compare(project, staged, paths, ignore)
+	require.NoError(t, err)
+	assert.Equal(t, Summary{Added: 1, Modified: 1, Removed: 1}, p.Summary)
+
+	out.Reset()
+	require.NoError(t, Write(&out, p, FormatUnified, false))
+	assert.Contains(t, out.String(), "--- a/CLAUDE.md\n+++ b/CLAUDE.md\n@@ -1,1 +1,1 @@\n-# Old\n+# Project\n")
+	assert.Contains(t, out.String(), "--- a/.claude/agents/legacy.md\n+++ /dev/null\n")
+	assert.NotContains(t, out.String(), "architect.md")
+
+	out.Reset()
+	require.NoError(t, Write(&out, p, FormatUnified, true))
+	assert.Contains(t, out.String(), colorRed+"-# Old"+colorReset)
+	assert.Contains(t, out.String(), colorGreen+"+# Project"+colorReset)
+
+	out.Reset()
+	require.NoError(t, Write(&out, p, FormatJSON, true))
+	var decoded Preview
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, p.Summary, decoded.Summary)
+	require.Len(t, decoded.Files, 3)
+	assert.Equal(t, Change{Path: ".claude/agents/legacy.md", Status: StatusRemoved, OldSize: 9, Diff: p.Files[0].Diff}, decoded.Files[0])
+	assert.NotContains(t, out.String(), "\033[", "el JSON nunca lleva colores")
+
+	assert.Error(t, Write(&out, p, "html", false))
+}
@@ -0,0 +1,264 @@
+// Package review implementa la revisión interactiva de los archivos generados: antes de
+// confirmar el staging en el proyecto, el usuario acepta, rechaza, edita o pide a la IA
+// que regenere cada agent, skill, command y CLAUDE.md.
+package review
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	gSurvey "github.com/AlecAivazis/survey/v2"
+	"github.com/drossan/claude-init/internal/claude"
+	"github.com/drossan/claude-init/internal/preview"
+)
+
+// Action es la decisión del usuario sobre un archivo generado.
+type Action string
+
+// Acciones disponibles para cada archivo.
+const (
+	ActionAccept     Action = "accept"
+	ActionReject     Action = "reject"
+	ActionEdit       Action = "edit"
+	ActionRegenerate Action = "regenerate"
+)
+
+// reviewedKinds son los tipos de artefacto que se revisan; los índices README y la guía de
+// desarrollo se derivan de ellos.
+var reviewedKinds = map[string]bool{
+	claude.ArtifactAgent:    true,
+	claude.ArtifactSkill:    true,
+	claude.ArtifactCommand:  true,
+	claude.ArtifactClaudeMD: true,
+}
+
+// Prompter pregunta al usuario qué hacer con cada archivo.
+type Prompter interface {
+	// Choose pregunta qué hacer con el archivo indicado.
+	Choose(path string) (Action, error)
+	// Instructions pide las instrucciones adicionales para regenerar el archivo.
+	Instructions(path string) (string, error)
+}
+
+// Reviser es lo que la revisión necesita del generador: regenerar un archivo con
+// instrucciones, descartarlo y guardar el manifiesto. Lo implementa *claude.Generator.
+type Reviser interface {
+	Revise(path, instructions string) error
+	Discard(path string) error
+	SaveManifest() error
+}
+
+// Summary cuenta las decisiones tomadas en una revisión. Un archivo editado o regenerado y
+// después aceptado cuenta como editado o regenerado.
+type Summary struct {
+	Accepted    int
+	Rejected    int
+	Edited      int
+	Regenerated int
+}
+
+// Reviewer recorre los archivos generados en un staging y aplica las decisiones del usuario.
+type Reviewer struct {
+	out      io.Writer
+	prompter Prompter
+	editor   func(path string) error
+	color    bool
+}
+
+// NewReviewer crea un Reviewer que escribe en out y pregunta con prompter. Los archivos se
+// editan con $VISUAL o $EDITOR.
+func NewReviewer(out io.Writer, prompter Prompter) *Reviewer {
+	return &Reviewer{out: out, prompter: prompter, editor: OpenEditor, color: preview.ColorEnabled(out)}
+}
+
+// SetEditor establece la función que abre un archivo en el editor.
+func (r *Reviewer) SetEditor(editor func(path string) error) {
+	r.editor = editor
+}
+
+// Review muestra el contenido, o el diff respecto al proyecto, de cada archivo revisable
+// generado en stagedPath y aplica la decisión del usuario con reviser. Al terminar guarda
+// el manifiesto del staging.
+func (r *Reviewer) Review(projectPath, stagedPath string, reviser Reviser) (*Summary, error) {
+	manifest, err := claude.LoadManifest(stagedPath)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{}
+	var reviewed []string
+	for _, entry := range manifest.Files {
+		if !reviewedKinds[entry.Kind] {
+			continue
+		}
+		// Los archivos iguales que en el proyecto no se revisan
+		change, err := preview.CompareFile(projectPath, stagedPath, entry.Path)
+		if err != nil {
+			return nil, err
+		}
+		if change != nil {
+			reviewed = append(reviewed, entry.Path)
+		}
+	}
+
+	for i, path := range reviewed {
+		var last Action
+		for {
+			change, err := preview.CompareFile(projectPath, stagedPath, path)
+			if err != nil {
+				return nil, err
+			}
+			if change == nil {
+				break // Editado hasta dejarlo como en el proyecto
+			}
+
+			fmt.Fprintf(r.out, "\n[%d/%d] %s\n", i+1, len(reviewed), path)
+			if err := preview.WriteChange(r.out, change, stagedPath, r.color); err != nil {
+				return nil, err
+			}
+
+			action, err := r.prompter.Choose(path)
+			if err != nil {
+				return nil, err
+			}
+			if action == ActionAccept || action == ActionReject {
+				if action == ActionReject {
+					if err := reviser.Discard(path); err != nil {
+						return nil, err
+					}
+				}
+				if last == "" || action == ActionReject {
+					last = action
+				}
+				break
+			}
+
+			if err := r.apply(action, path, stagedPath, reviser); err != nil {
+				fmt.Fprintf(r.out, "%v\n", err)
+				continue
+			}
+			last = action
+		}
+		summary.count(last)
+	}
+
+	if err := reviser.SaveManifest(); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// apply edita o regenera un archivo.
+func (r *Reviewer) apply(action Action, path, stagedPath string, reviser Reviser) error {
+	switch action {
+	case ActionEdit:
+		if err := r.editor(filepath.Join(stagedPath, filepath.FromSlash(path))); err != nil {
+			return fmt.Errorf("failed to edit %s: %w", path, err)
+		}
+	case ActionRegenerate:
+		instructions, err := r.prompter.Instructions(path)
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(instructions) == "" {
+			return fmt.Errorf("no instructions given, %s was not regenerated", path)
+		}
+		if err := reviser.Revise(path, instructions); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown action %q", action)
+	}
+	return nil
+}
+
+// count suma la decisión final sobre un archivo.
+func (s *Summary) count(action Action) {
+	switch action {
+	case ActionAccept:
+		s.Accepted++
+	case ActionReject:
+		s.Rejected++
+	case ActionEdit:
+		s.Edited++
+	case ActionRegenerate:
+		s.Regenerated++
+	}
+}
+
+// String resume la revisión en una línea.
+func (s *Summary) String() string {
+	total := s.Accepted + s.Rejected + s.Edited + s.Regenerated
+	return fmt.Sprintf("Reviewed %d file(s): %d accepted, %d edited, %d regenerated, %d rejected.",
+		total, s.Accepted, s.Edited, s.Regenerated, s.Rejected)
+}
+
+// SurveyPrompter pregunta con prompts interactivos en la terminal.
+type SurveyPrompter struct{}
+
+// Opciones del prompt, en el orden en que se muestran.
+var choices = []struct {
+	label  string
+	action Action
+}{
+	{"Accept", ActionAccept},
+	{"Reject (keep the current file, or do not create it)", ActionReject},
+	{"Edit in $EDITOR", ActionEdit},
+	{"Regenerate with extra instructions", ActionRegenerate},
+}
+
+// Choose pregunta qué hacer con el archivo.
+func (SurveyPrompter) Choose(path string) (Action, error) {
+	options := make([]string, len(choices))
+	for i, choice := range choices {
+		options[i] = choice.label
+	}
+
+	var selected string
+	prompt := &gSurvey.Select{Message: fmt.Sprintf("What do you want to do with %s?", path), Options: options}
+	if err := gSurvey.AskOne(prompt, &selected); err != nil {
+		return "", err
+	}
+	for _, choice := range choices {
+		if choice.label == selected {
+			return choice.action, nil
+		}
+	}
+	return ActionAccept, nil
+}
+
+// Instructions pide las instrucciones para regenerar el archivo.
+func (SurveyPrompter) Instructions(path string) (string, error) {
+	var instructions string
+	prompt := &gSurvey.Input{Message: fmt.Sprintf("What should change in %s?", path)}
+	if err := gSurvey.AskOne(prompt, &instructions); err != nil {
+		return "", err
+	}
+	return instructions, nil
+}
+
+// OpenEditor abre un archivo en el editor del usuario ($VISUAL, $EDITOR o, si no hay
+// ninguno, vi o notepad) y espera a que se cierre.
+func OpenEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+
+	// El editor puede llevar argumentos, como "code --wait"
+	args := strings.Fields(editor)
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd.Run()
+}
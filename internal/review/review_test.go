@@ -0,0 +1,134 @@
+package review
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/claude"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedPrompter responde con las acciones indicadas, en orden.
+type scriptedPrompter struct {
+	actions      []Action
+	instructions string
+	asked        []string
+}
+
+func (p *scriptedPrompter) Choose(path string) (Action, error) {
+	p.asked = append(p.asked, path)
+	action := p.actions[0]
+	p.actions = p.actions[1:]
+	return action, nil
+}
+
+func (p *scriptedPrompter) Instructions(path string) (string, error) {
+	return p.instructions, nil
+}
+
+// fakeReviser registra las llamadas y aplica los cambios sobre el staging.
+type fakeReviser struct {
+	stagedPath  string
+	projectPath string
+	revised     map[string]string
+	discarded   []string
+	saved       bool
+}
+
+func (r *fakeReviser) Revise(path, instructions string) error {
+	r.revised[path] = instructions
+	return os.WriteFile(filepath.Join(r.stagedPath, path), []byte("# Revised\n"), 0644)
+}
+
+func (r *fakeReviser) Discard(path string) error {
+	r.discarded = append(r.discarded, path)
+	content, err := os.ReadFile(filepath.Join(r.projectPath, path))
+	if os.IsNotExist(err) {
+		return os.Remove(filepath.Join(r.stagedPath, path))
+	}
+	return os.WriteFile(filepath.Join(r.stagedPath, path), content, 0644)
+}
+
+func (r *fakeReviser) SaveManifest() error {
+	r.saved = true
+	return nil
+}
+
+// writeFiles crea los archivos indicados (ruta relativa -> contenido) dentro de dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+// TestReviewer_Review verifica que cada archivo revisable se muestra y se acepta, rechaza,
+// edita o regenera según la decisión del usuario.
+func TestReviewer_Review(t *testing.T) {
+	project := t.TempDir()
+	staged := t.TempDir()
+	writeFiles(t, project, map[string]string{
+		"CLAUDE.md":                "# Old\n",
+		".claude/commands/test.md": "# Test\n",
+	})
+	writeFiles(t, staged, map[string]string{
+		"CLAUDE.md":                   "# New\n",
+		".claude/agents/architect.md": "# Architect\n",
+		".claude/agents/legacy.md":    "# Legacy\n",
+		".claude/commands/test.md":    "# Test\n",
+		".claude/skills/go.md":        "# Go\n",
+		".claude/agents/README.md":    "# Agents\n",
+	})
+	manifest := &claude.Manifest{}
+	for _, entry := range []claude.ManifestEntry{
+		{Path: "CLAUDE.md", Kind: claude.ArtifactClaudeMD},
+		{Path: ".claude/agents/architect.md", Kind: claude.ArtifactAgent},
+		{Path: ".claude/agents/legacy.md", Kind: claude.ArtifactAgent},
+		{Path: ".claude/commands/test.md", Kind: claude.ArtifactCommand},
+		{Path: ".claude/skills/go.md", Kind: claude.ArtifactSkill},
+		{Path: ".claude/agents/README.md", Kind: claude.ArtifactReadme},
+	} {
+		manifest.Record(entry)
+	}
+	require.NoError(t, manifest.Save(staged))
+
+	prompter := &scriptedPrompter{
+		// architect: editar y aceptar; legacy: rechazar; go: regenerar y aceptar; CLAUDE.md: aceptar
+		actions:      []Action{ActionEdit, ActionAccept, ActionReject, ActionRegenerate, ActionAccept, ActionAccept},
+		instructions: "Shorter",
+	}
+	reviser := &fakeReviser{stagedPath: staged, projectPath: project, revised: map[string]string{}}
+	var out bytes.Buffer
+	reviewer := NewReviewer(&out, prompter)
+	reviewer.SetEditor(func(path string) error {
+		return os.WriteFile(path, []byte("# Architect (edited)\n"), 0644)
+	})
+
+	summary, err := reviewer.Review(project, staged, reviser)
+	require.NoError(t, err)
+
+	// El comando no cambia y el README no se revisa
+	assert.Equal(t, []string{
+		".claude/agents/architect.md", ".claude/agents/architect.md",
+		".claude/agents/legacy.md",
+		".claude/skills/go.md", ".claude/skills/go.md",
+		"CLAUDE.md",
+	}, prompter.asked)
+	assert.Equal(t, &Summary{Accepted: 1, Rejected: 1, Edited: 1, Regenerated: 1}, summary)
+	assert.Equal(t, "Reviewed 4 file(s): 1 accepted, 1 edited, 1 regenerated, 1 rejected.", summary.String())
+
+	assert.Equal(t, []string{".claude/agents/legacy.md"}, reviser.discarded)
+	assert.Equal(t, map[string]string{".claude/skills/go.md": "Shorter"}, reviser.revised)
+	assert.True(t, reviser.saved)
+	assert.NoFileExists(t, filepath.Join(staged, ".claude", "agents", "legacy.md"))
+
+	assert.Contains(t, out.String(), "[1/4] .claude/agents/architect.md\n=== .claude/agents/architect.md (new, 12 B) ===\n# Architect\n")
+	assert.Contains(t, out.String(), "[1/4] .claude/agents/architect.md\n=== .claude/agents/architect.md (new, 21 B) ===\n# Architect (edited)\n", "tras editar se muestra de nuevo")
+	assert.Contains(t, out.String(), "[3/4] .claude/skills/go.md\n")
+	assert.Contains(t, out.String(), "--- a/CLAUDE.md\n+++ b/CLAUDE.md\n@@ -1,1 +1,1 @@\n-# Old\n+# New\n")
+}
@@ -0,0 +1,619 @@
+package scanner
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultDocsBudget es el presupuesto de tokens por defecto para la documentación del proyecto.
+const DefaultDocsBudget = 4000
+
+// maxDocTokens limita lo que ocupa el resumen de un documento.
+const maxDocTokens = 500
+
+// maxADRTokens limita el resumen de cada ADR, que suelen ser muchos y breves.
+const maxADRTokens = 150
+
+// maxDocsPerKind limita los documentos de cada tipo.
+const maxDocsPerKind = 20
+
+// maxDocFileSize evita leer documentos o especificaciones enormes.
+const maxDocFileSize = 512 * 1024
+
+// maxChunkChars limita el párrafo que se conserva de cada sección.
+const maxChunkChars = 300
+
+// DocKind es el tipo de documento del proyecto.
+type DocKind string
+
+// Tipos de documento, en orden de prioridad.
+const (
+	DocContributing DocKind = "contributing"
+	DocArchitecture DocKind = "architecture"
+	DocADR          DocKind = "adr"
+	DocAPISpec      DocKind = "api-spec"
+	DocGuide        DocKind = "guide"
+)
+
+// docKinds es el orden en que se reparten los documentos.
+var docKinds = []DocKind{DocContributing, DocArchitecture, DocADR, DocAPISpec, DocGuide}
+
+// Document es el resumen de un documento del proyecto.
+type Document struct {
+	Path      string  `json:"path" yaml:"path"`
+	Kind      DocKind `json:"kind" yaml:"kind"`
+	Title     string  `json:"title,omitempty" yaml:"title,omitempty"`
+	Content   string  `json:"content" yaml:"content"`
+	Truncated bool    `json:"truncated,omitempty" yaml:"truncated,omitempty"` // Algunas secciones u operaciones no cabían
+}
+
+// docExtensions son las extensiones de los documentos de texto.
+var docExtensions = map[string]bool{".md": true, ".markdown": true, ".txt": true, ".rst": true, ".adoc": true}
+
+// specExtensions son las extensiones de las especificaciones de API.
+var specExtensions = map[string]bool{".yaml": true, ".yml": true, ".json": true}
+
+// adrDirs son los directorios habituales de los Architecture Decision Records.
+var adrDirs = []string{"adr", "adrs", "decisions", "decision-records", "architecture-decisions"}
+
+// adrPriority son las secciones de un ADR que se resumen primero.
+var adrPriority = []string{"status", "decision", "context", "consequences"}
+
+// specPattern reconoce la clave raíz de una especificación OpenAPI, Swagger o AsyncAPI.
+var specPattern = regexp.MustCompile(`(?m)^[\s{]*"?(openapi|swagger|asyncapi)"?\s*:`)
+
+// numberedDoc reconoce los nombres numerados de los ADR (0001-use-postgres.md).
+var numberedDoc = regexp.MustCompile(`^\d{3,4}[-_]`)
+
+// httpMethods son las operaciones de un path de OpenAPI, en orden de presentación.
+var httpMethods = []string{"get", "post", "put", "patch", "delete", "head", "options"}
+
+// docCandidate es un documento candidato a resumirse.
+type docCandidate struct {
+	rel   string
+	kind  DocKind
+	depth int
+}
+
+// Documents busca recursivamente la documentación del proyecto (CONTRIBUTING, documentos
+// de arquitectura, ADRs, especificaciones OpenAPI/AsyncAPI y los documentos de dirs) y la
+// resume por secciones dentro del presupuesto de tokens. Los documentos se reparten por
+// turnos entre los tipos para que ninguno acapare el presupuesto.
+func (s *Scanner) Documents(dirs []string) []Document {
+	budget := s.opts.DocsBudget
+	if budget <= 0 {
+		budget = DefaultDocsBudget
+	}
+
+	byKind := make(map[DocKind][]docCandidate)
+	for _, c := range s.docCandidates(dirs) {
+		byKind[c.kind] = append(byKind[c.kind], c)
+	}
+
+	var docs []Document
+	remaining := budget
+	for round := 0; round < maxDocsPerKind; round++ {
+		for _, kind := range docKinds {
+			if remaining < minSampleTokens {
+				return docs
+			}
+			candidates := byKind[kind]
+			if round >= len(candidates) {
+				continue
+			}
+
+			limit := maxDocTokens
+			if kind == DocADR {
+				limit = maxADRTokens
+			}
+			if limit > remaining {
+				limit = remaining
+			}
+			doc, ok := s.summarizeDocument(candidates[round], limit)
+			if !ok {
+				continue
+			}
+			docs = append(docs, doc)
+			remaining -= EstimateTokens(FormatDocuments([]Document{doc}))
+		}
+	}
+
+	return docs
+}
+
+// DocumentFiles retorna las rutas de todos los documentos que Documents puede resumir,
+// sin aplicar el presupuesto.
+func (s *Scanner) DocumentFiles(dirs []string) []string {
+	candidates := s.docCandidates(dirs)
+	files := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		files = append(files, c.rel)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// docCandidates recorre el proyecto y clasifica los documentos, ordenados por tipo y
+// profundidad (los menos profundos suelen ser los más generales).
+func (s *Scanner) docCandidates(dirs []string) []docCandidate {
+	docDirs := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if dir = strings.Trim(filepath.ToSlash(filepath.Clean(dir)), "/"); dir != "" && dir != "." {
+			docDirs = append(docDirs, dir)
+		}
+	}
+
+	var candidates []docCandidate
+	_ = s.Walk(func(rel string, entry fs.DirEntry) error {
+		if entry.IsDir() {
+			if entry.Name() == "testdata" || entry.Name() == "fixtures" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if kind, ok := s.classifyDoc(rel, docDirs); ok {
+			candidates = append(candidates, docCandidate{rel: rel, kind: kind, depth: strings.Count(rel, "/")})
+		}
+		return nil
+	})
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].kind != candidates[j].kind {
+			return docKindIndex(candidates[i].kind) < docKindIndex(candidates[j].kind)
+		}
+		if candidates[i].depth != candidates[j].depth {
+			return candidates[i].depth < candidates[j].depth
+		}
+		return candidates[i].rel < candidates[j].rel
+	})
+	return candidates
+}
+
+// docKindIndex retorna la prioridad de un tipo de documento.
+func docKindIndex(kind DocKind) int {
+	for i, k := range docKinds {
+		if k == kind {
+			return i
+		}
+	}
+	return len(docKinds)
+}
+
+// classifyDoc asigna un tipo de documento a un archivo, o false si no es documentación.
+func (s *Scanner) classifyDoc(rel string, docDirs []string) (DocKind, bool) {
+	base := strings.ToLower(path.Base(rel))
+	ext := path.Ext(base)
+	name := strings.TrimSuffix(base, ext)
+	dirs := strings.Split(strings.ToLower(path.Dir(rel)), "/")
+	inDocDir := underAny(rel, docDirs)
+
+	if docExtensions[ext] {
+		switch {
+		case name == "contributing":
+			return DocContributing, true
+		case name == "architecture" || name == "design":
+			return DocArchitecture, true
+		case containsAny(dirs, adrDirs...) || inDocDir && numberedDoc.MatchString(name):
+			return DocADR, true
+		case inDocDir:
+			return DocGuide, true
+		}
+		return "", false
+	}
+
+	if specExtensions[ext] {
+		if strings.HasPrefix(name, "openapi") || strings.HasPrefix(name, "swagger") || strings.HasPrefix(name, "asyncapi") {
+			return DocAPISpec, true
+		}
+		// Otros YAML/JSON solo son especificaciones si declaran la versión del estándar
+		head, err := readHead(filepath.Join(s.root, filepath.FromSlash(rel)), 512)
+		if err == nil && specPattern.MatchString(head) {
+			return DocAPISpec, true
+		}
+	}
+
+	return "", false
+}
+
+// underAny indica si rel está dentro de alguno de los directorios indicados.
+func underAny(rel string, dirs []string) bool {
+	for _, dir := range dirs {
+		if strings.HasPrefix(rel, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// summarizeDocument lee un documento y lo resume en limit tokens como máximo.
+func (s *Scanner) summarizeDocument(c docCandidate, limit int) (Document, bool) {
+	content, err := readHead(filepath.Join(s.root, filepath.FromSlash(c.rel)), maxDocFileSize)
+	if err != nil || strings.TrimSpace(content) == "" || strings.ContainsRune(content, 0) {
+		return Document{}, false
+	}
+
+	doc := Document{Path: c.rel, Kind: c.kind}
+	// Se reserva espacio para la cabecera del documento
+	limit -= EstimateTokens(fmt.Sprintf("\n--- %s (%s, truncated) ---\n", c.rel, c.kind))
+
+	var lines []string
+	if c.kind == DocAPISpec {
+		doc.Title, lines = summarizeSpec(content)
+	} else {
+		var priority []string
+		if c.kind == DocADR {
+			priority = adrPriority
+		}
+		doc.Title, lines = summarizeText(content, priority)
+	}
+	if len(lines) == 0 && doc.Title == "" {
+		return Document{}, false
+	}
+	limit -= EstimateTokens(doc.Title)
+
+	var kept []string
+	used := 0
+	for _, line := range lines {
+		tokens := EstimateTokens(line + "\n")
+		if used+tokens > limit {
+			doc.Truncated = true
+			break
+		}
+		kept = append(kept, line)
+		used += tokens
+	}
+	doc.Content = strings.Join(kept, "\n")
+	return doc, true
+}
+
+// section es un encabezado de un documento con su primer párrafo.
+type section struct {
+	heading   string
+	paragraph string
+}
+
+// summarizeText divide un documento de texto en secciones y retorna su título y una línea
+// por sección con el encabezado y el primer párrafo. Las secciones cuyo encabezado
+// contiene alguna de las palabras de priority van primero.
+func summarizeText(content string, priority []string) (string, []string) {
+	sections := splitSections(stripFrontmatter(content))
+	if len(sections) == 0 {
+		return "", nil
+	}
+
+	title := ""
+	if sections[0].heading != "" {
+		title = sections[0].heading
+	}
+
+	rank := func(s section) int {
+		heading := strings.ToLower(s.heading)
+		for i, word := range priority {
+			if strings.Contains(heading, word) {
+				return i
+			}
+		}
+		return len(priority)
+	}
+	// El título conserva su posición: suele contener el estado o el resumen del documento
+	rest := append([]section(nil), sections[1:]...)
+	sort.SliceStable(rest, func(i, j int) bool { return rank(rest[i]) < rank(rest[j]) })
+
+	var lines []string
+	if sections[0].paragraph != "" {
+		lines = append(lines, sections[0].paragraph)
+	}
+	for _, s := range rest {
+		switch {
+		case s.paragraph != "":
+			lines = append(lines, fmt.Sprintf("- %s: %s", s.heading, s.paragraph))
+		case s.heading != "":
+			lines = append(lines, "- "+s.heading)
+		}
+	}
+	return title, lines
+}
+
+// stripFrontmatter elimina el frontmatter YAML del principio de un documento.
+func stripFrontmatter(content string) string {
+	trimmed := strings.TrimLeft(content, "\ufeff \t\r\n")
+	if !strings.HasPrefix(trimmed, "---\n") {
+		return content
+	}
+	if end := strings.Index(trimmed[4:], "\n---"); end >= 0 {
+		return trimmed[4+end+4:]
+	}
+	return content
+}
+
+// splitSections separa un documento en secciones por sus encabezados markdown, omitiendo
+// los bloques de código. Solo se conserva el primer párrafo (o los primeros elementos de
+// una lista) de cada sección.
+func splitSections(content string) []section {
+	var (
+		sections  []section
+		current   section
+		paragraph []string
+		done      bool // el primer párrafo de la sección ya está completo
+		inCode    bool
+	)
+
+	flush := func() {
+		if len(paragraph) > 0 && current.paragraph == "" {
+			current.paragraph = truncateText(strings.Join(paragraph, " "), maxChunkChars)
+		}
+		paragraph = nil
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "#") {
+			flush()
+			if current.heading != "" || current.paragraph != "" {
+				sections = append(sections, current)
+			}
+			current = section{heading: strings.TrimSpace(strings.TrimLeft(trimmed, "#"))}
+			done = false
+			continue
+		}
+
+		if trimmed == "" {
+			if len(paragraph) > 0 {
+				flush()
+				done = true
+			}
+			continue
+		}
+		if done || strings.HasPrefix(trimmed, "<!--") || strings.HasPrefix(trimmed, "|") {
+			continue
+		}
+		// Las listas se limitan a sus primeros elementos
+		if isListItem(trimmed) && countListItems(paragraph) >= 3 {
+			continue
+		}
+		paragraph = append(paragraph, trimmed)
+	}
+
+	flush()
+	if current.heading != "" || current.paragraph != "" {
+		sections = append(sections, current)
+	}
+	return sections
+}
+
+// isListItem indica si una línea es un elemento de lista markdown.
+func isListItem(line string) bool {
+	return strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") || strings.HasPrefix(line, "+ ") ||
+		len(line) > 2 && line[0] >= '0' && line[0] <= '9' && strings.Contains(line[:3], ".")
+}
+
+// countListItems cuenta los elementos de lista de un párrafo.
+func countListItems(lines []string) int {
+	count := 0
+	for _, line := range lines {
+		if isListItem(line) {
+			count++
+		}
+	}
+	return count
+}
+
+// truncateText corta el texto en el último espacio antes de maxChars.
+func truncateText(text string, maxChars int) string {
+	if len(text) <= maxChars {
+		return text
+	}
+	text = text[:maxChars]
+	if i := strings.LastIndexByte(text, ' '); i > 0 {
+		text = text[:i]
+	}
+	return text + "..."
+}
+
+// summarizeSpec resume una especificación OpenAPI, Swagger o AsyncAPI: título, versión,
+// servidores y una línea por operación o canal.
+func summarizeSpec(content string) (string, []string) {
+	var spec map[string]any
+	if err := yaml.Unmarshal([]byte(content), &spec); err != nil {
+		return "", nil
+	}
+
+	standard := ""
+	for _, key := range []string{"openapi", "swagger", "asyncapi"} {
+		if version, ok := spec[key]; ok {
+			standard = fmt.Sprintf("%s %v", specNames[key], version)
+			break
+		}
+	}
+	if standard == "" {
+		return "", nil
+	}
+
+	title := standard
+	if info, ok := spec["info"].(map[string]any); ok {
+		if name := stringField(info, "title"); name != "" {
+			title = fmt.Sprintf("%s: %s", standard, name)
+		}
+		if version := stringField(info, "version"); version != "" {
+			title += " v" + version
+		}
+	}
+
+	var lines []string
+	if servers := specServers(spec); len(servers) > 0 {
+		lines = append(lines, "- Servers: "+strings.Join(servers, ", "))
+	}
+	lines = append(lines, specOperations(spec)...)
+	lines = append(lines, specChannels(spec)...)
+	return title, lines
+}
+
+// specNames son los nombres de los estándares de especificación por clave raíz.
+var specNames = map[string]string{"openapi": "OpenAPI", "swagger": "Swagger", "asyncapi": "AsyncAPI"}
+
+// specServers retorna las URLs de los servidores (OpenAPI 3, AsyncAPI) o el host (Swagger 2).
+func specServers(spec map[string]any) []string {
+	var servers []string
+	switch value := spec["servers"].(type) {
+	case []any:
+		for _, server := range value {
+			if fields, ok := server.(map[string]any); ok {
+				if url := stringField(fields, "url"); url != "" {
+					servers = append(servers, url)
+				}
+			}
+		}
+	case map[string]any:
+		for _, name := range sortedKeys(value) {
+			if fields, ok := value[name].(map[string]any); ok {
+				url := stringField(fields, "url")
+				if url == "" {
+					url = stringField(fields, "host")
+				}
+				if url != "" {
+					servers = append(servers, url)
+				}
+			}
+		}
+	}
+	if host := stringField(spec, "host"); host != "" {
+		servers = append(servers, host+stringField(spec, "basePath"))
+	}
+	return servers
+}
+
+// specOperations retorna una línea por operación HTTP de los paths de OpenAPI/Swagger.
+func specOperations(spec map[string]any) []string {
+	paths, ok := spec["paths"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	var lines []string
+	for _, route := range sortedKeys(paths) {
+		operations, ok := paths[route].(map[string]any)
+		if !ok {
+			continue
+		}
+		for _, method := range httpMethods {
+			operation, ok := operations[method].(map[string]any)
+			if !ok {
+				continue
+			}
+			line := fmt.Sprintf("- %s %s", strings.ToUpper(method), route)
+			if summary := operationSummary(operation); summary != "" {
+				line += ": " + summary
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// specChannels retorna una línea por canal de AsyncAPI 2 o por operación de AsyncAPI 3.
+func specChannels(spec map[string]any) []string {
+	if _, ok := spec["asyncapi"]; !ok {
+		return nil
+	}
+
+	var lines []string
+	if operations, ok := spec["operations"].(map[string]any); ok {
+		for _, name := range sortedKeys(operations) {
+			operation, _ := operations[name].(map[string]any)
+			line := "- " + strings.TrimSpace(stringField(operation, "action")+" "+name)
+			if summary := operationSummary(operation); summary != "" {
+				line += ": " + summary
+			}
+			lines = append(lines, line)
+		}
+		return lines
+	}
+
+	channels, ok := spec["channels"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	for _, name := range sortedKeys(channels) {
+		channel, _ := channels[name].(map[string]any)
+		found := false
+		for _, action := range []string{"publish", "subscribe"} {
+			if operation, ok := channel[action].(map[string]any); ok {
+				line := fmt.Sprintf("- %s %s", action, name)
+				if summary := operationSummary(operation); summary != "" {
+					line += ": " + summary
+				}
+				lines = append(lines, line)
+				found = true
+			}
+		}
+		if !found {
+			lines = append(lines, "- channel "+name)
+		}
+	}
+	return lines
+}
+
+// operationSummary retorna el resumen, el id o la descripción de una operación.
+func operationSummary(operation map[string]any) string {
+	for _, key := range []string{"summary", "operationId", "description"} {
+		if value := stringField(operation, key); value != "" {
+			return truncateText(strings.Join(strings.Fields(value), " "), 120)
+		}
+	}
+	return ""
+}
+
+// stringField retorna un campo de texto (o número) de un mapa, o "" si no existe.
+func stringField(fields map[string]any, key string) string {
+	switch value := fields[key].(type) {
+	case string:
+		return strings.TrimSpace(value)
+	case int, float64:
+		return fmt.Sprint(value)
+	}
+	return ""
+}
+
+// sortedKeys retorna las claves de un mapa en orden alfabético.
+func sortedKeys(fields map[string]any) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// FormatDocuments formatea los resúmenes de documentación para incluirlos en un prompt.
+func FormatDocuments(docs []Document) string {
+	var sb strings.Builder
+	for _, doc := range docs {
+		note := string(doc.Kind)
+		if doc.Title != "" {
+			note += ": " + doc.Title
+		}
+		if doc.Truncated {
+			note += ", truncated"
+		}
+		sb.WriteString(fmt.Sprintf("\n--- %s (%s) ---\n", doc.Path, note))
+		if doc.Content != "" {
+			sb.WriteString(doc.Content + "\n")
+		}
+	}
+	return sb.String()
+}
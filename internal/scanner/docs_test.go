@@ -0,0 +1,125 @@
+package scanner
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDocuments verifica la búsqueda recursiva y el resumen de CONTRIBUTING, ADRs,
+// especificaciones de API y guías.
+func TestDocuments(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"CONTRIBUTING.md":            "# Contributing\n\nOpen an issue before large changes.\n\n## Pull requests\n\nOne change per PR, with tests.\n\n```bash\nmake test\n```\n",
+		"docs/guides/setup/local.md": "---\ntitle: Local setup\n---\n# Local setup\n\nRun `docker compose up` and then the API.\n",
+		"docs/architecture/decisions/0001-use-postgres.md": `# 1. Use PostgreSQL
+
+## Context
+
+We need transactions across orders and payments.
+
+## Consequences
+
+Migrations are managed with goose.
+
+## Decision
+
+We will use PostgreSQL 16 as the only datastore.
+
+## Status
+
+Accepted
+`,
+		"api/openapi.yaml": `openapi: 3.0.3
+info:
+  title: Orders API
+  version: 1.2.0
+servers:
+  - url: https://api.example.com/v1
+paths:
+  /orders:
+    get:
+      summary: List orders
+    post:
+      operationId: createOrder
+  /orders/{id}:
+    delete: {}
+`,
+		"events/spec.yml": `asyncapi: 2.6.0
+info:
+  title: Order events
+channels:
+  orders/created:
+    subscribe:
+      summary: An order was created
+`,
+		"deploy/values.yaml":     "replicas: 2\n",
+		"node_modules/x/ADR.md":  "# ignored\n",
+		"internal/orders/doc.md": "# Not a doc dir\n",
+	})
+
+	docs := NewScanner(dir, Options{}).Documents([]string{"docs"})
+	byPath := make(map[string]Document)
+	for _, doc := range docs {
+		byPath[doc.Path] = doc
+	}
+	require.Len(t, docs, 5)
+
+	contributing := byPath["CONTRIBUTING.md"]
+	assert.Equal(t, DocContributing, contributing.Kind)
+	assert.Equal(t, "Contributing", contributing.Title)
+	assert.Equal(t, "Open an issue before large changes.\n- Pull requests: One change per PR, with tests.", contributing.Content)
+
+	adr := byPath["docs/architecture/decisions/0001-use-postgres.md"]
+	assert.Equal(t, DocADR, adr.Kind)
+	assert.Equal(t, "1. Use PostgreSQL", adr.Title)
+	assert.True(t, strings.HasPrefix(adr.Content, "- Status: Accepted\n- Decision: We will use PostgreSQL 16"), adr.Content)
+
+	openapi := byPath["api/openapi.yaml"]
+	assert.Equal(t, DocAPISpec, openapi.Kind)
+	assert.Equal(t, "OpenAPI 3.0.3: Orders API v1.2.0", openapi.Title)
+	assert.Equal(t, "- Servers: https://api.example.com/v1\n- GET /orders: List orders\n- POST /orders: createOrder\n- DELETE /orders/{id}", openapi.Content)
+
+	asyncapi := byPath["events/spec.yml"]
+	assert.Equal(t, "AsyncAPI 2.6.0: Order events", asyncapi.Title)
+	assert.Equal(t, "- subscribe orders/created: An order was created", asyncapi.Content)
+
+	guide := byPath["docs/guides/setup/local.md"]
+	assert.Equal(t, DocGuide, guide.Kind)
+	assert.Equal(t, "Local setup", guide.Title)
+
+	assert.Equal(t, []string{
+		"CONTRIBUTING.md",
+		"api/openapi.yaml",
+		"docs/architecture/decisions/0001-use-postgres.md",
+		"docs/guides/setup/local.md",
+		"events/spec.yml",
+	}, NewScanner(dir, Options{}).DocumentFiles([]string{"docs"}))
+
+	formatted := FormatDocuments(docs)
+	assert.Contains(t, formatted, "--- api/openapi.yaml (api-spec: OpenAPI 3.0.3: Orders API v1.2.0) ---")
+}
+
+// TestDocuments_Budget verifica que los documentos se ajustan al presupuesto de tokens.
+func TestDocuments_Budget(t *testing.T) {
+	dir := t.TempDir()
+	var sb strings.Builder
+	sb.WriteString("# Guide\n")
+	for i := 0; i < 200; i++ {
+		sb.WriteString("\n## Section\n\nA long paragraph describing one part of the system in some detail.\n")
+	}
+	writeFiles(t, dir, map[string]string{
+		"docs/a.md": sb.String(),
+		"docs/b.md": sb.String(),
+		"docs/c.md": sb.String(),
+	})
+
+	docs := NewScanner(dir, Options{DocsBudget: 700}).Documents([]string{"docs"})
+	require.NotEmpty(t, docs)
+	assert.True(t, docs[0].Truncated)
+	assert.LessOrEqual(t, EstimateTokens(FormatDocuments(docs)), 700)
+	assert.Less(t, len(docs), 3)
+}
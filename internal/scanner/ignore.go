@@ -0,0 +1,193 @@
+package scanner
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Matcher evalúa rutas contra patrones con la sintaxis de .gitignore.
+//
+// Soporta comentarios, negación (!), patrones solo para directorios (sufijo /),
+// patrones anclados (con / inicial o intermedia), *, ?, [...] y **.
+// Como en git, la última regla que coincide decide.
+type Matcher struct {
+	rules []ignoreRule
+}
+
+// ignoreRule es un patrón compilado junto con el directorio donde se declaró.
+type ignoreRule struct {
+	base    string // Directorio relativo a la raíz ("" para la raíz)
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// NewMatcher crea un Matcher vacío.
+func NewMatcher() *Matcher {
+	return &Matcher{}
+}
+
+// AddPatterns añade patrones declarados en el directorio base (relativo a la raíz, con "/").
+func (m *Matcher) AddPatterns(base string, patterns ...string) {
+	base = strings.Trim(path.Clean("/"+base), "/")
+	for _, pattern := range patterns {
+		if rule, ok := compilePattern(base, pattern); ok {
+			m.rules = append(m.rules, rule)
+		}
+	}
+}
+
+// AddFile añade los patrones de un archivo de ignorados. Si el archivo no existe, no hace nada.
+func (m *Matcher) AddFile(base, filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read ignore file %s: %w", filePath, err)
+	}
+	defer file.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		patterns = append(patterns, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read ignore file %s: %w", filePath, err)
+	}
+
+	m.AddPatterns(base, patterns...)
+	return nil
+}
+
+// Empty indica si el Matcher no tiene reglas.
+func (m *Matcher) Empty() bool {
+	return len(m.rules) == 0
+}
+
+// Match indica si la ruta (relativa a la raíz, con "/") coincide con los patrones.
+func (m *Matcher) Match(rel string, isDir bool) bool {
+	matched := false
+	for _, rule := range m.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+
+		sub := rel
+		if rule.base != "" {
+			if !strings.HasPrefix(rel, rule.base+"/") {
+				continue
+			}
+			sub = strings.TrimPrefix(rel, rule.base+"/")
+		}
+
+		if rule.re.MatchString(sub) {
+			matched = !rule.negate
+		}
+	}
+	return matched
+}
+
+// MatchPathOrParent indica si la ruta o alguno de sus directorios padre coincide.
+// Se usa para los globs de --include, donde "src" incluye todo lo que hay debajo.
+func (m *Matcher) MatchPathOrParent(rel string, isDir bool) bool {
+	if m.Match(rel, isDir) {
+		return true
+	}
+	for dir := path.Dir(rel); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if m.Match(dir, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// compilePattern convierte una línea de .gitignore en una regla.
+func compilePattern(base, pattern string) (ignoreRule, bool) {
+	pattern = strings.TrimRight(pattern, " \t\r")
+	if pattern == "" || strings.HasPrefix(pattern, "#") {
+		return ignoreRule{}, false
+	}
+
+	rule := ignoreRule{base: base}
+	if strings.HasPrefix(pattern, "!") {
+		rule.negate = true
+		pattern = pattern[1:]
+	} else if strings.HasPrefix(pattern, `\!`) || strings.HasPrefix(pattern, `\#`) {
+		pattern = pattern[1:]
+	}
+
+	if strings.HasSuffix(pattern, "/") {
+		rule.dirOnly = true
+		pattern = strings.TrimRight(pattern, "/")
+	}
+	if pattern == "" {
+		return ignoreRule{}, false
+	}
+
+	// Un patrón con "/" al inicio o en medio se ancla al directorio base
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	expr := globToRegexp(pattern)
+	if anchored {
+		expr = "^" + expr + "$"
+	} else {
+		expr = "^(?:.*/)?" + expr + "$"
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return ignoreRule{}, false
+	}
+	rule.re = re
+	return rule, true
+}
+
+// globToRegexp traduce un glob de .gitignore a una expresión regular.
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "/**") && i+3 == len(glob):
+			sb.WriteString("/.*")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end == -1 {
+				sb.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(glob):
+			i++
+			sb.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return sb.String()
+}
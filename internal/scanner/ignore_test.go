@@ -0,0 +1,73 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMatcher_Match verifica la sintaxis de .gitignore soportada.
+func TestMatcher_Match(t *testing.T) {
+	m := NewMatcher()
+	m.AddPatterns("",
+		"# comentario",
+		"*.log",
+		"!keep.log",
+		"tmp/",
+		"/root-only.txt",
+		"docs/*.pdf",
+		"**/generated",
+		"assets/**",
+		"file[0-9].txt",
+	)
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"app.log", false, true},
+		{"logs/app.log", false, true},
+		{"keep.log", false, false},
+		{"tmp", true, true},
+		{"src/tmp", true, true},
+		{"tmp", false, false},
+		{"root-only.txt", false, true},
+		{"sub/root-only.txt", false, false},
+		{"docs/manual.pdf", false, true},
+		{"docs/v1/manual.pdf", false, false},
+		{"generated", true, true},
+		{"pkg/api/generated", true, true},
+		{"assets/img/logo.png", false, true},
+		{"assets", true, false},
+		{"file1.txt", false, true},
+		{"fileA.txt", false, false},
+		{"main.go", false, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, m.Match(tt.path, tt.isDir), "path %s (dir=%v)", tt.path, tt.isDir)
+	}
+}
+
+// TestMatcher_NestedBase verifica que los patrones de un .gitignore anidado solo aplican a su directorio.
+func TestMatcher_NestedBase(t *testing.T) {
+	m := NewMatcher()
+	m.AddPatterns("web", "*.css", "/local.json")
+
+	assert.True(t, m.Match("web/styles/app.css", false))
+	assert.True(t, m.Match("web/local.json", false))
+	assert.False(t, m.Match("web/src/local.json", false))
+	assert.False(t, m.Match("api/app.css", false))
+}
+
+// TestMatcher_MatchPathOrParent verifica que un directorio incluido incluye su contenido.
+func TestMatcher_MatchPathOrParent(t *testing.T) {
+	m := NewMatcher()
+	m.AddPatterns("", "src", "*.md")
+
+	assert.True(t, m.MatchPathOrParent("src/app/main.go", false))
+	assert.True(t, m.MatchPathOrParent("docs/README.md", false))
+	assert.False(t, m.MatchPathOrParent("cmd/main.go", false))
+	assert.True(t, NewMatcher().Empty())
+}
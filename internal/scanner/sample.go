@@ -0,0 +1,317 @@
+package scanner
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultSampleBudget es el presupuesto de tokens por defecto para las muestras de código.
+const DefaultSampleBudget = 4000
+
+// maxSampleTokens limita lo que ocupa una sola muestra, para repartir el presupuesto entre varios archivos.
+const maxSampleTokens = 600
+
+// minSampleTokens es el espacio mínimo que debe quedar en el presupuesto para añadir otra muestra.
+const minSampleTokens = 80
+
+// maxSamplesPerKind limita las muestras de cada tipo.
+const maxSamplesPerKind = 3
+
+// maxSampleFileSize evita leer archivos enormes (generados, minificados...).
+const maxSampleFileSize = 256 * 1024
+
+// SampleKind es el tipo de archivo representativo.
+type SampleKind string
+
+// Tipos de muestra, en orden de prioridad.
+const (
+	SampleEntrypoint SampleKind = "entrypoint"
+	SampleRouter     SampleKind = "router"
+	SampleModel      SampleKind = "model"
+	SampleTest       SampleKind = "test"
+	SampleConfig     SampleKind = "config"
+)
+
+// sampleKinds es el orden en que se reparten las muestras.
+var sampleKinds = []SampleKind{SampleEntrypoint, SampleRouter, SampleModel, SampleTest, SampleConfig}
+
+// Sample es un extracto de un archivo representativo del proyecto.
+type Sample struct {
+	Path       string     `json:"path" yaml:"path"`
+	Kind       SampleKind `json:"kind" yaml:"kind"`
+	Content    string     `json:"content" yaml:"content"`
+	Signatures bool       `json:"signatures,omitempty" yaml:"signatures,omitempty"` // Solo declaraciones, el archivo no cabía entero
+	Truncated  bool       `json:"truncated,omitempty" yaml:"truncated,omitempty"`
+}
+
+// sourceExtensions son las extensiones de código que pueden ser muestras (salvo config).
+var sourceExtensions = map[string]bool{
+	".go": true, ".ts": true, ".tsx": true, ".js": true, ".jsx": true, ".mjs": true,
+	".py": true, ".java": true, ".kt": true, ".cs": true, ".php": true, ".rb": true,
+	".rs": true, ".ex": true, ".exs": true, ".swift": true, ".dart": true, ".cpp": true, ".cc": true,
+}
+
+// entrypointNames son nombres de archivo típicos de puntos de entrada.
+var entrypointNames = map[string]bool{
+	"main.go": true, "main.py": true, "__main__.py": true, "app.py": true, "manage.py": true, "wsgi.py": true,
+	"asgi.py": true, "index.ts": true, "index.js": true, "main.ts": true, "main.js": true, "server.ts": true,
+	"server.js": true, "app.ts": true, "app.js": true, "main.rs": true, "program.cs": true, "main.java": true,
+	"main.kt": true, "index.php": true, "main.dart": true, "main.swift": true, "application.ex": true,
+}
+
+// routerDirs y routerNames identifican rutas, controladores y handlers.
+var (
+	routerDirs  = []string{"routes", "router", "routers", "controllers", "controller", "handlers", "handler", "api", "endpoints", "views", "resolvers"}
+	routerNames = []string{"route", "router", "controller", "handler", "urls", "endpoint", "resolver", "views"}
+)
+
+// modelDirs y modelNames identifican modelos de dominio, entidades y esquemas.
+var (
+	modelDirs  = []string{"models", "model", "domain", "entities", "entity", "schemas", "schema", "types"}
+	modelNames = []string{"model", "entity", "schema", "types"}
+)
+
+// configNames son archivos de configuración de herramientas que revelan convenciones.
+var configNames = map[string]bool{
+	"tsconfig.json": true, ".eslintrc": true, ".eslintrc.js": true, ".eslintrc.cjs": true, ".eslintrc.json": true,
+	".eslintrc.yml": true, "eslint.config.js": true, "eslint.config.mjs": true, ".prettierrc": true, ".prettierrc.json": true,
+	".golangci.yml": true, ".golangci.yaml": true, "ruff.toml": true, ".ruff.toml": true, "setup.cfg": true, "tox.ini": true,
+	".editorconfig": true, "jest.config.js": true, "jest.config.ts": true, "vitest.config.ts": true, "rustfmt.toml": true,
+	".rubocop.yml": true, "phpstan.neon": true, "makefile": true, "dockerfile": true, ".env.example": true,
+}
+
+// signaturePattern reconoce líneas de declaración en los lenguajes soportados:
+// funciones, tipos, clases, interfaces, decoradores y anotaciones.
+var signaturePattern = regexp.MustCompile(`^\s*(?:` +
+	`(?:export\s+)?(?:default\s+)?(?:abstract\s+)?(?:async\s+)?(?:function|class|interface|type|enum)\b|` +
+	`(?:pub(?:\([a-z]+\))?\s+)?(?:async\s+)?(?:fn|struct|enum|trait|impl|mod)\b|` +
+	`func\b|type\b|package\b|` +
+	`(?:async\s+)?def\b|defp\b|defmodule\b|module\b|` +
+	`(?:public|private|protected|internal)\b|` +
+	`@[A-Za-z]|` +
+	`export\s+(?:const|let)\b|` +
+	`(?:router|app|r|e|api)\.(?:get|post|put|patch|delete|route|use|group|handle|HandleFunc)\b)`)
+
+// candidate es un archivo candidato a muestra.
+type candidate struct {
+	rel   string
+	kind  SampleKind
+	depth int
+}
+
+// Samples selecciona archivos representativos (entrypoints, routers, modelos, tests y
+// configuración) y extrae su contenido o sus declaraciones dentro del presupuesto de tokens.
+// Las muestras se reparten por turnos entre los tipos para que ninguno acapare el presupuesto.
+func (s *Scanner) Samples() []Sample {
+	budget := s.opts.SampleBudget
+	if budget <= 0 {
+		budget = DefaultSampleBudget
+	}
+
+	byKind := make(map[SampleKind][]candidate)
+	_ = s.Walk(func(rel string, entry fs.DirEntry) error {
+		if entry.IsDir() {
+			if entry.Name() == "testdata" || entry.Name() == "fixtures" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if kind, ok := classify(rel); ok {
+			byKind[kind] = append(byKind[kind], candidate{rel: rel, kind: kind, depth: strings.Count(rel, "/")})
+		}
+		return nil
+	})
+
+	// Los archivos menos profundos suelen ser los más representativos
+	for _, candidates := range byKind {
+		sort.Slice(candidates, func(i, j int) bool {
+			if candidates[i].depth != candidates[j].depth {
+				return candidates[i].depth < candidates[j].depth
+			}
+			return candidates[i].rel < candidates[j].rel
+		})
+	}
+
+	var samples []Sample
+	remaining := budget
+	for round := 0; round < maxSamplesPerKind; round++ {
+		for _, kind := range sampleKinds {
+			if remaining < minSampleTokens {
+				return samples
+			}
+			candidates := byKind[kind]
+			if round >= len(candidates) {
+				continue
+			}
+
+			limit := remaining
+			if limit > maxSampleTokens {
+				limit = maxSampleTokens
+			}
+			sample, ok := s.extract(candidates[round], limit)
+			if !ok {
+				continue
+			}
+			samples = append(samples, sample)
+			remaining -= EstimateTokens(FormatSamples([]Sample{sample}))
+		}
+	}
+
+	return samples
+}
+
+// classify asigna un tipo de muestra a un archivo, o false si no es representativo.
+func classify(rel string) (SampleKind, bool) {
+	name := strings.ToLower(path.Base(rel))
+	if configNames[name] {
+		return SampleConfig, true
+	}
+
+	ext := path.Ext(name)
+	if !sourceExtensions[ext] {
+		return "", false
+	}
+	base := strings.TrimSuffix(name, ext)
+	dirs := strings.Split(strings.ToLower(path.Dir(rel)), "/")
+
+	switch {
+	case isTestName(base, ext) || containsAny(dirs, "test", "tests", "__tests__", "spec"):
+		return SampleTest, true
+	case entrypointNames[name] || strings.HasSuffix(name, "application.java") || strings.HasSuffix(name, "application.kt"):
+		return SampleEntrypoint, true
+	case containsAny(dirs, routerDirs...) || hasAnyFragment(base, routerNames...):
+		return SampleRouter, true
+	case containsAny(dirs, modelDirs...) || hasAnyFragment(base, modelNames...):
+		return SampleModel, true
+	}
+	return "", false
+}
+
+// isTestName indica si el nombre (sin extensión) sigue una convención de test.
+func isTestName(base, ext string) bool {
+	return strings.HasSuffix(base, "_test") ||
+		strings.HasPrefix(base, "test_") ||
+		strings.HasSuffix(base, ".test") ||
+		strings.HasSuffix(base, ".spec") ||
+		strings.HasSuffix(base, "test") && (ext == ".java" || ext == ".cs" || ext == ".kt")
+}
+
+// containsAny indica si alguno de los directorios está en la lista.
+func containsAny(dirs []string, names ...string) bool {
+	for _, dir := range dirs {
+		for _, name := range names {
+			if dir == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasAnyFragment indica si el nombre contiene alguno de los fragmentos.
+func hasAnyFragment(base string, fragments ...string) bool {
+	for _, fragment := range fragments {
+		if strings.Contains(base, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// extract lee un candidato y lo ajusta a limit tokens: el archivo completo si cabe,
+// sus declaraciones si no, y como último recurso las primeras líneas.
+func (s *Scanner) extract(c candidate, limit int) (Sample, bool) {
+	content, err := readHead(filepath.Join(s.root, filepath.FromSlash(c.rel)), maxSampleFileSize)
+	if err != nil || strings.TrimSpace(content) == "" || strings.ContainsRune(content, 0) {
+		return Sample{}, false
+	}
+
+	sample := Sample{Path: c.rel, Kind: c.kind}
+	// Se reserva espacio para la cabecera de la muestra
+	limit -= EstimateTokens(fmt.Sprintf("\n--- %s (%s, signatures only, truncated) ---\n", c.rel, c.kind))
+
+	content = strings.TrimSpace(content)
+	if EstimateTokens(content) <= limit {
+		sample.Content = content
+		return sample, true
+	}
+
+	if c.kind != SampleConfig {
+		if signatures := extractSignatures(content); signatures != "" {
+			sample.Signatures = true
+			content = signatures
+			if EstimateTokens(content) <= limit {
+				sample.Content = content
+				return sample, true
+			}
+		}
+	}
+
+	sample.Content = truncateLines(content, limit*4)
+	sample.Truncated = true
+	return sample, true
+}
+
+// readHead lee como máximo maxBytes de un archivo.
+func readHead(filePath string, maxBytes int64) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxBytes))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// extractSignatures retorna las líneas de declaración del código, sin los cuerpos.
+func extractSignatures(content string) string {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		if signaturePattern.MatchString(line) {
+			lines = append(lines, strings.TrimRight(line, " \t\r{"))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// truncateLines corta el texto en el último salto de línea antes de maxChars.
+func truncateLines(text string, maxChars int) string {
+	if maxChars <= 0 {
+		return ""
+	}
+	if len(text) <= maxChars {
+		return text
+	}
+	text = text[:maxChars]
+	if i := strings.LastIndexByte(text, '\n'); i > 0 {
+		text = text[:i]
+	}
+	return text
+}
+
+// FormatSamples formatea las muestras para incluirlas en un prompt.
+func FormatSamples(samples []Sample) string {
+	var sb strings.Builder
+	for _, sample := range samples {
+		note := string(sample.Kind)
+		if sample.Signatures {
+			note += ", signatures only"
+		}
+		if sample.Truncated {
+			note += ", truncated"
+		}
+		sb.WriteString(fmt.Sprintf("\n--- %s (%s) ---\n%s\n", sample.Path, note, sample.Content))
+	}
+	return sb.String()
+}
@@ -0,0 +1,119 @@
+package scanner
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestClassify verifica la clasificación de archivos representativos.
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		path string
+		kind SampleKind
+		ok   bool
+	}{
+		{"cmd/server/main.go", SampleEntrypoint, true},
+		{"src/index.ts", SampleEntrypoint, true},
+		{"src/main/java/com/acme/OrdersApplication.java", SampleEntrypoint, true},
+		{"internal/handlers/orders.go", SampleRouter, true},
+		{"app/urls.py", SampleRouter, true},
+		{"src/users.controller.ts", SampleRouter, true},
+		{"internal/domain/order.go", SampleModel, true},
+		{"app/models.py", SampleModel, true},
+		{"internal/domain/order_test.go", SampleTest, true},
+		{"tests/test_api.py", SampleTest, true},
+		{"src/app.spec.ts", SampleTest, true},
+		{"tsconfig.json", SampleConfig, true},
+		{"Makefile", SampleConfig, true},
+		{"internal/util/strings.go", "", false},
+		{"README.md", "", false},
+	}
+
+	for _, tt := range tests {
+		kind, ok := classify(tt.path)
+		assert.Equal(t, tt.ok, ok, tt.path)
+		assert.Equal(t, tt.kind, kind, tt.path)
+	}
+}
+
+// TestScanner_Samples verifica que se toma una muestra de cada tipo y se respeta el .gitignore.
+func TestScanner_Samples(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".gitignore":                    "generated/\n",
+		"cmd/server/main.go":            "package main\n\nfunc main() {}\n",
+		"internal/handlers/orders.go":   "package handlers\n\nfunc ListOrders() {}\n",
+		"internal/domain/order.go":      "package domain\n\ntype Order struct{}\n",
+		"internal/domain/order_test.go": "package domain\n\nfunc TestOrder(t *testing.T) {}\n",
+		".golangci.yml":                 "linters:\n  enable: [errcheck]\n",
+		"generated/models/model.go":     "package models\n",
+		"internal/domain/testdata/x.go": "package testdata\n",
+		"internal/util/strings.go":      "package util\n",
+	})
+
+	samples := NewScanner(dir, Options{}).Samples()
+
+	paths := make(map[SampleKind]string)
+	for _, sample := range samples {
+		paths[sample.Kind] = sample.Path
+	}
+	assert.Equal(t, map[SampleKind]string{
+		SampleEntrypoint: "cmd/server/main.go",
+		SampleRouter:     "internal/handlers/orders.go",
+		SampleModel:      "internal/domain/order.go",
+		SampleTest:       "internal/domain/order_test.go",
+		SampleConfig:     ".golangci.yml",
+	}, paths)
+
+	require.NotEmpty(t, samples)
+	assert.Equal(t, SampleEntrypoint, samples[0].Kind)
+	assert.Equal(t, "package main\n\nfunc main() {}", samples[0].Content)
+	assert.False(t, samples[0].Signatures)
+}
+
+// TestScanner_Samples_Budget verifica que los archivos grandes se reducen a sus
+// declaraciones y que el total no supera el presupuesto.
+func TestScanner_Samples_Budget(t *testing.T) {
+	var body strings.Builder
+	body.WriteString("package handlers\n\n")
+	for i := 0; i < 200; i++ {
+		body.WriteString(fmt.Sprintf("func Handler%03d(w http.ResponseWriter, r *http.Request) {\n\tw.WriteHeader(200)\n\tlog.Println(\"handled\")\n}\n\n", i))
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{"main.go": "package main\n\nfunc main() {}\n"}
+	for i := 0; i < 5; i++ {
+		files[fmt.Sprintf("handlers/h%d.go", i)] = body.String()
+	}
+	writeFiles(t, dir, files)
+
+	samples := NewScanner(dir, Options{SampleBudget: 1500}).Samples()
+
+	assert.LessOrEqual(t, EstimateTokens(FormatSamples(samples)), 1500)
+	var routers []Sample
+	for _, sample := range samples {
+		if sample.Kind == SampleRouter {
+			routers = append(routers, sample)
+		}
+	}
+	require.NotEmpty(t, routers)
+	assert.LessOrEqual(t, len(routers), maxSamplesPerKind)
+	assert.True(t, routers[0].Signatures)
+	assert.True(t, strings.HasPrefix(routers[0].Content, "package handlers\nfunc Handler000(w http.ResponseWriter, r *http.Request)"))
+	assert.NotContains(t, routers[0].Content, "WriteHeader")
+}
+
+// TestFormatSamples verifica el formato de las muestras para los prompts.
+func TestFormatSamples(t *testing.T) {
+	formatted := FormatSamples([]Sample{
+		{Path: "main.go", Kind: SampleEntrypoint, Content: "package main"},
+		{Path: "api/routes.go", Kind: SampleRouter, Content: "func Routes()", Signatures: true, Truncated: true},
+	})
+
+	assert.Contains(t, formatted, "--- main.go (entrypoint) ---\npackage main\n")
+	assert.Contains(t, formatted, "--- api/routes.go (router, signatures only, truncated) ---\nfunc Routes()\n")
+}
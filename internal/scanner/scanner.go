@@ -0,0 +1,318 @@
+// Package scanner recorre el árbol de un proyecto respetando .gitignore,
+// .git/info/exclude, .claude-init-ignore y los globs de --include/--exclude, y
+// representa su estructura dentro de un presupuesto de tokens.
+package scanner
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// IgnoreFile es el archivo de ignorados propio de claude-init, con sintaxis de .gitignore.
+const IgnoreFile = ".claude-init-ignore"
+
+// DefaultTokenBudget es el presupuesto de tokens por defecto para la estructura de directorios.
+const DefaultTokenBudget = 2000
+
+// maxEntries protege frente a repositorios enormes: no se recorren más entradas.
+const maxEntries = 50000
+
+// DefaultIgnores son los directorios que se ignoran siempre (dependencias, builds,
+// metadatos de herramientas), aunque el proyecto no los tenga en .gitignore.
+var DefaultIgnores = []string{
+	".git/",
+	"node_modules/",
+	"vendor/",
+	"dist/",
+	"build/",
+	"target/",
+	"bin/",
+	"obj/",
+	".venv/",
+	"venv/",
+	"__pycache__/",
+	".claude/",
+	".claude-init-staging-*/",
+	".idea/",
+	".vscode/",
+	".next/",
+	"coverage/",
+}
+
+// Options configura qué archivos se recorren y cuánto ocupa la estructura generada.
+type Options struct {
+	Include      []string // Globs a incluir (si hay alguno, solo se listan los archivos que coinciden)
+	Exclude      []string // Globs a excluir, además de los archivos de ignorados
+	TokenBudget  int      // Presupuesto de tokens de Tree (0 usa DefaultTokenBudget)
+	SampleBudget int      // Presupuesto de tokens de Samples (0 usa DefaultSampleBudget)
+	DocsBudget   int      // Presupuesto de tokens de Documents (0 usa DefaultDocsBudget)
+}
+
+// Scanner recorre un proyecto aplicando las reglas de ignorados.
+type Scanner struct {
+	root    string
+	opts    Options
+	ignore  *Matcher
+	include *Matcher
+	exclude *Matcher
+}
+
+// NewScanner crea un Scanner para el proyecto, cargando .gitignore, .git/info/exclude y .claude-init-ignore.
+// Los .gitignore de subdirectorios se cargan durante el recorrido.
+func NewScanner(root string, opts Options) *Scanner {
+	s := &Scanner{
+		root:    root,
+		opts:    opts,
+		ignore:  NewMatcher(),
+		include: NewMatcher(),
+		exclude: NewMatcher(),
+	}
+
+	s.ignore.AddPatterns("", DefaultIgnores...)
+	for _, file := range []string{filepath.Join(".git", "info", "exclude"), ".gitignore", IgnoreFile} {
+		// Un archivo ilegible no debe impedir el análisis: se omite
+		_ = s.ignore.AddFile("", filepath.Join(root, file))
+	}
+	s.include.AddPatterns("", opts.Include...)
+	s.exclude.AddPatterns("", opts.Exclude...)
+
+	return s
+}
+
+// Ignored indica si una ruta relativa (con "/") queda fuera del escaneo.
+// No tiene en cuenta los directorios padre: Walk no desciende a directorios ignorados.
+func (s *Scanner) Ignored(rel string, isDir bool) bool {
+	if s.ignore.Match(rel, isDir) || s.exclude.Match(rel, isDir) {
+		return true
+	}
+	if !isDir && !s.include.Empty() && !s.include.MatchPathOrParent(rel, false) {
+		return true
+	}
+	return false
+}
+
+// Walk recorre el proyecto llamando a fn con cada archivo y directorio no ignorado,
+// en orden alfabético. rel es relativo a la raíz y usa "/". Si fn retorna
+// filepath.SkipDir en un directorio, no se desciende a él.
+func (s *Scanner) Walk(fn func(rel string, entry fs.DirEntry) error) error {
+	visited := 0
+
+	err := filepath.WalkDir(s.root, func(fullPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// Directorios ilegibles se omiten sin abortar el recorrido
+			if entry != nil && entry.IsDir() && fullPath != s.root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, relErr := filepath.Rel(s.root, fullPath)
+		if relErr != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+
+		if rel == "." {
+			return nil
+		}
+
+		if s.Ignored(rel, entry.IsDir()) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		visited++
+		if visited > maxEntries {
+			return filepath.SkipAll
+		}
+
+		if entry.IsDir() {
+			_ = s.ignore.AddFile(rel, filepath.Join(fullPath, ".gitignore"))
+		}
+
+		return fn(rel, entry)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan %s: %w", s.root, err)
+	}
+	return nil
+}
+
+// treeNode es un directorio o archivo del árbol escaneado.
+type treeNode struct {
+	name     string
+	dir      bool
+	children []*treeNode
+	files    int // Archivos que cuelgan del nodo (recursivo)
+}
+
+// Tree retorna la estructura de directorios del proyecto ajustada al presupuesto de tokens.
+//
+// Se empieza mostrando todo y, si no cabe, se reducen primero los archivos por
+// directorio y después la profundidad. Las partes recortadas se resumen con un conteo.
+func (s *Scanner) Tree() string {
+	root := s.buildTree()
+
+	budget := s.opts.TokenBudget
+	if budget <= 0 {
+		budget = DefaultTokenBudget
+	}
+
+	maxDepth := root.depth()
+	if maxDepth == 0 {
+		return ""
+	}
+
+	minDepth := 3
+	if maxDepth < minDepth {
+		minDepth = maxDepth
+	}
+
+	fileCaps := []int{-1, 50, 20, 10, 5, 2, 0}
+	for _, filesCap := range fileCaps {
+		for depth := maxDepth; depth >= minDepth; depth-- {
+			if tree := root.render(depth, filesCap); EstimateTokens(tree) <= budget {
+				return tree
+			}
+		}
+	}
+	for depth := minDepth - 1; depth >= 1; depth-- {
+		if tree := root.render(depth, 0); EstimateTokens(tree) <= budget {
+			return tree
+		}
+	}
+
+	// Ni siquiera el primer nivel cabe: se corta el texto
+	tree := root.render(1, 0)
+	return tree[:budget*4] + "\n... (truncated)\n"
+}
+
+// EstimateTokens estima los tokens de un texto (aproximadamente 4 caracteres por token).
+func EstimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// buildTree construye el árbol en memoria con las entradas no ignoradas.
+func (s *Scanner) buildTree() *treeNode {
+	root := &treeNode{dir: true}
+	nodes := map[string]*treeNode{"": root}
+
+	_ = s.Walk(func(rel string, entry fs.DirEntry) error {
+		parent := nodes[parentDir(rel)]
+		if parent == nil {
+			return nil
+		}
+
+		node := &treeNode{name: entry.Name(), dir: entry.IsDir()}
+		parent.children = append(parent.children, node)
+		if node.dir {
+			nodes[rel] = node
+		}
+		return nil
+	})
+
+	root.prune(!s.include.Empty())
+	root.countFiles()
+	return root
+}
+
+// parentDir retorna el directorio padre de una ruta relativa ("" para la raíz).
+func parentDir(rel string) string {
+	dir := path.Dir(rel)
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
+
+// prune elimina los directorios vacíos cuando hay globs de inclusión, para que
+// solo aparezcan los directorios que contienen archivos incluidos.
+func (n *treeNode) prune(enabled bool) {
+	if !enabled {
+		return
+	}
+
+	kept := n.children[:0]
+	for _, child := range n.children {
+		if child.dir {
+			child.prune(enabled)
+			if len(child.children) == 0 {
+				continue
+			}
+		}
+		kept = append(kept, child)
+	}
+	n.children = kept
+}
+
+// countFiles calcula los archivos que cuelgan de cada nodo.
+func (n *treeNode) countFiles() int {
+	n.files = 0
+	for _, child := range n.children {
+		if child.dir {
+			n.files += child.countFiles()
+		} else {
+			n.files++
+		}
+	}
+	return n.files
+}
+
+// depth retorna la profundidad máxima de directorios del árbol.
+func (n *treeNode) depth() int {
+	maxDepth := 0
+	for _, child := range n.children {
+		d := 1
+		if child.dir {
+			d += child.depth()
+		}
+		if d > maxDepth {
+			maxDepth = d
+		}
+	}
+	return maxDepth
+}
+
+// render escribe el árbol hasta maxDepth niveles y con filesCap archivos por directorio (-1 sin límite).
+func (n *treeNode) render(maxDepth, filesCap int) string {
+	var sb strings.Builder
+	n.renderChildren(&sb, "", 1, maxDepth, filesCap)
+	return sb.String()
+}
+
+// renderChildren escribe los hijos del nodo: primero directorios y después archivos.
+func (n *treeNode) renderChildren(sb *strings.Builder, prefix string, depth, maxDepth, filesCap int) {
+	var dirs, files []*treeNode
+	for _, child := range n.children {
+		if child.dir {
+			dirs = append(dirs, child)
+		} else {
+			files = append(files, child)
+		}
+	}
+	sort.Slice(dirs, func(i, j int) bool { return dirs[i].name < dirs[j].name })
+	sort.Slice(files, func(i, j int) bool { return files[i].name < files[j].name })
+
+	for _, dir := range dirs {
+		if depth >= maxDepth && len(dir.children) > 0 {
+			sb.WriteString(fmt.Sprintf("%s%s/ (%d files)\n", prefix, dir.name, dir.files))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s%s/\n", prefix, dir.name))
+		dir.renderChildren(sb, prefix+"  ", depth+1, maxDepth, filesCap)
+	}
+
+	for i, file := range files {
+		if filesCap >= 0 && i >= filesCap {
+			sb.WriteString(fmt.Sprintf("%s... (%d more files)\n", prefix, len(files)-filesCap))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("%s%s\n", prefix, file.name))
+	}
+}
@@ -0,0 +1,129 @@
+package scanner
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFiles crea los archivos indicados (ruta relativa -> contenido) en dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		path := filepath.Join(dir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+// walkFiles retorna los archivos que recorre el Scanner.
+func walkFiles(t *testing.T, s *Scanner) []string {
+	t.Helper()
+	var files []string
+	require.NoError(t, s.Walk(func(rel string, entry fs.DirEntry) error {
+		if !entry.IsDir() {
+			files = append(files, rel)
+		}
+		return nil
+	}))
+	return files
+}
+
+// TestScanner_Walk_IgnoreFiles verifica que se respetan los archivos de ignorados y los directorios por defecto.
+func TestScanner_Walk_IgnoreFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".git/info/exclude":       "secret.txt\n",
+		".gitignore":              "*.log\ncache/\n",
+		IgnoreFile:                "fixtures/\n",
+		"main.go":                 "package main",
+		"app.log":                 "",
+		"secret.txt":              "",
+		"cache/data.bin":          "",
+		"fixtures/big.json":       "",
+		"node_modules/x/index.js": "",
+		"web/.gitignore":          "*.css\n",
+		"web/app.css":             "",
+		"web/app.ts":              "",
+		"api/app.css":             "",
+	})
+
+	files := walkFiles(t, NewScanner(dir, Options{}))
+
+	assert.ElementsMatch(t, []string{".gitignore", IgnoreFile, "main.go", "web/.gitignore", "web/app.ts", "api/app.css"}, files)
+}
+
+// TestScanner_Walk_IncludeExclude verifica los globs de --include y --exclude.
+func TestScanner_Walk_IncludeExclude(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"src/app/main.go":     "",
+		"src/app/main.gen.go": "",
+		"src/app/README.md":   "",
+		"scripts/deploy.sh":   "",
+		"go.mod":              "",
+	})
+
+	s := NewScanner(dir, Options{
+		Include: []string{"src", "go.mod"},
+		Exclude: []string{"*.gen.go"},
+	})
+
+	assert.ElementsMatch(t, []string{"src/app/main.go", "src/app/README.md", "go.mod"}, walkFiles(t, s))
+	assert.True(t, s.Ignored("scripts/deploy.sh", false))
+	assert.False(t, s.Ignored("scripts", true))
+}
+
+// TestScanner_Tree verifica la estructura completa cuando cabe en el presupuesto.
+func TestScanner_Tree(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod":                     "",
+		"cmd/app/main.go":            "",
+		"internal/core/core.go":      "",
+		"vendor/lib/lib.go":          "",
+		"internal/core/core_test.go": "",
+	})
+
+	tree := NewScanner(dir, Options{}).Tree()
+
+	assert.Equal(t, `cmd/
+  app/
+    main.go
+internal/
+  core/
+    core.go
+    core_test.go
+go.mod
+`, tree)
+}
+
+// TestScanner_Tree_TokenBudget verifica que el árbol se reduce para ajustarse al presupuesto.
+func TestScanner_Tree_TokenBudget(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{"go.mod": ""}
+	for i := 0; i < 40; i++ {
+		files[fmt.Sprintf("internal/handlers/handler_%02d.go", i)] = ""
+		files[fmt.Sprintf("internal/models/deep/nested/model_%02d.go", i)] = ""
+	}
+	writeFiles(t, dir, files)
+
+	full := NewScanner(dir, Options{TokenBudget: 100000}).Tree()
+	assert.Contains(t, full, "handler_39.go")
+
+	small := NewScanner(dir, Options{TokenBudget: 60}).Tree()
+	assert.LessOrEqual(t, EstimateTokens(small), 60)
+	assert.Contains(t, small, "internal/")
+	assert.Regexp(t, `\(\d+ (more )?files\)`, small)
+	assert.NotContains(t, small, "handler_39.go")
+}
+
+// TestScanner_Tree_Empty verifica un proyecto sin archivos.
+func TestScanner_Tree_Empty(t *testing.T) {
+	assert.Empty(t, NewScanner(t.TempDir(), Options{}).Tree())
+}
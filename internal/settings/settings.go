@@ -0,0 +1,400 @@
+// Package settings genera el .claude/settings.json de Claude Code: las reglas de permisos
+// (comandos de Bash permitidos y denegados, archivos que no se leen), las variables de
+// entorno, derivadas de las herramientas detectadas en el proyecto, y los hooks elegidos. El
+// resultado se fusiona con el settings.json existente en lugar de reemplazarlo.
+package settings
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/drossan/claude-init/internal/detector"
+	"github.com/drossan/claude-init/internal/hooks"
+	"github.com/drossan/claude-init/internal/jsonobject"
+)
+
+// File es el nombre del archivo de configuración de Claude Code dentro de .claude/.
+const File = "settings.json"
+
+// schemaURL es el JSON Schema de settings.json, que se añade a los archivos nuevos.
+const schemaURL = "https://json.schemastore.org/claude-code-settings.json"
+
+// Rules son las reglas que claude-init propone para un proyecto.
+type Rules struct {
+	Allow []string
+	Deny  []string
+	Env   map[string]string
+	Hooks []hooks.Hook // Hooks elegidos por el usuario; Derive no propone ninguno
+}
+
+// baseAllow son los comandos de solo lectura de git, seguros en cualquier proyecto.
+var baseAllow = []string{
+	"Bash(git status)",
+	"Bash(git diff:*)",
+	"Bash(git log:*)",
+	"Bash(git show:*)",
+}
+
+// baseDeny son los comandos destructivos y los archivos de secretos que se deniegan siempre.
+var baseDeny = []string{
+	"Bash(rm -rf:*)",
+	"Bash(sudo:*)",
+	"Bash(git push --force:*)",
+	"Bash(git push -f:*)",
+	"Bash(git reset --hard:*)",
+	"Bash(git clean:*)",
+	"Bash(curl:*)",
+	"Bash(wget:*)",
+	"Read(./.env)",
+	"Read(./.env.*)",
+	"Read(./secrets/**)",
+}
+
+// ecosystemDeny son los comandos de publicación de cada ecosistema de dependencias.
+var ecosystemDeny = map[string][]string{
+	detector.EcosystemNPM:      {"Bash(npm publish:*)", "Bash(yarn publish:*)", "Bash(pnpm publish:*)"},
+	detector.EcosystemPyPI:     {"Bash(twine upload:*)", "Bash(poetry publish:*)"},
+	detector.EcosystemCargo:    {"Bash(cargo publish:*)"},
+	detector.EcosystemRubyGems: {"Bash(gem push:*)"},
+	detector.EcosystemHex:      {"Bash(mix hex.publish:*)"},
+	detector.EcosystemNuGet:    {"Bash(dotnet nuget push:*)"},
+	detector.EcosystemMaven:    {"Bash(mvn deploy:*)"},
+}
+
+// infrastructureDeny son los comandos que cambian infraestructura real, por el prefijo con
+// el que el detector describe cada herramienta.
+var infrastructureDeny = []struct {
+	prefix string
+	rules  []string
+}{
+	{"Terraform", []string{"Bash(terraform apply:*)", "Bash(terraform destroy:*)"}},
+	{"Pulumi", []string{"Bash(pulumi up:*)", "Bash(pulumi destroy:*)"}},
+	{"Kubernetes", []string{"Bash(kubectl apply:*)", "Bash(kubectl delete:*)"}},
+	{"Kustomize", []string{"Bash(kubectl apply:*)", "Bash(kubectl delete:*)"}},
+	{"Helm", []string{"Bash(helm install:*)", "Bash(helm upgrade:*)", "Bash(helm uninstall:*)"}},
+}
+
+// telemetryEnv desactiva la telemetría de las herramientas de cada framework o lenguaje,
+// para que los comandos que ejecuta Claude Code no envíen datos ni pregunten.
+var telemetryEnv = map[string]map[string]string{
+	"Next.js":      {"NEXT_TELEMETRY_DISABLED": "1"},
+	"Nuxt":         {"NUXT_TELEMETRY_DISABLED": "1"},
+	"Angular":      {"NG_CLI_ANALYTICS": "false"},
+	"ASP.NET Core": {"DOTNET_CLI_TELEMETRY_OPTOUT": "1"},
+	"C#":           {"DOTNET_CLI_TELEMETRY_OPTOUT": "1"},
+}
+
+// allowedKinds son los tipos de comando detectados que se permiten: no cambian nada fuera
+// del árbol de trabajo. Los de tipo run arrancan la aplicación y se dejan preguntar.
+var allowedKinds = map[string]bool{
+	detector.CommandBuild:  true,
+	detector.CommandTest:   true,
+	detector.CommandLint:   true,
+	detector.CommandFormat: true,
+}
+
+// unsafeWords descartan un comando detectado aunque sea de build o test: publican,
+// despliegan o borran.
+var unsafeWords = []string{"deploy", "publish", "release", "push", "upload", "destroy", "rm", "sudo"}
+
+// Derive propone las reglas para un proyecto a partir de su detección. result puede ser nil:
+// entonces solo se proponen las reglas base.
+func Derive(result *detector.Result) *Rules {
+	rules := &Rules{Env: map[string]string{}}
+	rules.Allow = appendUnique(rules.Allow, baseAllow...)
+	rules.Deny = appendUnique(rules.Deny, baseDeny...)
+	if result == nil {
+		return rules
+	}
+
+	if result.Tooling != nil {
+		for _, command := range result.Tooling.Commands {
+			if allowedKinds[command.Kind] && safeCommand(command.Command) {
+				rules.Allow = appendUnique(rules.Allow, "Bash("+command.Command+")")
+			}
+		}
+		for _, infra := range result.Tooling.Infrastructure {
+			for _, deny := range infrastructureDeny {
+				if strings.HasPrefix(infra, deny.prefix) {
+					rules.Deny = appendUnique(rules.Deny, deny.rules...)
+				}
+			}
+		}
+		if len(result.Tooling.Containers) > 0 {
+			rules.Deny = appendUnique(rules.Deny, "Bash(docker system prune:*)", "Bash(docker push:*)")
+		}
+	}
+
+	for ecosystem := range result.Dependencies {
+		rules.Deny = appendUnique(rules.Deny, ecosystemDeny[ecosystem]...)
+	}
+
+	for _, name := range append([]string{result.Language}, result.Frameworks...) {
+		for key, value := range telemetryEnv[name] {
+			rules.Env[key] = value
+		}
+	}
+	return rules
+}
+
+// safeCommand indica si un comando detectado se puede permitir tal cual: un único comando,
+// sin operadores de shell ni palabras que indiquen publicación o borrado. Las opciones
+// (--release) no cuentan como palabras.
+func safeCommand(command string) bool {
+	if strings.ContainsAny(command, ";|&<>`$") {
+		return false
+	}
+	for _, field := range strings.Fields(strings.ToLower(command)) {
+		if strings.HasPrefix(field, "-") {
+			continue
+		}
+		for _, word := range strings.FieldsFunc(field, func(r rune) bool { return r == ':' || r == '/' || r == '.' || r == '-' }) {
+			for _, unsafe := range unsafeWords {
+				if word == unsafe {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Merge fusiona las reglas con el contenido de un settings.json existente (vacío si no
+// existe) y retorna el nuevo contenido. Nada de lo que ya contiene se elimina ni se cambia:
+// se añaden las reglas, variables y hooks que faltan. Una regla que el usuario ya tiene en la
+// lista contraria no se añade, para respetar su decisión. Las claves conservan su orden y, si
+// no falta nada, se retorna existing sin cambios.
+func Merge(existing []byte, rules *Rules) ([]byte, error) {
+	created := len(bytes.TrimSpace(existing)) == 0
+	doc := jsonobject.New()
+	if created {
+		if err := doc.Set("$schema", schemaURL); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if doc, err = jsonobject.Parse(existing); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", File, err)
+		}
+	}
+
+	changed, err := mergePermissions(doc, rules)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules.Env) > 0 {
+		envChanged, err := mergeEnv(doc, rules.Env)
+		if err != nil {
+			return nil, err
+		}
+		changed = changed || envChanged
+	}
+	if len(rules.Hooks) > 0 {
+		hooksChanged, err := mergeHooks(doc, rules.Hooks)
+		if err != nil {
+			return nil, err
+		}
+		changed = changed || hooksChanged
+	}
+	if !created && !changed {
+		return existing, nil
+	}
+
+	content, err := doc.Format()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", File, err)
+	}
+	return content, nil
+}
+
+// mergePermissions añade a permissions las reglas allow y deny que faltan. Retorna si ha
+// añadido alguna.
+func mergePermissions(doc *jsonobject.Object, rules *Rules) (bool, error) {
+	permissions := jsonobject.New()
+	if raw, ok := doc.Get("permissions"); ok {
+		var err error
+		if permissions, err = jsonobject.Parse(raw); err != nil {
+			return false, fmt.Errorf("invalid %s: permissions must be an object", File)
+		}
+	}
+	allow, err := stringList(permissions, "allow")
+	if err != nil {
+		return false, err
+	}
+	deny, err := stringList(permissions, "deny")
+	if err != nil {
+		return false, err
+	}
+
+	userAllow, userDeny := toSet(allow), toSet(deny)
+	newAllow, newDeny := allow, deny
+	for _, rule := range rules.Allow {
+		if !userDeny[rule] {
+			newAllow = appendUnique(newAllow, rule)
+		}
+	}
+	for _, rule := range rules.Deny {
+		if !userAllow[rule] {
+			newDeny = appendUnique(newDeny, rule)
+		}
+	}
+	if len(newAllow) == len(allow) && len(newDeny) == len(deny) {
+		return false, nil
+	}
+
+	if err := permissions.Set("allow", newAllow); err != nil {
+		return false, err
+	}
+	if err := permissions.Set("deny", newDeny); err != nil {
+		return false, err
+	}
+	return true, doc.Set("permissions", permissions)
+}
+
+// mergeEnv añade a env las variables que faltan, en orden alfabético. Retorna si ha añadido
+// alguna.
+func mergeEnv(doc *jsonobject.Object, vars map[string]string) (bool, error) {
+	env := jsonobject.New()
+	if raw, ok := doc.Get("env"); ok {
+		var err error
+		if env, err = jsonobject.Parse(raw); err != nil {
+			return false, fmt.Errorf("invalid %s: env must be an object", File)
+		}
+	}
+
+	keys := make([]string, 0, len(vars))
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	changed := false
+	for _, key := range keys {
+		if _, ok := env.Get(key); ok {
+			continue
+		}
+		if err := env.Set(key, vars[key]); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	if !changed {
+		return false, nil
+	}
+	return true, doc.Set("env", env)
+}
+
+// mergeHooks añade los hooks a la sección hooks del documento, cada uno en su propio grupo
+// del evento. Un hook cuyo comando ya está en el evento no se vuelve a añadir. Retorna si ha
+// añadido alguno.
+func mergeHooks(doc *jsonobject.Object, list []hooks.Hook) (bool, error) {
+	events := jsonobject.New()
+	if raw, ok := doc.Get("hooks"); ok {
+		var err error
+		if events, err = jsonobject.Parse(raw); err != nil {
+			return false, fmt.Errorf("invalid %s: hooks must be an object", File)
+		}
+	}
+
+	changed := false
+	for _, hook := range list {
+		groups := []json.RawMessage{}
+		if raw, ok := events.Get(hook.Event); ok {
+			if err := json.Unmarshal(raw, &groups); err != nil {
+				return false, fmt.Errorf("invalid %s: hooks.%s must be a list", File, hook.Event)
+			}
+		}
+		if hasHookCommand(groups, hook.Command) {
+			continue
+		}
+
+		group := map[string]any{
+			"hooks": []any{map[string]any{"type": "command", "command": hook.Command}},
+		}
+		if hook.Matcher != "" {
+			group["matcher"] = hook.Matcher
+		}
+		raw, err := jsonobject.Marshal(group)
+		if err != nil {
+			return false, fmt.Errorf("failed to encode %s: %w", File, err)
+		}
+		if err := events.Set(hook.Event, append(groups, raw)); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	if !changed {
+		return false, nil
+	}
+	return true, doc.Set("hooks", events)
+}
+
+// hasHookCommand indica si alguno de los grupos de un evento ya ejecuta command.
+func hasHookCommand(groups []json.RawMessage, command string) bool {
+	for _, raw := range groups {
+		var group struct {
+			Hooks []struct {
+				Command string `json:"command"`
+			} `json:"hooks"`
+		}
+		if json.Unmarshal(raw, &group) != nil {
+			continue
+		}
+		for _, entry := range group.Hooks {
+			if entry.Command == command {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stringList retorna la lista de reglas key de permissions.
+func stringList(permissions *jsonobject.Object, key string) ([]string, error) {
+	raw, ok := permissions.Get(key)
+	if !ok {
+		return []string{}, nil
+	}
+	var items []any
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, fmt.Errorf("invalid %s: permissions.%s must be a list", File, key)
+	}
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		rule, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s: permissions.%s must contain strings", File, key)
+		}
+		list = append(list, rule)
+	}
+	return list, nil
+}
+
+// toSet convierte una lista en un conjunto.
+func toSet(list []string) map[string]bool {
+	set := make(map[string]bool, len(list))
+	for _, item := range list {
+		set[item] = true
+	}
+	return set
+}
+
+// appendUnique añade a list los elementos que aún no contiene.
+func appendUnique(list []string, items ...string) []string {
+	for _, item := range items {
+		found := false
+		for _, existing := range list {
+			if existing == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,163 @@
+package settings
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/drossan/claude-init/internal/detector"
+	"github.com/drossan/claude-init/internal/hooks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDerive verifica que se permiten los comandos seguros detectados y se deniegan los
+// destructivos de las herramientas del proyecto.
+func TestDerive(t *testing.T) {
+	result := &detector.Result{
+		Language:     "TypeScript",
+		Frameworks:   []string{"Next.js"},
+		Dependencies: map[string][]string{detector.EcosystemNPM: {"next"}},
+		Tooling: &detector.Tooling{
+			Commands: []detector.Command{
+				{Kind: detector.CommandTest, Command: "go test ./...", Source: "go.mod"},
+				{Kind: detector.CommandLint, Command: "npm run lint", Source: "package.json"},
+				{Kind: detector.CommandBuild, Command: "make build", Source: "Makefile"},
+				{Kind: detector.CommandBuild, Command: "cargo build --release", Source: "Cargo.toml"},
+				{Kind: detector.CommandBuild, Command: "make release", Source: "Makefile"},
+				{Kind: detector.CommandTest, Command: "npm test && rm -rf out", Source: "ci.yml"},
+				{Kind: detector.CommandRun, Command: "npm run dev", Source: "package.json"},
+			},
+			Infrastructure: []string{"Terraform: infra/"},
+		},
+	}
+
+	rules := Derive(result)
+	assert.Subset(t, rules.Allow, []string{"Bash(git status)", "Bash(go test ./...)", "Bash(npm run lint)", "Bash(make build)", "Bash(cargo build --release)"})
+	assert.NotContains(t, rules.Allow, "Bash(make release)")
+	assert.NotContains(t, rules.Allow, "Bash(npm test && rm -rf out)")
+	assert.NotContains(t, rules.Allow, "Bash(npm run dev)")
+	assert.Subset(t, rules.Deny, []string{"Bash(rm -rf:*)", "Read(./.env)", "Bash(npm publish:*)", "Bash(terraform destroy:*)"})
+	assert.Equal(t, map[string]string{"NEXT_TELEMETRY_DISABLED": "1"}, rules.Env)
+
+	base := Derive(nil)
+	assert.Equal(t, baseAllow, base.Allow)
+	assert.Equal(t, baseDeny, base.Deny)
+}
+
+// TestMerge verifica que la fusión añade lo que falta sin quitar ni cambiar lo existente.
+func TestMerge(t *testing.T) {
+	rules := &Rules{
+		Allow: []string{"Bash(go test ./...)", "Bash(curl:*)"},
+		Deny:  []string{"Bash(rm -rf:*)", "Bash(git push:*)"},
+		Env:   map[string]string{"NEXT_TELEMETRY_DISABLED": "1", "EDITOR": "vi"},
+	}
+
+	// Archivo nuevo
+	content, err := Merge(nil, rules)
+	require.NoError(t, err)
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(content, &doc))
+	assert.Equal(t, schemaURL, doc["$schema"])
+	assert.Equal(t, []any{"Bash(go test ./...)", "Bash(curl:*)"}, doc["permissions"].(map[string]any)["allow"])
+
+	// Archivo existente con reglas y opciones del usuario
+	existing := []byte(`{
+  "model": "opus",
+  "cleanupPeriodDays": 30,
+  "permissions": {
+    "allow": ["Bash(git push:*)", "Bash(make build)"],
+    "deny": ["Bash(curl:*)"],
+    "defaultMode": "acceptEdits"
+  },
+  "env": {"EDITOR": "nano"}
+}`)
+	content, err = Merge(existing, rules)
+	require.NoError(t, err)
+	doc = nil
+	require.NoError(t, json.Unmarshal(content, &doc))
+	assert.Equal(t, "opus", doc["model"])
+	assert.Equal(t, float64(30), doc["cleanupPeriodDays"])
+	assert.NotContains(t, doc, "$schema")
+
+	permissions := doc["permissions"].(map[string]any)
+	assert.Equal(t, "acceptEdits", permissions["defaultMode"])
+	// Las reglas del usuario se conservan primero; las que contradicen su decisión no se añaden
+	assert.Equal(t, []any{"Bash(git push:*)", "Bash(make build)", "Bash(go test ./...)"}, permissions["allow"])
+	assert.Equal(t, []any{"Bash(curl:*)", "Bash(rm -rf:*)"}, permissions["deny"])
+	assert.Equal(t, map[string]any{"EDITOR": "nano", "NEXT_TELEMETRY_DISABLED": "1"}, doc["env"])
+
+	// Fusionar de nuevo no cambia nada
+	again, err := Merge(content, rules)
+	require.NoError(t, err)
+	assert.Equal(t, string(content), string(again))
+
+	_, err = Merge([]byte(`{"permissions": []}`), rules)
+	assert.ErrorContains(t, err, "permissions must be an object")
+	_, err = Merge([]byte(`{not json`), rules)
+	assert.Error(t, err)
+}
+
+// TestMerge_PreservesLayout verifica que un archivo al que no le falta nada se retorna tal
+// cual y que, al añadir reglas, las claves del usuario conservan su orden.
+func TestMerge_PreservesLayout(t *testing.T) {
+	rules := &Rules{Allow: []string{"Bash(make build && make test)"}, Env: map[string]string{"EDITOR": "vi"}}
+
+	existing := []byte(`{"zeta": 1, "permissions": {"deny": [], "allow": ["Bash(make build && make test)"]}, "env": {"EDITOR": "nano"}, "alpha": 1.50}`)
+	content, err := Merge(existing, rules)
+	require.NoError(t, err)
+	assert.Equal(t, string(existing), string(content))
+
+	existing = []byte(`{"zeta": 1, "permissions": {"defaultMode": "plan", "deny": []}, "alpha": 1.50}`)
+	content, err = Merge(existing, rules)
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "zeta": 1,
+  "permissions": {
+    "defaultMode": "plan",
+    "deny": [],
+    "allow": [
+      "Bash(make build && make test)"
+    ]
+  },
+  "alpha": 1.50,
+  "env": {
+    "EDITOR": "vi"
+  }
+}
+`, string(content))
+}
+
+// TestMerge_Hooks verifica que los hooks elegidos se añaden a su evento una sola vez, junto a
+// los hooks del usuario.
+func TestMerge_Hooks(t *testing.T) {
+	rules := &Rules{Hooks: []hooks.Hook{
+		{Name: hooks.HookFormat, Event: hooks.EventPostToolUse, Matcher: "Edit|MultiEdit|Write", Command: "fmt"},
+		{Name: hooks.HookTest, Event: hooks.EventStop, Command: "remind"},
+	}}
+	existing := []byte(`{"hooks": {"PostToolUse": [{"matcher": "Write", "hooks": [{"type": "command", "command": "notify"}]}]}}`)
+
+	content, err := Merge(existing, rules)
+	require.NoError(t, err)
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(content, &doc))
+	events := doc["hooks"].(map[string]any)
+	assert.Equal(t, []any{
+		map[string]any{"matcher": "Write", "hooks": []any{map[string]any{"type": "command", "command": "notify"}}},
+		map[string]any{"matcher": "Edit|MultiEdit|Write", "hooks": []any{map[string]any{"type": "command", "command": "fmt"}}},
+	}, events["PostToolUse"])
+	assert.Equal(t, []any{
+		map[string]any{"hooks": []any{map[string]any{"type": "command", "command": "remind"}}},
+	}, events["Stop"])
+
+	again, err := Merge(content, rules)
+	require.NoError(t, err)
+	assert.Equal(t, string(content), string(again))
+
+	// Sin hooks elegidos no se crea la sección
+	content, err = Merge(nil, &Rules{})
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), `"hooks"`)
+
+	_, err = Merge([]byte(`{"hooks": {"Stop": {}}}`), rules)
+	assert.ErrorContains(t, err, "hooks.Stop must be a list")
+}
@@ -0,0 +1,292 @@
+// Package snapshot protege los archivos que genera claude-init. Cada ejecución escribe en
+// un directorio de staging que, al terminar, se intercambia con el proyecto; el estado
+// anterior se guarda como una instantánea con fecha que undo puede restaurar.
+package snapshot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// StagingPrefix es el prefijo de los directorios de staging, creados dentro del proyecto
+// para que el intercambio sea un rename en el mismo sistema de archivos.
+const StagingPrefix = ".claude-init-staging-"
+
+// DefaultKeep es el número de instantáneas que se conservan por proyecto.
+const DefaultKeep = 20
+
+// CommandUndo es el comando que registran las instantáneas tomadas al restaurar otra.
+const CommandUndo = "undo"
+
+// metadataFile es el archivo con los datos de una instantánea, junto a sus archivos.
+const metadataFile = "snapshot.json"
+
+// idFormat es el formato de los IDs de instantánea; se ordenan cronológicamente.
+const idFormat = "20060102-150405.000"
+
+// Snapshot es el estado de los archivos gestionados por claude-init antes de una ejecución.
+type Snapshot struct {
+	ID         string    `json:"id"`
+	CreatedAt  time.Time `json:"created_at"`
+	Command    string    `json:"command"`               // Comando que reemplazó este estado
+	Paths      []string  `json:"paths"`                 // Rutas guardadas, relativas al proyecto
+	Missing    []string  `json:"missing,omitempty"`     // Rutas que no existían: restaurar las elimina
+	RestoredAt time.Time `json:"restored_at,omitempty"` // Última vez que undo la restauró
+}
+
+// Restored indica si la instantánea ya se ha restaurado alguna vez.
+func (s *Snapshot) Restored() bool {
+	return !s.RestoredAt.IsZero()
+}
+
+// Store guarda las instantáneas de un proyecto, cada una en un directorio con su ID.
+type Store struct {
+	projectPath string
+	dir         string
+}
+
+// NewStore crea el almacén de instantáneas de un proyecto en el directorio de caché del
+// usuario (~/.cache/claude-init/history/<proyecto> en Linux), fuera del repositorio.
+func NewStore(projectPath string) (*Store, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user cache directory: %w", err)
+	}
+	if abs, err := filepath.Abs(projectPath); err == nil {
+		projectPath = abs
+	}
+	sum := sha256.Sum256([]byte(projectPath))
+	return NewStoreWithDir(projectPath, filepath.Join(cacheDir, "claude-init", "history", hex.EncodeToString(sum[:8]))), nil
+}
+
+// NewStoreWithDir crea el almacén de instantáneas de un proyecto en el directorio indicado.
+func NewStoreWithDir(projectPath, dir string) *Store {
+	if abs, err := filepath.Abs(projectPath); err == nil {
+		projectPath = abs
+	}
+	return &Store{projectPath: projectPath, dir: dir}
+}
+
+// Dir retorna el directorio de las instantáneas del proyecto.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Take guarda una copia de las rutas indicadas del proyecto antes de que command las
+// reemplace. Las rutas que no existen se registran para eliminarlas al restaurar.
+func (s *Store) Take(command string, paths []string) (*Snapshot, error) {
+	now := time.Now().UTC()
+	snapshot := &Snapshot{ID: now.Format(idFormat), CreatedAt: now, Command: command}
+	for i := 2; ; i++ {
+		if _, err := os.Stat(s.path(snapshot.ID)); os.IsNotExist(err) {
+			break
+		}
+		snapshot.ID = fmt.Sprintf("%s-%d", now.Format(idFormat), i)
+	}
+
+	filesDir := filepath.Join(s.path(snapshot.ID), "files")
+	for _, rel := range paths {
+		src := filepath.Join(s.projectPath, filepath.FromSlash(rel))
+		if _, err := os.Lstat(src); os.IsNotExist(err) {
+			snapshot.Missing = append(snapshot.Missing, rel)
+			continue
+		}
+		if err := copyPath(src, filepath.Join(filesDir, filepath.FromSlash(rel))); err != nil {
+			_ = os.RemoveAll(s.path(snapshot.ID))
+			return nil, fmt.Errorf("failed to snapshot %s: %w", rel, err)
+		}
+		snapshot.Paths = append(snapshot.Paths, rel)
+	}
+
+	if err := s.write(snapshot); err != nil {
+		_ = os.RemoveAll(s.path(snapshot.ID))
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// List retorna las instantáneas del proyecto, de la más reciente a la más antigua.
+func (s *Store) List() ([]*Snapshot, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read snapshots: %w", err)
+	}
+
+	var snapshots []*Snapshot
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		snapshot, err := s.Get(entry.Name())
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].ID > snapshots[j].ID })
+	return snapshots, nil
+}
+
+// Get retorna la instantánea con el ID indicado.
+func (s *Store) Get(id string) (*Snapshot, error) {
+	content, err := os.ReadFile(filepath.Join(s.path(id), metadataFile))
+	if err != nil {
+		return nil, fmt.Errorf("snapshot %s not found", id)
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(content, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", id, err)
+	}
+	return &snapshot, nil
+}
+
+// Latest retorna la instantánea que undo restaura por defecto: la más reciente tomada por
+// una generación y aún no restaurada. Así, varios undo seguidos retroceden una generación
+// cada vez en lugar de alternar entre dos estados.
+func (s *Store) Latest() (*Snapshot, error) {
+	snapshots, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.Command != CommandUndo && !snapshot.Restored() {
+			return snapshot, nil
+		}
+	}
+	return nil, fmt.Errorf("no generation to undo")
+}
+
+// Restore devuelve el proyecto al estado de la instantánea indicada. El estado actual se
+// guarda antes en otra instantánea, de modo que la restauración también se puede deshacer.
+// Retorna esa nueva instantánea.
+func (s *Store) Restore(id string) (*Snapshot, error) {
+	snapshot, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := append(append([]string{}, snapshot.Paths...), snapshot.Missing...)
+	stage, err := NewEmptyStage(s.projectPath, paths)
+	if err != nil {
+		return nil, err
+	}
+	defer stage.Discard()
+
+	filesDir := filepath.Join(s.path(id), "files")
+	for _, rel := range snapshot.Paths {
+		if err := copyPath(filepath.Join(filesDir, filepath.FromSlash(rel)), filepath.Join(stage.Dir(), filepath.FromSlash(rel))); err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", rel, err)
+		}
+	}
+
+	backup, err := stage.Commit(s, CommandUndo)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot.RestoredAt = time.Now().UTC()
+	if err := s.write(snapshot); err != nil {
+		return backup, err
+	}
+	return backup, nil
+}
+
+// Prune elimina las instantáneas más antiguas, dejando las keep más recientes.
+func (s *Store) Prune(keep int) error {
+	snapshots, err := s.List()
+	if err != nil {
+		return err
+	}
+	for i := keep; i < len(snapshots); i++ {
+		if err := os.RemoveAll(s.path(snapshots[i].ID)); err != nil {
+			return fmt.Errorf("failed to remove snapshot %s: %w", snapshots[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// path retorna el directorio de una instantánea.
+func (s *Store) path(id string) string {
+	return filepath.Join(s.dir, id)
+}
+
+// write guarda los datos de una instantánea.
+func (s *Store) write(snapshot *Snapshot) error {
+	content, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := os.MkdirAll(s.path(snapshot.ID), 0755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(s.path(snapshot.ID), metadataFile), content, 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// copyPath copia un archivo o un directorio completo, conservando los permisos.
+func copyPath(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case entry.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case entry.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		}
+		return copyFile(path, target, info.Mode().Perm())
+	})
+}
+
+// copyFile copia un archivo regular.
+func copyFile(src, dst string, perm fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
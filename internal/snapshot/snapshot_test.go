@@ -0,0 +1,147 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFiles crea los archivos indicados (ruta relativa -> contenido) dentro de dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+// readFile retorna el contenido de un archivo del proyecto, o "" si no existe.
+func readFile(t *testing.T, dir, rel string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+	if os.IsNotExist(err) {
+		return ""
+	}
+	require.NoError(t, err)
+	return string(content)
+}
+
+// TestStage_CommitAndRestore verifica que el staging se intercambia con el proyecto, que el
+// estado anterior queda en una instantánea y que undo retrocede una generación cada vez.
+func TestStage_CommitAndRestore(t *testing.T) {
+	project := t.TempDir()
+	store := NewStoreWithDir(project, t.TempDir())
+	paths := []string{".claude", "CLAUDE.md", "api/CLAUDE.md"}
+	writeFiles(t, project, map[string]string{
+		".claude/agents/architect.md": "v1",
+		"CLAUDE.md":                   "root v1",
+		"main.go":                     "package main\n",
+	})
+
+	// Primera generación: modifica un agente, añade otro y crea el CLAUDE.md de un paquete
+	stage, err := NewStage(project, paths)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", readFile(t, stage.Dir(), ".claude/agents/architect.md"), "el staging parte del estado actual")
+	writeFiles(t, stage.Dir(), map[string]string{
+		".claude/agents/architect.md": "v2",
+		".claude/agents/tester.md":    "v2",
+		"api/CLAUDE.md":               "api v2",
+	})
+	first, err := stage.Commit(store, "generate")
+	require.NoError(t, err)
+	assert.Equal(t, []string{".claude", "CLAUDE.md"}, first.Paths)
+	assert.Equal(t, []string{"api/CLAUDE.md"}, first.Missing)
+	assert.NoDirExists(t, stage.Dir())
+	assert.Equal(t, "v2", readFile(t, project, ".claude/agents/architect.md"))
+	assert.Equal(t, "api v2", readFile(t, project, "api/CLAUDE.md"))
+
+	// Segunda generación, con --force: el .claude/ anterior se descarta entero
+	stage, err = NewStage(project, paths)
+	require.NoError(t, err)
+	require.NoError(t, os.RemoveAll(filepath.Join(stage.Dir(), ".claude")))
+	writeFiles(t, stage.Dir(), map[string]string{".claude/agents/architect.md": "v3"})
+	_, err = stage.Commit(store, "init")
+	require.NoError(t, err)
+	assert.Equal(t, "", readFile(t, project, ".claude/agents/tester.md"))
+
+	// Una generación descartada no toca el proyecto
+	stage, err = NewStage(project, paths)
+	require.NoError(t, err)
+	writeFiles(t, stage.Dir(), map[string]string{".claude/agents/architect.md": "broken"})
+	stage.Discard()
+	assert.Equal(t, "v3", readFile(t, project, ".claude/agents/architect.md"))
+
+	// undo restaura el estado anterior a init...
+	latest, err := store.Latest()
+	require.NoError(t, err)
+	assert.Equal(t, "init", latest.Command)
+	_, err = store.Restore(latest.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", readFile(t, project, ".claude/agents/architect.md"))
+	assert.Equal(t, "v2", readFile(t, project, ".claude/agents/tester.md"))
+
+	// ...y un segundo undo, el anterior a generate, eliminando lo que no existía
+	latest, err = store.Latest()
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, latest.ID)
+	_, err = store.Restore(latest.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", readFile(t, project, ".claude/agents/architect.md"))
+	assert.Equal(t, "", readFile(t, project, ".claude/agents/tester.md"))
+	assert.Equal(t, "", readFile(t, project, "api/CLAUDE.md"))
+	assert.Equal(t, "package main\n", readFile(t, project, "main.go"))
+
+	_, err = store.Latest()
+	assert.Error(t, err, "no quedan generaciones por deshacer")
+
+	snapshots, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, snapshots, 4)
+	assert.Equal(t, CommandUndo, snapshots[0].Command)
+	assert.True(t, snapshots[3].Restored())
+}
+
+// TestNewStage_SweepsStale verifica que al crear un staging se eliminan los abandonados por
+// ejecuciones anteriores y se conservan los recientes, que pueden ser de otra en curso.
+func TestNewStage_SweepsStale(t *testing.T) {
+	project := t.TempDir()
+	stale := filepath.Join(project, StagingPrefix+"crashed")
+	recent := filepath.Join(project, StagingPrefix+"running")
+	writeFiles(t, stale, map[string]string{".claude/agents/architect.md": "v2"})
+	writeFiles(t, recent, map[string]string{".claude/agents/architect.md": "v2"})
+	old := time.Now().Add(-2 * staleAge)
+	require.NoError(t, os.Chtimes(stale, old, old))
+
+	stage, err := NewStage(project, []string{".claude"})
+	require.NoError(t, err)
+	defer stage.Discard()
+	assert.NoDirExists(t, stale)
+	assert.DirExists(t, recent)
+	assert.DirExists(t, stage.Dir())
+}
+
+// TestStore_Prune verifica que solo se conservan las instantáneas más recientes.
+func TestStore_Prune(t *testing.T) {
+	project := t.TempDir()
+	store := NewStoreWithDir(project, t.TempDir())
+	writeFiles(t, project, map[string]string{"CLAUDE.md": "root"})
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		snapshot, err := store.Take("generate", []string{"CLAUDE.md"})
+		require.NoError(t, err)
+		ids = append(ids, snapshot.ID)
+	}
+
+	require.NoError(t, store.Prune(2))
+	snapshots, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, ids[2], snapshots[0].ID)
+	assert.Equal(t, ids[1], snapshots[1].ID)
+}
@@ -0,0 +1,188 @@
+package snapshot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// oldDir es el subdirectorio del staging donde se apartan las rutas del proyecto durante
+// el intercambio, para poder devolverlas si falla.
+const oldDir = ".old"
+
+// staleAge es la antigüedad a partir de la cual un staging del proyecto se considera
+// abandonado por una ejecución que no terminó (un cierre forzado o una caída).
+const staleAge = time.Hour
+
+// Stage es un directorio de staging con la misma estructura que el proyecto para las rutas
+// gestionadas (.claude/, CLAUDE.md...). Las generaciones escriben en él y Commit lo
+// intercambia con el proyecto: un fallo a mitad de ejecución no deja el proyecto a medias.
+type Stage struct {
+	projectPath string
+	dir         string
+	paths       []string
+	moved       map[string]bool // Rutas ya intercambiadas, y si existían en el proyecto
+	preview     bool            // Staging fuera del proyecto, solo para comparar
+}
+
+// NewStage crea un staging dentro del proyecto con una copia de las rutas indicadas que
+// existen, para que la generación parta del estado actual.
+func NewStage(projectPath string, paths []string) (*Stage, error) {
+	stage, err := NewEmptyStage(projectPath, paths)
+	if err != nil {
+		return nil, err
+	}
+	return stage, stage.fill()
+}
+
+// NewPreviewStage crea un staging como NewStage, pero en el directorio temporal del sistema:
+// sirve para generar y comparar el resultado (--dry-run) sin escribir nada en el proyecto.
+// No se puede confirmar con Commit.
+func NewPreviewStage(projectPath string, paths []string) (*Stage, error) {
+	if abs, err := filepath.Abs(projectPath); err == nil {
+		projectPath = abs
+	}
+	dir, err := os.MkdirTemp("", StagingPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	stage := &Stage{projectPath: projectPath, dir: dir, paths: paths, moved: make(map[string]bool), preview: true}
+	return stage, stage.fill()
+}
+
+// fill copia en el staging las rutas gestionadas que existen en el proyecto. Si falla,
+// elimina el staging.
+func (s *Stage) fill() error {
+	for _, rel := range s.paths {
+		src := filepath.Join(s.projectPath, filepath.FromSlash(rel))
+		if _, err := os.Lstat(src); os.IsNotExist(err) {
+			continue
+		}
+		if err := copyPath(src, filepath.Join(s.dir, filepath.FromSlash(rel))); err != nil {
+			s.Discard()
+			return fmt.Errorf("failed to stage %s: %w", rel, err)
+		}
+	}
+	return nil
+}
+
+// NewEmptyStage crea un staging vacío dentro del proyecto para las rutas indicadas. Antes
+// elimina los staging abandonados por ejecuciones anteriores.
+func NewEmptyStage(projectPath string, paths []string) (*Stage, error) {
+	if abs, err := filepath.Abs(projectPath); err == nil {
+		projectPath = abs
+	}
+	sweepStale(projectPath, time.Now().Add(-staleAge))
+	dir, err := os.MkdirTemp(projectPath, StagingPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	return &Stage{projectPath: projectPath, dir: dir, paths: paths, moved: make(map[string]bool)}, nil
+}
+
+// sweepStale elimina los directorios de staging del proyecto modificados antes de before: los
+// deja una ejecución que no llegó a confirmarlos ni descartarlos. Si se interrumpió durante
+// Commit, el estado anterior del proyecto ya está en su instantánea. Los errores se ignoran:
+// un staging que no se puede eliminar no impide crear otro.
+func sweepStale(projectPath string, before time.Time) {
+	entries, err := os.ReadDir(projectPath)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), StagingPrefix) {
+			continue
+		}
+		if info, err := entry.Info(); err == nil && info.ModTime().Before(before) {
+			_ = os.RemoveAll(filepath.Join(projectPath, entry.Name()))
+		}
+	}
+}
+
+// Dir retorna el directorio de staging, que hace de raíz del proyecto para la generación.
+func (s *Stage) Dir() string {
+	return s.dir
+}
+
+// Paths retorna las rutas gestionadas, relativas al proyecto.
+func (s *Stage) Paths() []string {
+	return s.paths
+}
+
+// Commit guarda en store una instantánea del estado actual de las rutas gestionadas y las
+// reemplaza por las del staging: las que existen en el staging se mueven al proyecto y las
+// que no, se eliminan. Si un reemplazo falla, se deshacen los anteriores. Retorna la
+// instantánea del estado anterior.
+func (s *Stage) Commit(store *Store, command string) (*Snapshot, error) {
+	if s.preview {
+		return nil, fmt.Errorf("a preview staging cannot be committed")
+	}
+	snapshot, err := store.Take(command, s.paths)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, rel := range s.paths {
+		if err := s.swap(rel); err != nil {
+			for j := i; j >= 0; j-- {
+				s.rollback(s.paths[j])
+			}
+			_ = os.RemoveAll(store.path(snapshot.ID))
+			return nil, fmt.Errorf("failed to replace %s (previous state kept): %w", rel, err)
+		}
+	}
+
+	s.Discard()
+	if err := store.Prune(DefaultKeep); err != nil {
+		return snapshot, err
+	}
+	return snapshot, nil
+}
+
+// Discard elimina el staging sin tocar el proyecto.
+func (s *Stage) Discard() {
+	_ = os.RemoveAll(s.dir)
+}
+
+// swap aparta la ruta del proyecto y mueve en su lugar la del staging, si existe.
+func (s *Stage) swap(rel string) error {
+	staged := filepath.Join(s.dir, filepath.FromSlash(rel))
+	target := filepath.Join(s.projectPath, filepath.FromSlash(rel))
+	old := filepath.Join(s.dir, oldDir, filepath.FromSlash(rel))
+
+	existed := false
+	if _, err := os.Lstat(target); err == nil {
+		if err := os.MkdirAll(filepath.Dir(old), 0755); err != nil {
+			return err
+		}
+		if err := os.Rename(target, old); err != nil {
+			return err
+		}
+		existed = true
+	}
+	s.moved[rel] = existed
+
+	if _, err := os.Lstat(staged); os.IsNotExist(err) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	return os.Rename(staged, target)
+}
+
+// rollback deshace el intercambio de una ruta: elimina la versión nueva y devuelve al
+// proyecto la que apartó swap.
+func (s *Stage) rollback(rel string) {
+	existed, ok := s.moved[rel]
+	if !ok {
+		return
+	}
+	target := filepath.Join(s.projectPath, filepath.FromSlash(rel))
+	_ = os.RemoveAll(target)
+	if existed {
+		_ = os.Rename(filepath.Join(s.dir, oldDir, filepath.FromSlash(rel)), target)
+	}
+}
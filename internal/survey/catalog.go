@@ -0,0 +1,123 @@
+package survey
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed embeds/catalogs.yaml
+var catalogsContent []byte
+
+// OtherOption es la opción de los catálogos que permite escribir un valor libre.
+const OtherOption = "Otro (especificar)"
+
+// CatalogEntry es un valor de un catálogo de opciones junto con sus alias.
+type CatalogEntry struct {
+	Value   string   `yaml:"value"`
+	Aliases []string `yaml:"aliases,omitempty"`
+}
+
+// catalogs contiene los catálogos incrustados, indexados por nombre (language, framework, ...).
+var catalogs = mustLoadCatalogs()
+
+// mustLoadCatalogs parsea los catálogos incrustados.
+func mustLoadCatalogs() map[string][]CatalogEntry {
+	parsed := make(map[string][]CatalogEntry)
+	if err := yaml.Unmarshal(catalogsContent, &parsed); err != nil {
+		panic(fmt.Sprintf("invalid embedded catalogs: %v", err))
+	}
+	return parsed
+}
+
+// CatalogOptions retorna los valores de un catálogo seguidos de OtherOption.
+// Retorna nil si el catálogo no existe.
+func CatalogOptions(name string) []string {
+	entries, ok := catalogs[name]
+	if !ok {
+		return nil
+	}
+
+	options := make([]string, 0, len(entries)+1)
+	for _, entry := range entries {
+		options = append(options, entry.Value)
+	}
+	return append(options, OtherOption)
+}
+
+// NormalizeChoice convierte un valor libre al valor del catálogo que coincide con él
+// (por valor o alias, sin distinguir mayúsculas). Si no hay coincidencia, retorna el valor limpio.
+func NormalizeChoice(catalog, value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+
+	for _, entry := range catalogs[catalog] {
+		if strings.EqualFold(entry.Value, value) {
+			return entry.Value
+		}
+		for _, alias := range entry.Aliases {
+			if strings.EqualFold(alias, value) {
+				return entry.Value
+			}
+		}
+	}
+
+	return value
+}
+
+// NormalizeList normaliza cada elemento de una lista separada por comas y elimina duplicados.
+func NormalizeList(catalog, value string) string {
+	items := SplitList(value)
+	normalized := make([]string, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		item = NormalizeChoice(catalog, item)
+		key := strings.ToLower(item)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, item)
+	}
+	return JoinList(normalized)
+}
+
+// SplitList separa una respuesta de selección múltiple ("Gin, GORM") en sus elementos.
+func SplitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// JoinList une los elementos de una selección múltiple en el formato guardado en Answers.
+func JoinList(items []string) string {
+	return strings.Join(items, ", ")
+}
+
+// Frameworks retorna los frameworks seleccionados.
+func (a *Answers) Frameworks() []string {
+	return SplitList(a.Framework)
+}
+
+// Databases retorna las bases de datos seleccionadas.
+func (a *Answers) Databases() []string {
+	return SplitList(a.Database)
+}
+
+// Normalize convierte las respuestas libres de lenguaje, framework, arquitectura,
+// base de datos y categoría a los valores de sus catálogos.
+func (a *Answers) Normalize() {
+	a.Language = NormalizeChoice("language", a.Language)
+	a.Framework = NormalizeList("framework", a.Framework)
+	a.Architecture = NormalizeChoice("architecture", a.Architecture)
+	a.Database = NormalizeList("database", a.Database)
+	a.ProjectCategory = NormalizeChoice("project_category", a.ProjectCategory)
+}
@@ -0,0 +1,87 @@
+package survey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCatalogOptions_IncludesOther verifica que los catálogos terminan con OtherOption.
+func TestCatalogOptions_IncludesOther(t *testing.T) {
+	for _, name := range []string{"language", "framework", "architecture", "database", "project_category"} {
+		options := CatalogOptions(name)
+		require.NotEmpty(t, options, "catalog %s", name)
+		assert.Equal(t, OtherOption, options[len(options)-1])
+	}
+
+	assert.Nil(t, CatalogOptions("unknown"))
+}
+
+// TestNormalizeChoice verifica la normalización por valor y alias.
+func TestNormalizeChoice(t *testing.T) {
+	tests := []struct {
+		catalog string
+		value   string
+		want    string
+	}{
+		{"architecture", "microservices", "Microservicios"},
+		{"architecture", " hexagonal ", "Hexagonal"},
+		{"architecture", "Ports and Adapters", "Hexagonal"},
+		{"architecture", "Domain-Driven Design", "DDD"},
+		{"language", "golang", "Go"},
+		{"language", "Node.js", "JavaScript"},
+		{"database", "postgres", "PostgreSQL"},
+		{"project_category", "rest api", "API REST"},
+		{"architecture", "Cell-based", "Cell-based"},
+		{"language", "", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, NormalizeChoice(tt.catalog, tt.value), "%s: %q", tt.catalog, tt.value)
+	}
+}
+
+// TestNormalizeList verifica la normalización de selecciones múltiples.
+func TestNormalizeList(t *testing.T) {
+	assert.Equal(t, "PostgreSQL, Redis", NormalizeList("database", "postgres, redis, PostgreSQL"))
+	assert.Equal(t, "", NormalizeList("database", " , "))
+}
+
+// TestAnswers_Normalize verifica que las respuestas libres se normalizan a los catálogos.
+func TestAnswers_Normalize(t *testing.T) {
+	answers := &Answers{
+		Language:        "golang",
+		Framework:       "gin,echo",
+		Architecture:    "microservices",
+		Database:        "postgres, mongo",
+		ProjectCategory: "cli tool",
+	}
+
+	answers.Normalize()
+
+	assert.Equal(t, "Go", answers.Language)
+	assert.Equal(t, []string{"Gin", "Echo"}, answers.Frameworks())
+	assert.Equal(t, "Microservicios", answers.Architecture)
+	assert.Equal(t, []string{"PostgreSQL", "MongoDB"}, answers.Databases())
+	assert.Equal(t, "CLI", answers.ProjectCategory)
+}
+
+// TestRunner_choiceOptions_AddsPrefilledValues verifica que los valores pre-llenados fuera del catálogo se ofrecen.
+func TestRunner_choiceOptions_AddsPrefilledValues(t *testing.T) {
+	r := NewRunner(nil)
+	q := &Question{ID: "architecture", Options: []string{"Monolito", OtherOption}}
+
+	options := r.choiceOptions(q, []string{"Monolito", "Cell-based", ""})
+	assert.Equal(t, []string{"Monolito", "Cell-based", OtherOption}, options)
+	assert.Equal(t, []string{"Monolito", OtherOption}, q.Options, "no debe modificar las opciones originales")
+}
+
+// TestRunner_resolveOther_WithoutOther verifica que la selección se mantiene si no se elige OtherOption.
+func TestRunner_resolveOther_WithoutOther(t *testing.T) {
+	r := NewRunner(nil)
+	selected, err := r.resolveOther(&Question{ID: "database"}, []string{"PostgreSQL", "Redis"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PostgreSQL", "Redis"}, selected)
+}
@@ -0,0 +1,244 @@
+package survey
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed embeds/questions.yaml
+var defaultQuestionsContent []byte
+
+// QuestionSet es el formato YAML de un conjunto de preguntas.
+type QuestionSet struct {
+	// Replace indica que el conjunto reemplaza por completo a las preguntas por defecto
+	// en lugar de combinarse con ellas por ID.
+	Replace   bool        `yaml:"replace,omitempty"`
+	Questions []*Question `yaml:"questions"`
+}
+
+// ParseQuestionSet parsea y valida un conjunto de preguntas en YAML.
+func ParseQuestionSet(data []byte) (*QuestionSet, error) {
+	var set QuestionSet
+	if err := yaml.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse question set: %w", err)
+	}
+
+	seen := make(map[string]bool, len(set.Questions))
+	for i, q := range set.Questions {
+		if q == nil || strings.TrimSpace(q.ID) == "" {
+			return nil, fmt.Errorf("question %d: id is required", i+1)
+		}
+		if seen[q.ID] {
+			return nil, fmt.Errorf("question %s: duplicated id", q.ID)
+		}
+		seen[q.ID] = true
+
+		if q.Type == "" {
+			q.Type = QuestionTypeInput
+		}
+		if q.Catalog != "" && len(q.Options) == 0 {
+			q.Options = CatalogOptions(q.Catalog)
+			if q.Options == nil {
+				return nil, fmt.Errorf("question %s: unknown catalog %q", q.ID, q.Catalog)
+			}
+		}
+		if err := q.check(); err != nil {
+			return nil, fmt.Errorf("question %s: %w", q.ID, err)
+		}
+	}
+
+	return &set, nil
+}
+
+// LoadQuestions carga las preguntas por defecto y aplica encima el primer archivo
+// existente de paths (configuración del proyecto o del equipo).
+func LoadQuestions(paths ...string) ([]*Question, error) {
+	defaults, err := ParseQuestionSet(defaultQuestionsContent)
+	if err != nil {
+		return nil, fmt.Errorf("invalid default questions: %w", err)
+	}
+
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read questions file %s: %w", path, err)
+		}
+
+		override, err := ParseQuestionSet(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid questions file %s: %w", path, err)
+		}
+
+		return mergeQuestions(defaults.Questions, override), nil
+	}
+
+	return defaults.Questions, nil
+}
+
+// mergeQuestions combina las preguntas por defecto con un conjunto personalizado.
+// Las preguntas con el mismo ID se reemplazan en su posición; las nuevas se añaden al final.
+func mergeQuestions(defaults []*Question, override *QuestionSet) []*Question {
+	if override.Replace {
+		return override.Questions
+	}
+
+	index := make(map[string]int, len(defaults))
+	merged := make([]*Question, len(defaults))
+	for i, q := range defaults {
+		merged[i] = q
+		index[q.ID] = i
+	}
+
+	for _, q := range override.Questions {
+		if i, ok := index[q.ID]; ok {
+			merged[i] = q
+			continue
+		}
+		merged = append(merged, q)
+	}
+
+	return merged
+}
+
+// check valida la definición de una pregunta.
+func (q *Question) check() error {
+	switch q.Type {
+	case QuestionTypeInput, QuestionTypeMultiline, QuestionTypeConfirm:
+	case QuestionTypeSelect, QuestionTypeMultiSelect:
+		if len(q.Options) == 0 {
+			return fmt.Errorf("type %s requires options", q.Type)
+		}
+	default:
+		return fmt.Errorf("unknown type %q", q.Type)
+	}
+
+	if _, _, err := parseCondition(q.When); err != nil {
+		return err
+	}
+
+	for _, rule := range q.Validators {
+		if _, err := parseValidator(rule); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ShouldAsk evalúa la condición when: de la pregunta con las respuestas recogidas hasta el momento.
+//
+// Formatos soportados: "id" (respuesta no vacía), "!id" (respuesta vacía),
+// "id == valor" e "id != valor". La comparación no distingue mayúsculas.
+func (q *Question) ShouldAsk(answers *Answers) bool {
+	if strings.TrimSpace(q.When) == "" {
+		return true
+	}
+
+	id, match, err := parseCondition(q.When)
+	if err != nil {
+		return true
+	}
+
+	return match(answers.Get(id))
+}
+
+// parseCondition parsea una condición when: y retorna el ID evaluado y la función de comparación.
+func parseCondition(when string) (string, func(string) bool, error) {
+	when = strings.TrimSpace(when)
+	if when == "" {
+		return "", func(string) bool { return true }, nil
+	}
+
+	for _, op := range []string{"!=", "=="} {
+		if parts := strings.SplitN(when, op, 2); len(parts) == 2 {
+			id := strings.TrimSpace(parts[0])
+			expected := strings.Trim(strings.TrimSpace(parts[1]), `"'`)
+			if id == "" {
+				return "", nil, fmt.Errorf("invalid when condition %q", when)
+			}
+			equal := op == "=="
+			return id, func(value string) bool {
+				return strings.EqualFold(strings.TrimSpace(value), expected) == equal
+			}, nil
+		}
+	}
+
+	if strings.HasPrefix(when, "!") {
+		id := strings.TrimSpace(strings.TrimPrefix(when, "!"))
+		return id, func(value string) bool { return strings.TrimSpace(value) == "" }, nil
+	}
+
+	return when, func(value string) bool { return strings.TrimSpace(value) != "" }, nil
+}
+
+// Validate aplica los validadores declarados (validate:) a una respuesta.
+// Las reglas soportadas son min_length=N, max_length=N y pattern=REGEX.
+func (q *Question) Validate(value string) error {
+	for _, rule := range q.Validators {
+		validate, err := parseValidator(rule)
+		if err != nil {
+			return err
+		}
+		if err := validate(strings.TrimSpace(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseValidator convierte una regla de validación en una función.
+func parseValidator(rule string) (func(string) error, error) {
+	name, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+	name = strings.TrimSpace(name)
+	arg = strings.TrimSpace(arg)
+
+	switch name {
+	case "min_length", "max_length":
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid validator %q", rule)
+		}
+		if name == "min_length" {
+			return func(value string) error {
+				if len([]rune(value)) < n {
+					return fmt.Errorf("la respuesta debe tener al menos %d caracteres", n)
+				}
+				return nil
+			}, nil
+		}
+		return func(value string) error {
+			if len([]rune(value)) > n {
+				return fmt.Errorf("la respuesta debe tener como máximo %d caracteres", n)
+			}
+			return nil
+		}, nil
+
+	case "pattern":
+		re, err := regexp.Compile(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validator %q: %w", rule, err)
+		}
+		return func(value string) error {
+			if value != "" && !re.MatchString(value) {
+				return fmt.Errorf("la respuesta no cumple el formato esperado (%s)", arg)
+			}
+			return nil
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown validator %q", rule)
+	}
+}
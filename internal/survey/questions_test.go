@@ -0,0 +1,130 @@
+package survey
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoadQuestions_DefaultsAreValid verifica que el archivo incrustado es válido.
+func TestLoadQuestions_DefaultsAreValid(t *testing.T) {
+	questions, err := LoadQuestions()
+	require.NoError(t, err)
+	require.Len(t, questions, 8)
+	assert.Equal(t, "project_name", questions[0].ID)
+	assert.Equal(t, QuestionTypeMultiline, questions[7].Type)
+	assert.Equal(t, []string{"min_length=20"}, questions[7].Validators)
+}
+
+// TestLoadQuestions_MergesOverrideByID verifica que un archivo personalizado se combina por ID.
+func TestLoadQuestions_MergesOverrideByID(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "questions.yaml")
+	content := `questions:
+  - id: language
+    text: "Main language:"
+    type: select
+    options: [Go, Python]
+    required: true
+  - id: team_size
+    text: "Team size:"
+    validate: ["pattern=^[0-9]+$"]
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	questions, err := LoadQuestions("", filepath.Join(t.TempDir(), "missing.yaml"), path)
+	require.NoError(t, err)
+	require.Len(t, questions, 9)
+	assert.Equal(t, QuestionTypeSelect, questions[2].Type)
+	assert.Equal(t, "team_size", questions[8].ID)
+	assert.Equal(t, QuestionTypeInput, questions[8].Type)
+}
+
+// TestLoadQuestions_Replace verifica que replace: true reemplaza las preguntas por defecto.
+func TestLoadQuestions_Replace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "questions.yaml")
+	content := `replace: true
+questions:
+  - id: project_name
+    text: "Name:"
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	questions, err := LoadQuestions(path)
+	require.NoError(t, err)
+	assert.Len(t, questions, 1)
+}
+
+// TestParseQuestionSet_InvalidDefinitions verifica los errores de definición.
+func TestParseQuestionSet_InvalidDefinitions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"missing id", "questions:\n  - text: x\n"},
+		{"duplicated id", "questions:\n  - id: a\n  - id: a\n"},
+		{"unknown type", "questions:\n  - id: a\n    type: slider\n"},
+		{"select without options", "questions:\n  - id: a\n    type: select\n"},
+		{"unknown validator", "questions:\n  - id: a\n    validate: [email]\n"},
+		{"invalid pattern", "questions:\n  - id: a\n    validate: ['pattern=[']\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseQuestionSet([]byte(tt.content))
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestQuestion_ShouldAsk verifica la evaluación de las condiciones when:.
+func TestQuestion_ShouldAsk(t *testing.T) {
+	answers := &Answers{Language: "Go", Extra: map[string]string{"uses_queue": "yes"}}
+
+	tests := []struct {
+		when string
+		want bool
+	}{
+		{"", true},
+		{"language == go", true},
+		{"language == Python", false},
+		{"language != Python", true},
+		{"database", false},
+		{"!database", true},
+		{"uses_queue == 'yes'", true},
+	}
+
+	for _, tt := range tests {
+		q := &Question{ID: "q", When: tt.when}
+		assert.Equal(t, tt.want, q.ShouldAsk(answers), "when: %q", tt.when)
+	}
+}
+
+// TestQuestion_Validate verifica los validadores declarados.
+func TestQuestion_Validate(t *testing.T) {
+	q := &Question{ID: "q", Validators: []string{"min_length=3", "max_length=5", "pattern=^[a-z]+$"}}
+
+	assert.NoError(t, q.Validate("abcd"))
+	assert.Error(t, q.Validate("ab"))
+	assert.Error(t, q.Validate("abcdef"))
+	assert.Error(t, q.Validate("ABCD"))
+}
+
+// TestAnswers_GetSet_Extra verifica que los IDs personalizados se guardan en Extra.
+func TestAnswers_GetSet_Extra(t *testing.T) {
+	answers := &Answers{}
+	answers.Set("language", "Go")
+	answers.Set("team_size", "5")
+
+	assert.Equal(t, "Go", answers.Language)
+	assert.Equal(t, "5", answers.Extra["team_size"])
+	assert.Equal(t, "5", answers.Get("team_size"))
+	assert.Equal(t, "5", answers.ToMap()["team_size"])
+	assert.Contains(t, answers.ExtraContext(), "team_size: 5")
+
+	loaded := &Answers{}
+	loaded.FromMap(answers.ToMap())
+	assert.Equal(t, answers.Extra, loaded.Extra)
+}
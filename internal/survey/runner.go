@@ -34,9 +34,18 @@ func (r *Runner) RunWithPrefill(prefill *Answers) (*Answers, error) {
 	// Si hay prefill, copiar esos valores iniciales
 	if prefill != nil {
 		*answers = *prefill
+		answers.Extra = nil
+		for id, value := range prefill.Extra {
+			answers.Set(id, value)
+		}
 	}
 
 	for _, q := range r.Survey.Questions {
+		// Saltar las preguntas cuya condición when: no se cumple
+		if !q.ShouldAsk(answers) {
+			continue
+		}
+
 		// Obtener valor pre-llenado para esta pregunta
 		defaultValue := r.getPrefillValue(prefill, q.ID)
 		if defaultValue == "" {
@@ -54,59 +63,51 @@ func (r *Runner) RunWithPrefill(prefill *Answers) (*Answers, error) {
 				Default: defaultValue,
 				Help:    q.Placeholder,
 			}
-			if q.Required {
-				err = survey.AskOne(prompt, &answer, survey.WithValidator(survey.Required))
-			} else {
-				err = survey.AskOne(prompt, &answer)
-			}
+			err = survey.AskOne(prompt, &answer, r.askOptions(q)...)
 
 		case QuestionTypeMultiline:
-			// Usar input estándar con validación de longitud mínima
+			// Usar input estándar con los validadores declarados (ej: longitud mínima)
 			// El usuario puede pegar texto o escribir una descripción corta
+			help := q.Placeholder
+			if help == "" {
+				help = "Puedes pegar texto o escribir una descripción corta."
+			}
 			prompt := &survey.Input{
 				Message: q.Text,
 				Default: defaultValue,
-				Help:    "Describe el contexto del negocio, objetivos y requisitos. Puedes pegar texto o escribir una descripción corta.",
-			}
-			if q.Required {
-				err = survey.AskOne(prompt, &answer, survey.WithValidator(func(ans interface{}) error {
-					str, ok := ans.(string)
-					if !ok || strings.TrimSpace(str) == "" {
-						return fmt.Errorf("se requiere una descripción del contexto del negocio (mínimo 20 caracteres)")
-					}
-					if len(strings.TrimSpace(str)) < 20 {
-						return fmt.Errorf("la descripción es muy corta. Por favor, proporciona más detalles (mínimo 20 caracteres)")
-					}
-					return nil
-				}))
-			} else {
-				err = survey.AskOne(prompt, &answer)
+				Help:    help,
 			}
+			err = survey.AskOne(prompt, &answer, r.askOptions(q)...)
 
 		case QuestionTypeSelect:
+			defaultChoice := NormalizeChoice(q.Catalog, defaultValue)
 			prompt := &survey.Select{
 				Message: q.Text,
-				Options: q.Options,
-				Default: defaultValue,
+				Options: r.choiceOptions(q, []string{defaultChoice}),
 			}
-			if q.Required {
-				err = survey.AskOne(prompt, &answer, survey.WithValidator(survey.Required))
-			} else {
-				err = survey.AskOne(prompt, &answer)
+			if defaultChoice != "" {
+				prompt.Default = defaultChoice
+			}
+			err = survey.AskOne(prompt, &answer, r.askOptions(q)...)
+			if err == nil && answer == OtherOption {
+				answer, err = r.askOther(q)
 			}
 
 		case QuestionTypeMultiSelect:
 			var selected []string
+			defaults := SplitList(NormalizeList(q.Catalog, defaultValue))
 			prompt := &survey.MultiSelect{
 				Message: q.Text,
-				Options: q.Options,
+				Options: r.choiceOptions(q, defaults),
 			}
-			if q.Required {
-				err = survey.AskOne(prompt, &selected, survey.WithValidator(survey.MinItems(1)))
-			} else {
-				err = survey.AskOne(prompt, &selected)
+			if len(defaults) > 0 {
+				prompt.Default = defaults
+			}
+			err = survey.AskOne(prompt, &selected, r.askOptions(q)...)
+			if err == nil {
+				selected, err = r.resolveOther(q, selected)
 			}
-			answer = fmt.Sprintf("%v", selected)
+			answer = JoinList(selected)
 
 		case QuestionTypeConfirm:
 			var confirmed bool
@@ -126,35 +127,120 @@ func (r *Runner) RunWithPrefill(prefill *Answers) (*Answers, error) {
 			return nil, fmt.Errorf("failed to ask question %s: %w", q.ID, err)
 		}
 
+		// Las preguntas de seguimiento se guardan junto con su texto
+		if strings.HasPrefix(q.ID, FollowUpQuestionPrefix) {
+			answers.AddFollowUp(q.Text, answer)
+			continue
+		}
+
 		// Asignar respuesta al campo correspondiente
 		r.setAnswer(answers, q.ID, answer)
 	}
 
+	// Normalizar las respuestas libres a los valores de los catálogos
+	answers.Normalize()
+
 	return answers, nil
 }
 
-// setAnswer asigna la respuesta al campo correspondiente en Answers.
-func (r *Runner) setAnswer(answers *Answers, id, value string) {
-	switch id {
-	case "project_origin":
-		answers.ProjectOrigin = value
-	case "project_name":
-		answers.ProjectName = value
-	case "description":
-		answers.Description = value
-	case "language":
-		answers.Language = value
-	case "framework":
-		answers.Framework = value
-	case "architecture":
-		answers.Architecture = value
-	case "database":
-		answers.Database = value
-	case "project_category":
-		answers.ProjectCategory = value
-	case "business_context":
-		answers.BusinessContext = value
+// choiceOptions retorna las opciones de una pregunta de selección, añadiendo los valores
+// pre-llenados que no están en la lista para que el usuario pueda aceptarlos.
+func (r *Runner) choiceOptions(q *Question, values []string) []string {
+	options := append([]string{}, q.Options...)
+	for _, value := range values {
+		if value == "" || contains(options, value) {
+			continue
+		}
+		// Insertar antes de OtherOption si existe
+		if n := len(options); n > 0 && options[n-1] == OtherOption {
+			options = append(options[:n-1], value, OtherOption)
+			continue
+		}
+		options = append(options, value)
 	}
+	return options
+}
+
+// askOther pide un valor libre cuando el usuario elige OtherOption.
+func (r *Runner) askOther(q *Question) (string, error) {
+	var value string
+	prompt := &survey.Input{
+		Message: "Especifica:",
+		Help:    "Puedes indicar varios valores separados por comas.",
+	}
+	if err := survey.AskOne(prompt, &value, survey.WithValidator(survey.Required)); err != nil {
+		return "", err
+	}
+	if q.Type == QuestionTypeMultiSelect {
+		return NormalizeList(q.Catalog, value), nil
+	}
+	return NormalizeChoice(q.Catalog, value), nil
+}
+
+// resolveOther sustituye OtherOption en una selección múltiple por los valores que escriba el usuario.
+func (r *Runner) resolveOther(q *Question, selected []string) ([]string, error) {
+	result := make([]string, 0, len(selected))
+	askOther := false
+	for _, item := range selected {
+		if item == OtherOption {
+			askOther = true
+			continue
+		}
+		result = append(result, item)
+	}
+
+	if !askOther {
+		return result, nil
+	}
+
+	other, err := r.askOther(q)
+	if err != nil {
+		return nil, err
+	}
+	return append(result, SplitList(other)...), nil
+}
+
+// contains verifica si una lista contiene un valor.
+func contains(items []string, value string) bool {
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// askOptions construye las opciones de validación de survey para una pregunta.
+func (r *Runner) askOptions(q *Question) []survey.AskOpt {
+	var opts []survey.AskOpt
+	if q.Type == QuestionTypeMultiSelect {
+		if q.Required {
+			opts = append(opts, survey.WithValidator(survey.MinItems(1)))
+		}
+		return opts
+	}
+	if q.Required {
+		opts = append(opts, survey.WithValidator(survey.Required))
+	}
+	if len(q.Validators) > 0 {
+		opts = append(opts, survey.WithValidator(func(ans interface{}) error {
+			str, ok := ans.(string)
+			if !ok {
+				// Las respuestas de select llegan como survey.OptionAnswer
+				if option, isOption := ans.(survey.OptionAnswer); isOption {
+					str = option.Value
+				}
+			}
+			return q.Validate(str)
+		}))
+	}
+	return opts
+}
+
+// setAnswer asigna la respuesta al campo correspondiente en Answers
+// (o a Answers.Extra si es una pregunta personalizada).
+func (r *Runner) setAnswer(answers *Answers, id, value string) {
+	answers.Set(id, value)
 }
 
 // getPrefillValue obtiene el valor pre-llenado para una pregunta específica.
@@ -162,27 +248,5 @@ func (r *Runner) getPrefillValue(prefill *Answers, id string) string {
 	if prefill == nil {
 		return ""
 	}
-
-	switch id {
-	case "project_origin":
-		return prefill.ProjectOrigin
-	case "project_name":
-		return prefill.ProjectName
-	case "description":
-		return prefill.Description
-	case "language":
-		return prefill.Language
-	case "framework":
-		return prefill.Framework
-	case "architecture":
-		return prefill.Architecture
-	case "database":
-		return prefill.Database
-	case "project_category":
-		return prefill.ProjectCategory
-	case "business_context":
-		return prefill.BusinessContext
-	default:
-		return ""
-	}
+	return prefill.Get(id)
 }
@@ -112,3 +112,16 @@ func TestAnswers_FromMap_WithEmptyMap_CreatesEmptyAnswers(t *testing.T) {
 	assert.Empty(t, answers.ProjectCategory)
 	assert.Empty(t, answers.BusinessContext)
 }
+
+// TestAnswers_FromMap_ParsesDocumentationDirs verifica que documentation_dirs se separa por comas.
+func TestAnswers_FromMap_ParsesDocumentationDirs(t *testing.T) {
+	answers := &Answers{}
+	answers.FromMap(map[string]string{
+		"ai_provider":        "openai",
+		"documentation_dirs": "docs, adr,,",
+	})
+
+	assert.Equal(t, "openai", answers.AIProvider)
+	assert.Equal(t, []string{"docs", "adr"}, answers.DocumentationDirs)
+	assert.Equal(t, "docs,adr", answers.ToMap()["documentation_dirs"])
+}
@@ -4,6 +4,7 @@ package survey
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -25,28 +26,102 @@ const (
 
 // Question representa una pregunta del survey.
 type Question struct {
-	ID          string       // Identificador único de la pregunta
-	Text        string       // Texto de la pregunta
-	Type        QuestionType // Tipo de pregunta
-	Required    bool         // Si es true, la respuesta es obligatoria
-	Options     []string     // Opciones para select/multiselect
-	Default     string       // Valor por defecto
-	Placeholder string       // Placeholder para input
+	ID          string       `yaml:"id"`                    // Identificador único de la pregunta
+	Text        string       `yaml:"text"`                  // Texto de la pregunta
+	Type        QuestionType `yaml:"type"`                  // Tipo de pregunta
+	Required    bool         `yaml:"required,omitempty"`    // Si es true, la respuesta es obligatoria
+	Options     []string     `yaml:"options,omitempty"`     // Opciones para select/multiselect
+	Catalog     string       `yaml:"catalog,omitempty"`     // Catálogo que proporciona las opciones (language, framework, ...)
+	Default     string       `yaml:"default,omitempty"`     // Valor por defecto
+	Placeholder string       `yaml:"placeholder,omitempty"` // Placeholder para input
+	When        string       `yaml:"when,omitempty"`        // Condición para hacer la pregunta (ej: "database != ''")
+	Validators  []string     `yaml:"validate,omitempty"`    // Reglas de validación (min_length=N, max_length=N, pattern=REGEX)
 }
 
 // Answers contiene las respuestas del usuario.
 type Answers struct {
-	ProjectOrigin     string   // Origen del proyecto: "new" o "existing"
-	ProjectName       string   // Nombre del proyecto
-	Description       string   // Descripción breve
-	Language          string   // Lenguaje principal
-	Framework         string   // Framework (si aplica)
-	Architecture      string   // Arquitectura
-	Database          string   // Base de datos (si aplica)
-	ProjectCategory   string   // Categoría del proyecto (API REST, Web App, CLI, Library, etc.)
-	BusinessContext   string   // Contexto del negocio
-	AIProvider        string   // Provider de IA: "cli", "claude-api", "openai", "zai"
-	DocumentationDirs []string // Directorios de documentación adicionales (para proyectos existentes)
+	ProjectOrigin     string            // Origen del proyecto: "new" o "existing"
+	ProjectName       string            // Nombre del proyecto
+	Description       string            // Descripción breve
+	Language          string            // Lenguaje principal
+	Framework         string            // Framework (si aplica)
+	Architecture      string            // Arquitectura
+	Database          string            // Base de datos (si aplica)
+	ProjectCategory   string            // Categoría del proyecto (API REST, Web App, CLI, Library, etc.)
+	BusinessContext   string            // Contexto del negocio
+	AIProvider        string            // Provider de IA: "cli", "claude-api", "openai", "zai"
+	DocumentationDirs []string          // Directorios de documentación adicionales (para proyectos existentes)
+	Packages          []string          // Paquetes de un monorepo que tendrán su propio CLAUDE.md (rutas relativas)
+	FollowUps         []FollowUp        // Respuestas a las preguntas de seguimiento sugeridas por la IA
+	Conventions       []string          // Convenciones de código observadas al analizar un proyecto existente
+	Extra             map[string]string // Respuestas a preguntas personalizadas (por ID)
+}
+
+// FollowUpQuestionPrefix es el prefijo de ID de las preguntas de seguimiento generadas por la IA.
+const FollowUpQuestionPrefix = "follow_up_"
+
+// FollowUp es una pregunta de seguimiento de la IA junto con la respuesta del usuario.
+type FollowUp struct {
+	Question string `yaml:"question" json:"question"`
+	Answer   string `yaml:"answer" json:"answer"`
+}
+
+// NewFollowUpQuestions crea preguntas opcionales a partir de las preguntas sugeridas por la IA.
+func NewFollowUpQuestions(texts []string) []*Question {
+	questions := make([]*Question, 0, len(texts))
+	for _, text := range texts {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		questions = append(questions, &Question{
+			ID:          fmt.Sprintf("%s%d", FollowUpQuestionPrefix, len(questions)+1),
+			Text:        text,
+			Type:        QuestionTypeInput,
+			Required:    false,
+			Placeholder: "Opcional, presiona Enter para omitir",
+		})
+	}
+	return questions
+}
+
+// AddFollowUp guarda la respuesta a una pregunta de seguimiento. Las respuestas vacías se ignoran.
+func (a *Answers) AddFollowUp(question, answer string) {
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return
+	}
+	a.FollowUps = append(a.FollowUps, FollowUp{Question: question, Answer: answer})
+}
+
+// FollowUpContext formatea las respuestas de seguimiento para incluirlas en los prompts.
+// Retorna un string vacío si no hay respuestas.
+func (a *Answers) FollowUpContext() string {
+	if len(a.FollowUps) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Additional project details provided by the user:\n")
+	for _, followUp := range a.FollowUps {
+		sb.WriteString(fmt.Sprintf("- %s\n  %s\n", followUp.Question, followUp.Answer))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// ConventionsContext formatea las convenciones de código observadas para incluirlas en los prompts.
+// Retorna un string vacío si no hay convenciones.
+func (a *Answers) ConventionsContext() string {
+	if len(a.Conventions) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Coding conventions observed in the existing code (follow them):\n")
+	for _, convention := range a.Conventions {
+		sb.WriteString(fmt.Sprintf("- %s\n", convention))
+	}
+	return strings.TrimRight(sb.String(), "\n")
 }
 
 // Survey representa un conjunto de preguntas.
@@ -61,64 +136,15 @@ func NewSurvey() *Survey {
 	}
 }
 
-// GetProjectQuestions retorna las 8 preguntas predefinidas para el proyecto.
+// GetProjectQuestions retorna las 8 preguntas predefinidas para el proyecto
+// (definidas en embeds/questions.yaml).
 func GetProjectQuestions() []*Question {
-	return []*Question{
-		{
-			ID:       "project_name",
-			Text:     "Nombre del proyecto:",
-			Type:     QuestionTypeInput,
-			Required: true,
-		},
-		{
-			ID:       "description",
-			Text:     "Descripción breve del proyecto:",
-			Type:     QuestionTypeInput,
-			Required: true,
-		},
-		{
-			ID:       "language",
-			Text:     "Lenguaje principal:",
-			Type:     QuestionTypeInput,
-			Default:  "",
-			Required: true,
-		},
-		{
-			ID:       "framework",
-			Text:     "Framework (opcional, presiona Enter para omitir):",
-			Type:     QuestionTypeInput,
-			Default:  "",
-			Required: false,
-		},
-		{
-			ID:       "architecture",
-			Text:     "Arquitectura deseada:",
-			Type:     QuestionTypeInput,
-			Default:  "",
-			Required: true,
-		},
-		{
-			ID:       "database",
-			Text:     "Base de datos (opcional, presiona Enter para omitir):",
-			Type:     QuestionTypeInput,
-			Default:  "",
-			Required: false,
-		},
-		{
-			ID:       "project_category",
-			Text:     "Categoría del proyecto (ej: API REST, Web App, CLI, Library):",
-			Type:     QuestionTypeInput,
-			Default:  "",
-			Required: true,
-		},
-		{
-			ID:          "business_context",
-			Text:        "Contexto del negocio (descripción detallada):",
-			Type:        QuestionTypeMultiline,
-			Required:    true,
-			Placeholder: "Escribe una descripción detallada del contexto del negocio, objetivos y requisitos...",
-		},
+	questions, err := LoadQuestions()
+	if err != nil {
+		// El archivo incrustado se valida en los tests; no debería fallar nunca
+		panic(err)
 	}
+	return questions
 }
 
 // Validate verifica que todas las respuestas requeridas estén presentes.
@@ -159,30 +185,128 @@ func (a *Answers) Validate() error {
 	return nil
 }
 
+// fields mapea los IDs de pregunta predefinidos a los campos de Answers.
+func (a *Answers) fields() map[string]*string {
+	return map[string]*string{
+		"project_origin":   &a.ProjectOrigin,
+		"project_name":     &a.ProjectName,
+		"description":      &a.Description,
+		"language":         &a.Language,
+		"framework":        &a.Framework,
+		"architecture":     &a.Architecture,
+		"database":         &a.Database,
+		"project_category": &a.ProjectCategory,
+		"business_context": &a.BusinessContext,
+		"ai_provider":      &a.AIProvider,
+	}
+}
+
+// listFields mapea los IDs de las respuestas de tipo lista a los campos de Answers.
+func (a *Answers) listFields() map[string]*[]string {
+	return map[string]*[]string{
+		"documentation_dirs": &a.DocumentationDirs,
+		"packages":           &a.Packages,
+	}
+}
+
+// Get retorna la respuesta a la pregunta con el ID dado.
+// Las listas se retornan separadas por comas y los IDs que no corresponden
+// a un campo se buscan en Extra.
+func (a *Answers) Get(id string) string {
+	if field, ok := a.fields()[id]; ok {
+		return *field
+	}
+	if list, ok := a.listFields()[id]; ok {
+		return strings.Join(*list, ",")
+	}
+	return a.Extra[id]
+}
+
+// Set asigna la respuesta a la pregunta con el ID dado.
+// Los IDs que no corresponden a un campo se guardan en Extra.
+func (a *Answers) Set(id, value string) {
+	if field, ok := a.fields()[id]; ok {
+		*field = value
+		return
+	}
+
+	if list, ok := a.listFields()[id]; ok {
+		*list = nil
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*list = append(*list, item)
+			}
+		}
+		return
+	}
+
+	if a.Extra == nil {
+		a.Extra = make(map[string]string)
+	}
+	a.Extra[id] = value
+}
+
 // ToMap convierte Answers a un map[string]string.
+// Las listas (documentation_dirs, packages) se serializan separadas por comas y
+// las respuestas personalizadas se incluyen con su propio ID.
 func (a *Answers) ToMap() map[string]string {
-	return map[string]string{
-		"project_origin":   a.ProjectOrigin,
-		"project_name":     a.ProjectName,
-		"description":      a.Description,
-		"language":         a.Language,
-		"framework":        a.Framework,
-		"architecture":     a.Architecture,
-		"database":         a.Database,
-		"project_category": a.ProjectCategory,
-		"business_context": a.BusinessContext,
+	m := make(map[string]string, len(a.Extra)+12)
+	for id, value := range a.Extra {
+		m[id] = value
+	}
+	for id := range a.fields() {
+		m[id] = a.Get(id)
+	}
+	for id := range a.listFields() {
+		m[id] = a.Get(id)
 	}
+	return m
 }
 
 // FromMap carga Answers desde un map[string]string.
+// Las listas (documentation_dirs, packages) aceptan valores separados por comas
+// y las claves desconocidas se guardan en Extra.
 func (a *Answers) FromMap(m map[string]string) {
-	a.ProjectOrigin = m["project_origin"]
-	a.ProjectName = m["project_name"]
-	a.Description = m["description"]
-	a.Language = m["language"]
-	a.Framework = m["framework"]
-	a.Architecture = m["architecture"]
-	a.Database = m["database"]
-	a.ProjectCategory = m["project_category"]
-	a.BusinessContext = m["business_context"]
+	for id := range a.fields() {
+		a.Set(id, m[id])
+	}
+	for id := range a.listFields() {
+		a.Set(id, m[id])
+	}
+
+	a.Extra = nil
+	for id, value := range m {
+		_, isField := a.fields()[id]
+		_, isList := a.listFields()[id]
+		if isField || isList || value == "" {
+			continue
+		}
+		a.Set(id, value)
+	}
+}
+
+// ExtraContext formatea las respuestas personalizadas para incluirlas en los prompts.
+// Retorna un string vacío si no hay respuestas personalizadas.
+func (a *Answers) ExtraContext() string {
+	if len(a.Extra) == 0 {
+		return ""
+	}
+
+	ids := make([]string, 0, len(a.Extra))
+	for id, value := range a.Extra {
+		if strings.TrimSpace(value) != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return ""
+	}
+	sort.Strings(ids)
+
+	var sb strings.Builder
+	sb.WriteString("Additional project answers:\n")
+	for _, id := range ids {
+		sb.WriteString(fmt.Sprintf("- %s: %s\n", id, a.Extra[id]))
+	}
+	return strings.TrimRight(sb.String(), "\n")
 }
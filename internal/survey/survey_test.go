@@ -175,3 +175,54 @@ func TestGetProjectQuestions_HasRequiredFields(t *testing.T) {
 		assert.NotEmpty(t, q.Type, "pregunta %d debe tener Type", i)
 	}
 }
+
+// TestNewFollowUpQuestions_CreatesOptionalQuestions verifica que se crean preguntas opcionales.
+func TestNewFollowUpQuestions_CreatesOptionalQuestions(t *testing.T) {
+	questions := NewFollowUpQuestions([]string{"¿Qué proveedor de auth usas?", " ", "¿Dónde se despliega?"})
+
+	require.Len(t, questions, 2)
+	assert.Equal(t, "follow_up_1", questions[0].ID)
+	assert.Equal(t, "follow_up_2", questions[1].ID)
+	assert.False(t, questions[0].Required)
+	assert.Equal(t, QuestionTypeInput, questions[0].Type)
+}
+
+// TestAnswers_FollowUpContext verifica el formato de las respuestas de seguimiento.
+func TestAnswers_FollowUpContext(t *testing.T) {
+	answers := &Answers{}
+	assert.Empty(t, answers.FollowUpContext())
+
+	answers.AddFollowUp("¿Qué proveedor de auth usas?", "Auth0")
+	answers.AddFollowUp("¿Dónde se despliega?", "  ")
+
+	require.Len(t, answers.FollowUps, 1)
+	context := answers.FollowUpContext()
+	assert.Contains(t, context, "¿Qué proveedor de auth usas?")
+	assert.Contains(t, context, "Auth0")
+}
+
+// TestAnswers_ConventionsContext verifica el formato de las convenciones observadas.
+func TestAnswers_ConventionsContext(t *testing.T) {
+	answers := &Answers{}
+	assert.Empty(t, answers.ConventionsContext())
+
+	answers.Conventions = []string{"Table-driven tests", "Errors wrapped with %w"}
+	assert.Equal(t, "Coding conventions observed in the existing code (follow them):\n- Table-driven tests\n- Errors wrapped with %w", answers.ConventionsContext())
+}
+
+// TestAnswers_ListFields_RoundTrip verifica que las listas se serializan y cargan desde el map.
+func TestAnswers_ListFields_RoundTrip(t *testing.T) {
+	answers := &Answers{
+		DocumentationDirs: []string{"docs", "adr"},
+		Packages:          []string{"apps/api", "apps/web"},
+	}
+
+	m := answers.ToMap()
+	assert.Equal(t, "apps/api,apps/web", m["packages"])
+
+	loaded := &Answers{}
+	loaded.FromMap(m)
+	assert.Equal(t, answers.Packages, loaded.Packages)
+	assert.Equal(t, answers.DocumentationDirs, loaded.DocumentationDirs)
+	assert.Empty(t, loaded.Extra)
+}